@@ -39,6 +39,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Environment{},
 		&EnvironmentList{},
+		&GitService{},
+		&GitServiceList{},
 		&PipelineActivity{},
 		&PipelineActivityList{},
 		&Release{},
@@ -3,6 +3,7 @@ package v1
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"strings"
+	"time"
 )
 
 // +genclient
@@ -106,9 +107,197 @@ type EnvironmentRepository struct {
 	Ref  string                    `json:"ref,omitempty" protobuf:"bytes,3,opt,name=ref"`
 }
 
+// TeamSettingsVersion is the current schema version of the team settings so
+// that future format changes can be migrated with 'jx upgrade config'
+const TeamSettingsVersion = 1
+
 type TeamSettings struct {
+	// Version is the schema version of these settings. A missing version means
+	// version 0 which predates the versioned schema
+	Version int `json:"version,omitempty" protobuf:"bytes,7,opt,name=version"`
+
 	UseGitOPs   bool `json:"useGitOps,omitempty" protobuf:"bytes,1,opt,name=useGitOps"`
 	AskOnCreate bool `json:"askOnCreate,omitempty" protobuf:"bytes,1,opt,name=askOnCreate"`
+
+	// ImportExcludes are glob patterns of files which should not be staged in the
+	// initial commit when importing projects
+	ImportExcludes []string `json:"importExcludes,omitempty" protobuf:"bytes,3,opt,name=importExcludes"`
+
+	// ImportDefaults are the team wide defaults applied to projects imported via
+	// jx import so that they comply with the organisation policy
+	ImportDefaults ImportDefaults `json:"importDefaults,omitempty" protobuf:"bytes,4,opt,name=importDefaults"`
+
+	// AgentScheduling configures how the Jenkins build agent pods are scheduled
+	// so that builds can run on cheaper spot / preemptible node pools
+	AgentScheduling AgentScheduling `json:"agentScheduling,omitempty" protobuf:"bytes,5,opt,name=agentScheduling"`
+
+	// ResourceProfiles are the named presets of container resources which
+	// repositories can pick via the resourceProfile entry of their jenkins-x.yml
+	ResourceProfiles []ResourceProfile `json:"resourceProfiles,omitempty" protobuf:"bytes,6,opt,name=resourceProfiles"`
+
+	// ReleaseTrains are the named sets of applications which are versioned and
+	// promoted together
+	ReleaseTrains []ReleaseTrain `json:"releaseTrains,omitempty" protobuf:"bytes,8,opt,name=releaseTrains"`
+
+	// FreezeWindows are the maintenance windows during which promotions to the
+	// matching environments are blocked
+	FreezeWindows []FreezeWindow `json:"freezeWindows,omitempty" protobuf:"bytes,9,opt,name=freezeWindows"`
+
+	// DNS configures the automatic management of DNS records for exposed
+	// services and preview environments
+	DNS DNSSettings `json:"dns,omitempty" protobuf:"bytes,10,opt,name=dns"`
+
+	// PipelinePolicies configures the timeout and retry wrappers applied to
+	// the stages of generated pipelines so that flaky steps such as registry
+	// pushes or helm installs recover automatically
+	PipelinePolicies PipelinePolicies `json:"pipelinePolicies,omitempty" protobuf:"bytes,11,opt,name=pipelinePolicies"`
+}
+
+// PipelinePolicies describes the timeout and retry policies applied to each
+// stage of the pipelines generated for the team
+type PipelinePolicies struct {
+	// StageTimeoutMinutes aborts a stage which runs longer than this many minutes
+	StageTimeoutMinutes int `json:"stageTimeoutMinutes,omitempty" protobuf:"bytes,1,opt,name=stageTimeoutMinutes"`
+	// StageRetryCount retries a failed stage this many times before failing the build
+	StageRetryCount int `json:"stageRetryCount,omitempty" protobuf:"bytes,2,opt,name=stageRetryCount"`
+}
+
+// DNSSettings configures a DNS provider managing the records of the team domain
+type DNSSettings struct {
+	// Provider is the kind of DNS provider such as route53, clouddns, azuredns or cloudflare
+	Provider string `json:"provider,omitempty" protobuf:"bytes,1,opt,name=provider"`
+	// Domain is the team domain the records are created in
+	Domain string `json:"domain,omitempty" protobuf:"bytes,2,opt,name=domain"`
+	// Target is the IP address or host name of the ingress controller the
+	// records point at
+	Target string `json:"target,omitempty" protobuf:"bytes,3,opt,name=target"`
+}
+
+// ReleaseTrain is a named set of applications promoted together at pinned
+// versions such as payments-2024.06
+type ReleaseTrain struct {
+	// Name is the name of the train
+	Name string `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
+	// Apps are the applications on the train with their pinned versions
+	Apps []ReleaseTrainApp `json:"apps,omitempty" protobuf:"bytes,2,opt,name=apps"`
+}
+
+// ReleaseTrainApp is a single application on a release train
+type ReleaseTrainApp struct {
+	// Name is the application name
+	Name string `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
+	// Version is the released version to promote
+	Version string `json:"version,omitempty" protobuf:"bytes,2,opt,name=version"`
+}
+
+// FreezeWindow blocks promotions to a set of environments for a period of time
+// such as a production change freeze over a holiday
+type FreezeWindow struct {
+	// Name is the name of the window
+	Name string `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
+	// Environments are the names of the environments the freeze applies to.
+	// An empty list freezes every environment
+	Environments []string `json:"environments,omitempty" protobuf:"bytes,2,opt,name=environments"`
+	// From is when the freeze starts. A zero time means the freeze has already started
+	From metav1.Time `json:"from,omitempty" protobuf:"bytes,3,opt,name=from"`
+	// To is when the freeze ends. A zero time means the freeze never expires
+	To metav1.Time `json:"to,omitempty" protobuf:"bytes,4,opt,name=to"`
+	// Description says why the freeze is in place
+	Description string `json:"description,omitempty" protobuf:"bytes,5,opt,name=description"`
+	// Overrides record the promotions which were forced through the window
+	Overrides []FreezeOverride `json:"overrides,omitempty" protobuf:"bytes,6,opt,name=overrides"`
+}
+
+// FreezeOverride records a promotion which was forced through a freeze window
+// so that there is an audit trail of who promoted during the freeze and why
+type FreezeOverride struct {
+	// User is the local user who performed the promotion
+	User string `json:"user,omitempty" protobuf:"bytes,1,opt,name=user"`
+	// Reason is why the freeze was overridden
+	Reason string `json:"reason,omitempty" protobuf:"bytes,2,opt,name=reason"`
+	// Time is when the promotion happened
+	Time metav1.Time `json:"time,omitempty" protobuf:"bytes,3,opt,name=time"`
+	// Application is the promoted application
+	Application string `json:"application,omitempty" protobuf:"bytes,4,opt,name=application"`
+	// Version is the promoted version
+	Version string `json:"version,omitempty" protobuf:"bytes,5,opt,name=version"`
+}
+
+// Active returns true if the window blocks promotion to the named environment
+// at the given time
+func (w *FreezeWindow) Active(environment string, t time.Time) bool {
+	if !w.From.IsZero() && t.Before(w.From.Time) {
+		return false
+	}
+	if !w.To.IsZero() && t.After(w.To.Time) {
+		return false
+	}
+	if len(w.Environments) == 0 {
+		return true
+	}
+	for _, name := range w.Environments {
+		if name == environment {
+			return true
+		}
+	}
+	return false
+}
+
+// ResourceProfile is a named preset of container resource requests and limits
+// for the build agent pod templates such as small, medium or large
+type ResourceProfile struct {
+	// Name is the name repositories use to select the profile
+	Name string `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
+	// RequestCpu is the cpu request of each container
+	RequestCpu string `json:"requestCpu,omitempty" protobuf:"bytes,2,opt,name=requestCpu"`
+	// RequestMemory is the memory request of each container
+	RequestMemory string `json:"requestMemory,omitempty" protobuf:"bytes,3,opt,name=requestMemory"`
+	// LimitCpu is the cpu limit of each container
+	LimitCpu string `json:"limitCpu,omitempty" protobuf:"bytes,4,opt,name=limitCpu"`
+	// LimitMemory is the memory limit of each container
+	LimitMemory string `json:"limitMemory,omitempty" protobuf:"bytes,5,opt,name=limitMemory"`
+}
+
+// AgentScheduling describes how the Jenkins build agent pods are scheduled
+type AgentScheduling struct {
+	// UseSpotNodes schedules the build agents onto spot / preemptible nodes
+	UseSpotNodes bool `json:"useSpotNodes,omitempty" protobuf:"bytes,1,opt,name=useSpotNodes"`
+	// NodeSelector is the node selector applied to the agent pod templates in
+	// the key=value,key2=value2 form used by the Jenkins kubernetes plugin
+	NodeSelector string `json:"nodeSelector,omitempty" protobuf:"bytes,2,opt,name=nodeSelector"`
+	// Tolerations are the tolerations applied to the agent pods in the
+	// key=value:Effect form
+	Tolerations []string `json:"tolerations,omitempty" protobuf:"bytes,3,opt,name=tolerations"`
+	// PriorityClass is the priority class name of the agent pods
+	PriorityClass string `json:"priorityClass,omitempty" protobuf:"bytes,4,opt,name=priorityClass"`
+	// EvictionRetryCount makes generated pipelines retry this many times so
+	// that builds survive the eviction of an agent from a preempted node
+	EvictionRetryCount int `json:"evictionRetryCount,omitempty" protobuf:"bytes,5,opt,name=evictionRetryCount"`
+}
+
+// ImportDefaults contains the default import options for a team
+type ImportDefaults struct {
+	// Credentials is the default Jenkins credentials id used by the pipelines
+	Credentials string `json:"credentials,omitempty" protobuf:"bytes,1,opt,name=credentials"`
+	// PrivateRepos creates any new git repositories as private
+	PrivateRepos bool `json:"privateRepos,omitempty" protobuf:"bytes,2,opt,name=privateRepos"`
+	// BranchProtection protects the master branch of new repositories after the import
+	BranchProtection bool `json:"branchProtection,omitempty" protobuf:"bytes,3,opt,name=branchProtection"`
+	// JenkinsfileTemplate is the name of the Jenkinsfile used when a project does not have one
+	JenkinsfileTemplate string `json:"jenkinsfileTemplate,omitempty" protobuf:"bytes,4,opt,name=jenkinsfileTemplate"`
+	// NoDraft disables Draft from defaulting a Dockerfile and helm chart
+	NoDraft bool `json:"noDraft,omitempty" protobuf:"bytes,5,opt,name=noDraft"`
+	// Owners are the default code owners used to seed a CODEOWNERS file into
+	// imported projects and to assign reviewers to their Pull Requests
+	Owners []string `json:"owners,omitempty" protobuf:"bytes,6,opt,name=owners"`
+	// BuildTags discovers and builds tags as well as branches on imported projects
+	BuildTags bool `json:"buildTags,omitempty" protobuf:"bytes,7,opt,name=buildTags"`
+	// ForkPRTrust is the trust policy for building Pull Requests from forks:
+	// none, contributors, permissions or everyone. Defaults to none so that
+	// fork Pull Requests are not built
+	ForkPRTrust string `json:"forkPRTrust,omitempty" protobuf:"bytes,8,opt,name=forkPRTrust"`
+	// OnlyProtectedBranches restricts branch discovery to protected branches
+	OnlyProtectedBranches bool `json:"onlyProtectedBranches,omitempty" protobuf:"bytes,9,opt,name=onlyProtectedBranches"`
 }
 type PreviewGitSpec struct {
 	Name string   `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
@@ -345,3 +534,36 @@ func (i *IssueSummary) IsClosed() bool {
 	return strings.HasPrefix(lower, "clos") || strings.HasPrefix(lower, "fix")
 }
 
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+
+// GitService represents a git provider server so we can configure the kind of git provider
+// for a given URL in the cluster as well as in the local auth configuration
+type GitService struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Spec GitServiceSpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+}
+
+// GitServiceSpec is the specification of an GitService
+type GitServiceSpec struct {
+	GitKind string `json:"gitKind,omitempty" protobuf:"bytes,1,opt,name=gitKind"`
+	URL     string `json:"url,omitempty" protobuf:"bytes,2,opt,name=url"`
+	Name    string `json:"name,omitempty" protobuf:"bytes,3,opt,name=name"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GitServiceList is a list of GitService resources
+type GitServiceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []GitService `json:"items"`
+}
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// encryptedPrefix marks values in the auth config which have been encrypted so
+// that plaintext configs from older versions can be migrated transparently
+const encryptedPrefix = "aes:"
+
+// SecretCipher encrypts and decrypts the secret values stored in the auth
+// config so API tokens are not kept in plaintext on disk
+type SecretCipher interface {
+	// Encrypt returns the encrypted form of the plaintext value
+	Encrypt(plain string) (string, error)
+
+	// Decrypt returns the plaintext of an encrypted value. Values which are
+	// not encrypted are returned unchanged so old configs keep working
+	Decrypt(encrypted string) (string, error)
+}
+
+// IsEncrypted returns true if the value has been encrypted by a SecretCipher
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+type aesCipher struct {
+	key []byte
+}
+
+// NewAESCipher returns a SecretCipher using AES GCM with the given key
+func NewAESCipher(key []byte) (SecretCipher, error) {
+	if len(key) != 16 && len(key) != 24 && len(key) != 32 {
+		return nil, fmt.Errorf("Invalid AES key length %d", len(key))
+	}
+	return &aesCipher{key: key}, nil
+}
+
+func (c *aesCipher) Encrypt(plain string) (string, error) {
+	if plain == "" || IsEncrypted(plain) {
+		return plain, nil
+	}
+	block, err := aes.NewCipher(c.key)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return "", err
+	}
+	data := gcm.Seal(nonce, nonce, []byte(plain), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(data), nil
+}
+
+func (c *aesCipher) Decrypt(encrypted string) (string, error) {
+	if !IsEncrypted(encrypted) {
+		return encrypted, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(encrypted, encryptedPrefix))
+	if err != nil {
+		return "", err
+	}
+	block, err := aes.NewCipher(c.key)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	if len(data) < gcm.NonceSize() {
+		return "", fmt.Errorf("Encrypted value is too short")
+	}
+	nonce := data[0:gcm.NonceSize()]
+	plain, err := gcm.Open(nil, nonce, data[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// encryptUserAuths returns a copy of the config with the secret fields of each
+// user encrypted, leaving the in memory config in plaintext
+func encryptUserAuths(config *AuthConfig, secretCipher SecretCipher) (*AuthConfig, error) {
+	answer := *config
+	answer.Servers = []*AuthServer{}
+	for _, server := range config.Servers {
+		serverCopy := *server
+		serverCopy.Users = []*UserAuth{}
+		for _, user := range server.Users {
+			userCopy := *user
+			var err error
+			userCopy.ApiToken, err = secretCipher.Encrypt(user.ApiToken)
+			if err == nil {
+				userCopy.BearerToken, err = secretCipher.Encrypt(user.BearerToken)
+			}
+			if err == nil {
+				userCopy.Password, err = secretCipher.Encrypt(user.Password)
+			}
+			if err != nil {
+				return nil, err
+			}
+			serverCopy.Users = append(serverCopy.Users, &userCopy)
+		}
+		answer.Servers = append(answer.Servers, &serverCopy)
+	}
+	return &answer, nil
+}
+
+// decryptUserAuths decrypts the secret fields of each user in place. Plaintext
+// values from configs written before encryption was added are left unchanged
+func decryptUserAuths(config *AuthConfig, secretCipher SecretCipher) error {
+	for _, server := range config.Servers {
+		for _, user := range server.Users {
+			var err error
+			user.ApiToken, err = secretCipher.Decrypt(user.ApiToken)
+			if err == nil {
+				user.BearerToken, err = secretCipher.Decrypt(user.BearerToken)
+			}
+			if err == nil {
+				user.Password, err = secretCipher.Decrypt(user.Password)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
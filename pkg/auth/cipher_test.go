@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAESCipherRoundTrip(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	cipher, err := NewAESCipher(key)
+	assert.NoError(t, err)
+
+	encrypted, err := cipher.Encrypt("myToken")
+	assert.NoError(t, err)
+	assert.True(t, IsEncrypted(encrypted), "Encrypt should add the encrypted prefix but got %s", encrypted)
+	assert.NotContains(t, encrypted, "myToken")
+
+	plain, err := cipher.Decrypt(encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, "myToken", plain)
+
+	// plaintext values from old configs are returned unchanged
+	plain, err = cipher.Decrypt("legacyToken")
+	assert.NoError(t, err)
+	assert.Equal(t, "legacyToken", plain)
+
+	// empty values stay empty
+	encrypted, err = cipher.Encrypt("")
+	assert.NoError(t, err)
+	assert.Equal(t, "", encrypted)
+}
+
+func TestAuthConfigServiceEncryptsSecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("/tmp", "jx-test-auth-cipher-")
+	assert.NoError(t, err)
+
+	fileName := filepath.Join(dir, "gitAuth.yaml")
+	key := []byte("0123456789abcdef0123456789abcdef")
+	cipher, err := NewAESCipher(key)
+	assert.NoError(t, err)
+
+	svc := AuthConfigService{
+		FileName: fileName,
+		Cipher:   cipher,
+	}
+	err = svc.SaveUserAuth(url1, &UserAuth{
+		Username: user1,
+		ApiToken: "secretToken",
+	})
+	assert.NoError(t, err)
+
+	// the in memory config keeps the plaintext token
+	assert.Equal(t, "secretToken", svc.Config().FindUserAuth(url1, user1).ApiToken)
+
+	// the file on disk must not contain the plaintext token
+	data, err := ioutil.ReadFile(fileName)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "secretToken")
+
+	// loading the config decrypts the token again
+	loaded := AuthConfigService{
+		FileName: fileName,
+		Cipher:   cipher,
+	}
+	config, err := loaded.LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "secretToken", config.FindUserAuth(url1, user1).ApiToken)
+
+	// a plaintext config from an older version loads unchanged
+	err = ioutil.WriteFile(fileName, []byte("servers:\n- url: "+url1+"\n  users:\n  - username: "+user1+"\n    apitoken: oldToken\n"), DefaultWritePermissions)
+	assert.NoError(t, err)
+	migrated := AuthConfigService{
+		FileName: fileName,
+		Cipher:   cipher,
+	}
+	config, err = migrated.LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "oldToken", config.FindUserAuth(url1, user1).ApiToken)
+}
@@ -166,6 +166,9 @@ func (c *AuthConfig) PickServer(message string) (*AuthServer, error) {
 func (c *AuthConfig) PickServerUserAuth(server *AuthServer, message string, batchMode bool) (*UserAuth, error) {
 	url := server.URL
 	userAuths := c.FindUserAuths(url)
+	if batchMode && len(userAuths) == 0 {
+		return &UserAuth{}, fmt.Errorf("Server %s has no user authentication configured. Please configure a user and API token before running in batch mode", url)
+	}
 	if len(userAuths) == 1 {
 		auth := userAuths[0]
 		if batchMode {
@@ -196,6 +199,15 @@ func (c *AuthConfig) PickServerUserAuth(server *AuthServer, message string, batc
 		return c.GetOrCreateUserAuth(url, username), nil
 	}
 	if len(userAuths) > 1 {
+		if batchMode {
+			if server.CurrentUser != "" {
+				ua := c.FindUserAuth(url, server.CurrentUser)
+				if ua != nil {
+					return ua, nil
+				}
+			}
+			return userAuths[0], nil
+		}
 		usernames := []string{}
 		m := map[string]*UserAuth{}
 		for _, ua := range userAuths {
@@ -34,6 +34,15 @@ func (s *AuthConfigService) LoadConfig() (*AuthConfig, error) {
 			if err != nil {
 				return config, fmt.Errorf("Failed to unmarshal YAML file %s due to %s", fileName, err)
 			}
+			if config.Version > AuthConfigVersion {
+				return config, fmt.Errorf("%s has schema version %d but this version of jx only supports up to version %d. Please upgrade jx", fileName, config.Version, AuthConfigVersion)
+			}
+			if s.Cipher != nil {
+				err = decryptUserAuths(config, s.Cipher)
+				if err != nil {
+					return config, fmt.Errorf("Failed to decrypt the secrets in %s due to %s", fileName, err)
+				}
+			}
 		}
 	}
 	return config, nil
@@ -45,7 +54,15 @@ func (s *AuthConfigService) SaveConfig() error {
 	if fileName == "" {
 		return fmt.Errorf("No filename defined!")
 	}
-	data, err := yaml.Marshal(s.config)
+	config := &s.config
+	if s.Cipher != nil {
+		encrypted, err := encryptUserAuths(config, s.Cipher)
+		if err != nil {
+			return fmt.Errorf("Failed to encrypt the secrets for %s due to %s", fileName, err)
+		}
+		config = encrypted
+	}
+	data, err := yaml.Marshal(config)
 	if err != nil {
 		return err
 	}
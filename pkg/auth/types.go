@@ -21,6 +21,11 @@ type UserAuth struct {
 }
 
 type AuthConfig struct {
+	// Version is the schema version of this file so that future format changes
+	// can be migrated with 'jx upgrade config'. A missing version means
+	// version 0 which predates the versioned schema
+	Version int `yaml:"version,omitempty"`
+
 	Servers []*AuthServer
 
 	DefaultUsername string
@@ -29,5 +34,10 @@ type AuthConfig struct {
 
 type AuthConfigService struct {
 	FileName string
-	config   AuthConfig
+
+	// Cipher encrypts the secret values when saving the config and decrypts
+	// them on load. When nil the config is stored in plaintext as before
+	Cipher SecretCipher
+
+	config AuthConfig
 }
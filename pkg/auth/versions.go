@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"fmt"
+)
+
+// AuthConfigVersion is the current schema version of the auth configuration
+// files. Bump this and append a migration to authConfigMigrations whenever the
+// format changes in a way which needs existing files rewriting
+const AuthConfigVersion = 1
+
+// authConfigMigrations upgrade an older auth configuration to the current
+// schema. The migration at index i upgrades a configuration from version i to i+1
+var authConfigMigrations = []func(c *AuthConfig) error{
+	// 0 -> 1: the first versioned schema. The layout is unchanged, only the
+	// version marker is added
+	func(c *AuthConfig) error {
+		return nil
+	},
+}
+
+// Migrate upgrades the configuration to the current schema version returning
+// whether anything was changed so that callers know to save the file
+func (c *AuthConfig) Migrate() (bool, error) {
+	if c.Version > AuthConfigVersion {
+		return false, fmt.Errorf("The configuration has schema version %d but this version of jx only supports up to version %d. Please upgrade jx", c.Version, AuthConfigVersion)
+	}
+	changed := false
+	for c.Version < AuthConfigVersion {
+		err := authConfigMigrations[c.Version](c)
+		if err != nil {
+			return changed, fmt.Errorf("Failed to migrate the configuration from schema version %d to %d due to: %s", c.Version, c.Version+1, err)
+		}
+		c.Version++
+		changed = true
+	}
+	return changed, nil
+}
@@ -0,0 +1,127 @@
+package buildpacks
+
+import (
+	"path/filepath"
+)
+
+// BuildPack describes how projects of one language are detected and built
+type BuildPack struct {
+	// Language is the name of the language or platform
+	Language string
+
+	// Markers are the file names whose presence in a project identifies the
+	// language; glob patterns such as *.csproj are supported
+	Markers []string
+
+	// DraftPack is passed to draft create --pack when scaffolding the charts;
+	// when empty draft falls back to its own pack detection
+	DraftPack string
+
+	// AgentLabel is the pod template label the generated Jenkinsfile uses
+	AgentLabel string
+
+	// Container is the container of the agent pod which runs the build steps
+	Container string
+
+	// BuildCommand builds the project inside the container
+	BuildCommand string
+}
+
+// javaDraftPack is the draft pack used for JVM based projects
+const javaDraftPack = "github.com/jenkins-x/draft-repo/packs/java"
+
+// buildPacks are the build packs in detection order; packs registered via
+// Register are tried before the built in ones
+var buildPacks = []*BuildPack{
+	{
+		Language:     "maven",
+		Markers:      []string{"pom.xml"},
+		DraftPack:    javaDraftPack,
+		AgentLabel:   "jenkins-maven",
+		Container:    "maven",
+		BuildCommand: "mvn install",
+	},
+	{
+		Language:     "gradle",
+		Markers:      []string{"build.gradle"},
+		DraftPack:    javaDraftPack,
+		AgentLabel:   "jenkins-gradle",
+		Container:    "gradle",
+		BuildCommand: "gradle build",
+	},
+	{
+		Language:     "nodejs",
+		Markers:      []string{"package.json"},
+		AgentLabel:   "jenkins-nodejs",
+		Container:    "nodejs",
+		BuildCommand: "npm install",
+	},
+	{
+		Language:     "go",
+		Markers:      []string{"go.mod", "Gopkg.toml"},
+		AgentLabel:   "jenkins-go",
+		Container:    "go",
+		BuildCommand: "go build ./...",
+	},
+	{
+		Language:     "python",
+		Markers:      []string{"requirements.txt", "setup.py"},
+		AgentLabel:   "jenkins-python",
+		Container:    "python",
+		BuildCommand: "pip install -r requirements.txt",
+	},
+	{
+		Language:     "ruby",
+		Markers:      []string{"Gemfile"},
+		AgentLabel:   "jenkins-ruby",
+		Container:    "ruby",
+		BuildCommand: "bundle install",
+	},
+	{
+		Language:     "csharp",
+		Markers:      []string{"*.csproj"},
+		AgentLabel:   "jenkins-dotnet",
+		Container:    "dotnet",
+		BuildCommand: "dotnet build",
+	},
+}
+
+// Register adds a custom build pack which is tried before the built in ones
+func Register(pack *BuildPack) {
+	buildPacks = append([]*BuildPack{pack}, buildPacks...)
+}
+
+// BuildPacks returns the registered build packs in detection order
+func BuildPacks() []*BuildPack {
+	return buildPacks
+}
+
+// Detect returns the build pack matching the project in the given directory
+// or nil if no pack matches
+func Detect(dir string) (*BuildPack, error) {
+	for _, pack := range buildPacks {
+		matches, err := pack.Matches(dir)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			return pack, nil
+		}
+	}
+	return nil, nil
+}
+
+// Matches returns true if the directory contains one of the marker files of
+// this build pack
+func (p *BuildPack) Matches(dir string) (bool, error) {
+	for _, marker := range p.Markers {
+		names, err := filepath.Glob(filepath.Join(dir, marker))
+		if err != nil {
+			return false, err
+		}
+		if len(names) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
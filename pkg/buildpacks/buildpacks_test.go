@@ -0,0 +1,45 @@
+package buildpacks
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetect(t *testing.T) {
+	assertDetectLanguage(t, "maven", "pom.xml")
+	assertDetectLanguage(t, "gradle", "build.gradle")
+	assertDetectLanguage(t, "nodejs", "package.json")
+	assertDetectLanguage(t, "go", "go.mod")
+	assertDetectLanguage(t, "python", "requirements.txt")
+	assertDetectLanguage(t, "ruby", "Gemfile")
+	assertDetectLanguage(t, "csharp", "myapp.csproj")
+}
+
+func TestDetectNoMatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jx-buildpacks-test-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	pack, err := Detect(dir)
+	assert.Nil(t, err)
+	assert.Nil(t, pack, "Expected no build pack for an empty directory")
+}
+
+func assertDetectLanguage(t *testing.T, language string, marker string) {
+	dir, err := ioutil.TempDir("", "jx-buildpacks-test-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, marker), []byte("dummy"), 0644)
+	assert.Nil(t, err)
+
+	pack, err := Detect(dir)
+	assert.Nil(t, err)
+	if assert.NotNil(t, pack, "Expected a build pack for marker %s", marker) {
+		assert.Equal(t, language, pack.Language, "Detected the wrong language for marker %s", marker)
+	}
+}
@@ -6,4 +6,6 @@ type EnvironmentExpansion interface{}
 
 type PipelineActivityExpansion interface{}
 
+type GitServiceExpansion interface{}
+
 type ReleaseExpansion interface{}
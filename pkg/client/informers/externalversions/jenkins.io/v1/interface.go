@@ -14,6 +14,8 @@ type Interface interface {
 	Environments() EnvironmentInformer
 	// PipelineActivities returns a PipelineActivityInformer.
 	PipelineActivities() PipelineActivityInformer
+	// GitServices returns a GitServiceInformer.
+	GitServices() GitServiceInformer
 	// Releases returns a ReleaseInformer.
 	Releases() ReleaseInformer
 }
@@ -39,6 +41,11 @@ func (v *version) PipelineActivities() PipelineActivityInformer {
 	return &pipelineActivityInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// GitServices returns a GitServiceInformer.
+func (v *version) GitServices() GitServiceInformer {
+	return &gitserviceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Releases returns a ReleaseInformer.
 func (v *version) Releases() ReleaseInformer {
 	return &releaseInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -20,6 +20,14 @@ type PipelineActivityListerExpansion interface{}
 // PipelineActivityNamespaceLister.
 type PipelineActivityNamespaceListerExpansion interface{}
 
+// GitServiceListerExpansion allows custom methods to be added to
+// GitServiceLister.
+type GitServiceListerExpansion interface{}
+
+// GitServiceNamespaceListerExpansion allows custom methods to be added to
+// GitServiceNamespaceLister.
+type GitServiceNamespaceListerExpansion interface{}
+
 // ReleaseListerExpansion allows custom methods to be added to
 // ReleaseLister.
 type ReleaseListerExpansion interface{}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultLabelsFileName is the default name of the labels configuration file
+const DefaultLabelsFileName = "labels.yaml"
+
+// LabelsConfig declares the standard label set to sync across repositories and
+// the path mappings used to label Pull Requests by area
+type LabelsConfig struct {
+	// Labels are the labels to create on each repository
+	Labels []LabelConfig `yaml:"labels,omitempty"`
+	// Areas map path prefixes to area labels so that Pull Requests touching
+	// those paths are labelled accordingly
+	Areas []AreaConfig `yaml:"areas,omitempty"`
+}
+
+// LabelConfig declares one label with its colour
+type LabelConfig struct {
+	Name  string `yaml:"name"`
+	Color string `yaml:"color,omitempty"`
+}
+
+// AreaConfig maps paths to an area label
+type AreaConfig struct {
+	// Name is the area label such as area/docs
+	Name string `yaml:"name"`
+	// Paths are the path patterns of the area such as docs/
+	Paths []string `yaml:"paths"`
+}
+
+// DefaultLabelsConfig returns the standard label set used when no labels
+// configuration file is provided
+func DefaultLabelsConfig() *LabelsConfig {
+	return &LabelsConfig{
+		Labels: []LabelConfig{
+			{Name: "kind/feature", Color: "c7def8"},
+			{Name: "kind/bug", Color: "ee0701"},
+			{Name: "kind/chore", Color: "c2e0c6"},
+			{Name: "kind/docs", Color: "fbca04"},
+			{Name: "kind/refactor", Color: "fef2c0"},
+			{Name: "kind/test", Color: "d4c5f9"},
+			{Name: "size/XS", Color: "009900"},
+			{Name: "size/S", Color: "77bb00"},
+			{Name: "size/M", Color: "eebb00"},
+			{Name: "size/L", Color: "ee9900"},
+			{Name: "size/XL", Color: "ee5500"},
+		},
+	}
+}
+
+// LoadLabelsConfig loads the labels configuration from the given file falling
+// back to the default label set when the file does not exist
+func LoadLabelsConfig(fileName string) (*LabelsConfig, error) {
+	exists, err := util.FileExists(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return DefaultLabelsConfig(), nil
+	}
+	config := &LabelsConfig{}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return config, fmt.Errorf("Failed to load %s due to %s", fileName, err)
+	}
+	err = yaml.Unmarshal(data, config)
+	if err != nil {
+		return config, fmt.Errorf("Failed to unmarshal %s due to %s", fileName, err)
+	}
+	for _, label := range config.Labels {
+		if label.Name == "" {
+			return config, fmt.Errorf("Missing name for a label in %s", fileName)
+		}
+	}
+	for _, area := range config.Areas {
+		if area.Name == "" {
+			return config, fmt.Errorf("Missing name for an area in %s", fileName)
+		}
+		if len(area.Paths) == 0 {
+			return config, fmt.Errorf("Missing paths for the area %s in %s", area.Name, fileName)
+		}
+	}
+	return config, nil
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+// PreviewDependenciesFileName is the name of the file in a project which declares
+// the ephemeral dependencies to provision for each preview environment
+const PreviewDependenciesFileName = "preview-dependencies.yaml"
+
+// PreviewConfig configures the preview environments of a project declared in
+// the jenkins-x.yml file
+type PreviewConfig struct {
+	// Dependencies are the charts installed into the preview namespace
+	// alongside the application. A dependency without a chart refers to
+	// another application built by the team so that whole service graphs can
+	// be previewed together at pinned versions
+	Dependencies []*PreviewDependency `yaml:"dependencies"`
+}
+
+// PreviewDependenciesConfig declares the ephemeral dependencies such as databases
+// which are provisioned into the preview namespace for each Pull Request and are
+// torn down along with the preview
+type PreviewDependenciesConfig struct {
+	Dependencies []*PreviewDependency `yaml:"dependencies"`
+}
+
+// PreviewDependency describes a single chart to install into the preview namespace
+type PreviewDependency struct {
+	// Name is used to generate the release name inside the preview namespace
+	Name string `yaml:"name"`
+	// Chart is the helm chart to install such as stable/postgresql
+	Chart string `yaml:"chart"`
+	// Version is the chart version. If blank the latest version is used
+	Version string `yaml:"version,omitempty"`
+	// Fixtures is an optional file of fixtures which is made available to the
+	// dependency as a ConfigMap so that database charts can seed from it
+	Fixtures string `yaml:"fixtures,omitempty"`
+	// Values are passed to the chart install as --set values
+	Values map[string]string `yaml:"values,omitempty"`
+	// Env are environment variables describing the connection details which are
+	// wired into the preview deployment of the application
+	Env map[string]string `yaml:"env,omitempty"`
+}
+
+// LoadPreviewDependenciesConfig loads the preview dependencies configuration from
+// the given project directory if the file exists
+func LoadPreviewDependenciesConfig(dir string) (*PreviewDependenciesConfig, error) {
+	config := &PreviewDependenciesConfig{}
+	fileName := filepath.Join(dir, PreviewDependenciesFileName)
+	exists, err := util.FileExists(fileName)
+	if err != nil || !exists {
+		return config, err
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return config, fmt.Errorf("Failed to load %s due to %s", fileName, err)
+	}
+	err = yaml.Unmarshal(data, config)
+	if err != nil {
+		return config, fmt.Errorf("Failed to unmarshal %s due to %s", fileName, err)
+	}
+	for _, dependency := range config.Dependencies {
+		if dependency.Name == "" {
+			return config, fmt.Errorf("Missing name for a dependency in %s", fileName)
+		}
+		if dependency.Chart == "" {
+			return config, fmt.Errorf("Missing chart for the dependency %s in %s", dependency.Name, fileName)
+		}
+	}
+	return config, nil
+}
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+// ProjectConfigFileName is the name of the project configuration file in the
+// root of an application git repository
+const ProjectConfigFileName = "jenkins-x.yml"
+
+// ProjectConfig is the per project configuration for the pipelines of an
+// application loaded from the jenkins-x.yml file in the repository root
+type ProjectConfig struct {
+	// Version is the schema version of this file so that future format changes
+	// can be migrated with 'jx upgrade config'. A missing version means
+	// version 0 which predates the versioned schema
+	Version int `yaml:"version,omitempty"`
+
+	// E2E configures the end to end test suite of the project which is run
+	// against a disposable environment on Pull Requests
+	E2E *E2EConfig `yaml:"e2e,omitempty"`
+
+	// Matrix configures a build matrix so that the project is built across
+	// several toolchain versions or platforms in parallel stages
+	Matrix *MatrixConfig `yaml:"matrix,omitempty"`
+
+	// Preview configures the preview environments of the project such as the
+	// dependent services deployed alongside the application
+	Preview *PreviewConfig `yaml:"preview,omitempty"`
+
+	// Trigger configures path filters so that pipelines only run when relevant
+	// files have changed
+	Trigger *TriggerConfig `yaml:"trigger,omitempty"`
+
+	// ResourceProfile selects one of the named resource profiles of the team
+	// such as small, medium or large so that the build agents of this project
+	// get the matching container requests and limits
+	ResourceProfile string `yaml:"resourceProfile,omitempty"`
+}
+
+// TriggerConfig declares path filters which decide whether a pipeline should
+// run for a change. A pipeline runs when at least one changed file matches the
+// paths after removing the files matching the ignored paths
+type TriggerConfig struct {
+	// Paths are the path patterns which trigger the pipeline such as src/ or
+	// docs/. If empty then every path triggers
+	Paths []string `yaml:"paths,omitempty"`
+	// IgnorePaths are the path patterns which never trigger the pipeline such
+	// as *.md so that documentation only changes skip CI
+	IgnorePaths []string `yaml:"ignorePaths,omitempty"`
+}
+
+// E2EConfig declares how to run the end to end test suite of a project
+type E2EConfig struct {
+	// Command is the shell command which runs the test suite. The namespace of
+	// the disposable test environment is available as $E2E_NAMESPACE
+	Command string `yaml:"command"`
+	// Dependencies are the charts to install into the disposable test
+	// namespace before the application such as databases
+	Dependencies []*PreviewDependency `yaml:"dependencies,omitempty"`
+	// TimeoutMinutes is the maximum time to wait for the test environment to
+	// become ready. Defaults to 10 minutes
+	TimeoutMinutes int `yaml:"timeoutMinutes,omitempty"`
+}
+
+// MatrixConfig declares the axes of a build matrix such as the JDK or Node
+// versions to build the project with. Each axis becomes a parallel stage in the
+// generated pipeline with its own pod template
+type MatrixConfig struct {
+	// Command is the shell command which builds the project. It can be
+	// overridden per axis
+	Command string `yaml:"command"`
+	// Axes are the variants to build in parallel
+	Axes []*MatrixAxis `yaml:"axes"`
+}
+
+// MatrixAxis is one variant of a build matrix such as a single JDK version
+type MatrixAxis struct {
+	// Name is the display name of the stage such as java8
+	Name string `yaml:"name"`
+	// Label is the pod template label to build this axis with such as
+	// jenkins-maven-java11
+	Label string `yaml:"label"`
+	// Container is the container of the pod template to run the build command
+	// in. Defaults to the label without any jenkins- prefix
+	Container string `yaml:"container,omitempty"`
+	// Command overrides the matrix build command for this axis
+	Command string `yaml:"command,omitempty"`
+}
+
+// LoadProjectConfig loads the project configuration from the given project
+// directory returning an empty configuration if the file does not exist
+func LoadProjectConfig(dir string) (*ProjectConfig, error) {
+	config := &ProjectConfig{}
+	fileName := filepath.Join(dir, ProjectConfigFileName)
+	exists, err := util.FileExists(fileName)
+	if err != nil || !exists {
+		return config, err
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return config, fmt.Errorf("Failed to load %s due to %s", fileName, err)
+	}
+	err = yaml.Unmarshal(data, config)
+	if err != nil {
+		return config, fmt.Errorf("Failed to unmarshal %s due to %s", fileName, err)
+	}
+	if config.Version > ProjectConfigVersion {
+		return config, fmt.Errorf("%s has schema version %d but this version of jx only supports up to version %d. Please upgrade jx", fileName, config.Version, ProjectConfigVersion)
+	}
+	if config.Matrix != nil {
+		if config.Matrix.Command == "" {
+			return config, fmt.Errorf("Missing command for the matrix configuration in %s", fileName)
+		}
+		if len(config.Matrix.Axes) == 0 {
+			return config, fmt.Errorf("No axes declared for the matrix configuration in %s", fileName)
+		}
+		for _, axis := range config.Matrix.Axes {
+			if axis.Name == "" {
+				return config, fmt.Errorf("Missing name for a matrix axis in %s", fileName)
+			}
+			if axis.Label == "" {
+				return config, fmt.Errorf("Missing label for the matrix axis %s in %s", axis.Name, fileName)
+			}
+		}
+	}
+	if config.Preview != nil {
+		for _, dependency := range config.Preview.Dependencies {
+			if dependency.Name == "" {
+				return config, fmt.Errorf("Missing name for a preview dependency in %s", fileName)
+			}
+		}
+	}
+	if config.E2E != nil {
+		if config.E2E.Command == "" {
+			return config, fmt.Errorf("Missing command for the e2e configuration in %s", fileName)
+		}
+		for _, dependency := range config.E2E.Dependencies {
+			if dependency.Name == "" {
+				return config, fmt.Errorf("Missing name for an e2e dependency in %s", fileName)
+			}
+			if dependency.Chart == "" {
+				return config, fmt.Errorf("Missing chart for the e2e dependency %s in %s", dependency.Name, fileName)
+			}
+		}
+	}
+	return config, nil
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+// TeamBootConfigFileName is the default name of the declarative team
+// onboarding file used by jx boot
+const TeamBootConfigFileName = "team.yaml"
+
+// TeamBootConfig declares everything needed to onboard a new team so that a
+// whole business unit can be set up from one file in a bootstrap repository
+type TeamBootConfig struct {
+	// Team is the name of the team. It becomes the team namespace
+	Team string `yaml:"team"`
+
+	// SharedJenkins registers the team with the shared Jenkins master instead
+	// of provisioning a dedicated one
+	SharedJenkins bool `yaml:"sharedJenkins,omitempty"`
+
+	// GitServers are the git servers to register for the team
+	GitServers []TeamGitServer `yaml:"gitServers,omitempty"`
+
+	// Environments are additional Environments to create beyond the default
+	// staging and production
+	Environments []TeamEnvironment `yaml:"environments,omitempty"`
+
+	// Addons are the addons to install such as gitea or cdx
+	Addons []string `yaml:"addons,omitempty"`
+
+	// Policies are the team settings applied to the imported projects and
+	// generated pipelines
+	Policies *TeamPolicies `yaml:"policies,omitempty"`
+
+	// Repositories are the git URLs of the initial projects to import
+	Repositories []string `yaml:"repositories,omitempty"`
+}
+
+// TeamGitServer declares a git server to register for the team
+type TeamGitServer struct {
+	// Name is the display name of the server. Defaults to the kind
+	Name string `yaml:"name,omitempty"`
+	// Kind is the git provider kind such as github, gitea or bitbucketserver
+	Kind string `yaml:"kind"`
+	// URL is the base URL of the git server
+	URL string `yaml:"url"`
+}
+
+// TeamEnvironment declares an additional Environment of the team
+type TeamEnvironment struct {
+	// Name is the name of the Environment such as preprod
+	Name string `yaml:"name"`
+	// Namespace is the namespace the Environment deploys into. Defaults to
+	// <team>-<name>
+	Namespace string `yaml:"namespace,omitempty"`
+	// PromotionStrategy is either Auto or Manual. Defaults to Manual
+	PromotionStrategy string `yaml:"promotionStrategy,omitempty"`
+	// Order sorts the Environments in the promotion pipeline
+	Order int32 `yaml:"order,omitempty"`
+}
+
+// TeamPolicies are the team settings applied during onboarding
+type TeamPolicies struct {
+	// BranchProtection protects the master branch of imported repositories
+	BranchProtection bool `yaml:"branchProtection,omitempty"`
+	// PrivateRepos creates any new git repositories as private
+	PrivateRepos bool `yaml:"privateRepos,omitempty"`
+	// Owners are the default code owners of imported projects
+	Owners []string `yaml:"owners,omitempty"`
+	// StageTimeoutMinutes aborts any pipeline stage running longer than this
+	StageTimeoutMinutes int `yaml:"stageTimeoutMinutes,omitempty"`
+	// StageRetryCount retries failed pipeline stages this many times
+	StageRetryCount int `yaml:"stageRetryCount,omitempty"`
+}
+
+// LoadTeamBootConfig loads the team onboarding configuration from the file
+func LoadTeamBootConfig(fileName string) (*TeamBootConfig, error) {
+	config := &TeamBootConfig{}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return config, fmt.Errorf("Failed to load %s due to %s", fileName, err)
+	}
+	err = yaml.Unmarshal(data, config)
+	if err != nil {
+		return config, fmt.Errorf("Failed to unmarshal %s due to %s", fileName, err)
+	}
+	if config.Team == "" {
+		return config, fmt.Errorf("Missing team name in %s", fileName)
+	}
+	for _, server := range config.GitServers {
+		if server.Kind == "" || server.URL == "" {
+			return config, fmt.Errorf("Each git server in %s requires a kind and a url", fileName)
+		}
+	}
+	for _, env := range config.Environments {
+		if env.Name == "" {
+			return config, fmt.Errorf("Each environment in %s requires a name", fileName)
+		}
+	}
+	return config, nil
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTeamBootConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jx-team-boot-test-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, TeamBootConfigFileName)
+	yaml := `team: payments
+gitServers:
+- kind: gitea
+  url: https://gitea.example.com
+environments:
+- name: preprod
+  promotionStrategy: Auto
+  order: 150
+addons:
+- gitea
+policies:
+  branchProtection: true
+  stageRetryCount: 2
+repositories:
+- https://github.com/myorg/payments-api.git
+`
+	err = ioutil.WriteFile(fileName, []byte(yaml), 0644)
+	assert.NoError(t, err)
+
+	config, err := LoadTeamBootConfig(fileName)
+	assert.NoError(t, err)
+	assert.Equal(t, "payments", config.Team)
+	assert.Len(t, config.GitServers, 1)
+	assert.Equal(t, "gitea", config.GitServers[0].Kind)
+	assert.Len(t, config.Environments, 1)
+	assert.Equal(t, "preprod", config.Environments[0].Name)
+	assert.NotNil(t, config.Policies)
+	assert.True(t, config.Policies.BranchProtection)
+	assert.Equal(t, 2, config.Policies.StageRetryCount)
+	assert.Equal(t, []string{"https://github.com/myorg/payments-api.git"}, config.Repositories)
+}
+
+func TestLoadTeamBootConfigValidation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jx-team-boot-test-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, TeamBootConfigFileName)
+	err = ioutil.WriteFile(fileName, []byte("gitServers:\n- kind: gitea\n  url: https://gitea.example.com\n"), 0644)
+	assert.NoError(t, err)
+
+	_, err = LoadTeamBootConfig(fileName)
+	assert.Error(t, err, "a configuration without a team name should not load")
+
+	err = ioutil.WriteFile(fileName, []byte("team: payments\ngitServers:\n- kind: gitea\n"), 0644)
+	assert.NoError(t, err)
+
+	_, err = LoadTeamBootConfig(fileName)
+	assert.Error(t, err, "a git server without a url should not load")
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+// ProjectConfigVersion is the current schema version of the jenkins-x.yml file.
+// Bump this and append a migration to projectConfigMigrations whenever the
+// format changes in a way which needs existing files rewriting
+const ProjectConfigVersion = 1
+
+// projectConfigMigrations upgrade an older jenkins-x.yml to the current schema.
+// The migration at index i upgrades a configuration from version i to i+1
+var projectConfigMigrations = []func(c *ProjectConfig) error{
+	// 0 -> 1: the first versioned schema. The layout is unchanged, only the
+	// version marker is added
+	func(c *ProjectConfig) error {
+		return nil
+	},
+}
+
+// Migrate upgrades the configuration to the current schema version returning
+// whether anything was changed so that callers know to save the file
+func (c *ProjectConfig) Migrate() (bool, error) {
+	if c.Version > ProjectConfigVersion {
+		return false, fmt.Errorf("The configuration has schema version %d but this version of jx only supports up to version %d. Please upgrade jx", c.Version, ProjectConfigVersion)
+	}
+	changed := false
+	for c.Version < ProjectConfigVersion {
+		err := projectConfigMigrations[c.Version](c)
+		if err != nil {
+			return changed, fmt.Errorf("Failed to migrate the configuration from schema version %d to %d due to: %s", c.Version, c.Version+1, err)
+		}
+		c.Version++
+		changed = true
+	}
+	return changed, nil
+}
+
+// SaveProjectConfig writes the project configuration to the jenkins-x.yml file
+// in the given project directory
+func SaveProjectConfig(config *ProjectConfig, dir string) error {
+	fileName := filepath.Join(dir, ProjectConfigFileName)
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("Failed to marshal the configuration of %s due to %s", fileName, err)
+	}
+	err = ioutil.WriteFile(fileName, data, util.DefaultWritePermissions)
+	if err != nil {
+		return fmt.Errorf("Failed to save %s due to %s", fileName, err)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateProjectConfig(t *testing.T) {
+	config := &ProjectConfig{}
+	changed, err := config.Migrate()
+	assert.NoError(t, err)
+	assert.True(t, changed, "migrating an unversioned configuration should change it")
+	assert.Equal(t, ProjectConfigVersion, config.Version)
+
+	changed, err = config.Migrate()
+	assert.NoError(t, err)
+	assert.False(t, changed, "migrating a current configuration should not change it")
+}
+
+func TestMigrateProjectConfigFromNewerVersion(t *testing.T) {
+	config := &ProjectConfig{
+		Version: ProjectConfigVersion + 1,
+	}
+	_, err := config.Migrate()
+	assert.Error(t, err, "a configuration from a newer version of jx should not migrate")
+}
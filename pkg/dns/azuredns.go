@@ -0,0 +1,94 @@
+package dns
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// AzureDNSProvider manages records in Azure DNS via the az CLI so that it
+// reuses the local az credentials
+type AzureDNSProvider struct {
+}
+
+// NewAzureDNSProvider creates the provider
+func NewAzureDNSProvider() *AzureDNSProvider {
+	return &AzureDNSProvider{}
+}
+
+// Kind returns the provider kind
+func (p *AzureDNSProvider) Kind() string {
+	return KindAzureDNS
+}
+
+// EnsureRecord creates or updates the record of the host
+func (p *AzureDNSProvider) EnsureRecord(domain string, host string, target string) error {
+	group, err := p.resourceGroup(domain)
+	if err != nil {
+		return err
+	}
+	name := relativeName(host, domain)
+	var args []string
+	if RecordType(target) == "A" {
+		args = []string{"network", "dns", "record-set", "a", "add-record",
+			"--resource-group", group, "--zone-name", domain, "--record-set-name", name, "--ipv4-address", target}
+	} else {
+		args = []string{"network", "dns", "record-set", "cname", "set-record",
+			"--resource-group", group, "--zone-name", domain, "--record-set-name", name, "--cname", target}
+	}
+	_, err = util.GetCommandOutput("", "az", args...)
+	if err != nil {
+		return fmt.Errorf("Failed to update the record of %s due to: %s", host, err)
+	}
+	return nil
+}
+
+// RemoveRecord removes the record of the host
+func (p *AzureDNSProvider) RemoveRecord(domain string, host string) error {
+	group, err := p.resourceGroup(domain)
+	if err != nil {
+		return err
+	}
+	name := relativeName(host, domain)
+	query := fmt.Sprintf("[?name=='%s'].type", name)
+	output, err := util.GetCommandOutput("", "az", "network", "dns", "record-set", "list",
+		"--resource-group", group, "--zone-name", domain, "--query", query, "--output", "tsv")
+	if err != nil {
+		return fmt.Errorf("Failed to find the record of %s due to: %s", host, err)
+	}
+	recordType := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(output), "Microsoft.Network/dnszones/"))
+	if recordType == "" {
+		return nil
+	}
+	_, err = util.GetCommandOutput("", "az", "network", "dns", "record-set", recordType, "delete",
+		"--resource-group", group, "--zone-name", domain, "--name", name, "--yes")
+	if err != nil {
+		return fmt.Errorf("Failed to remove the record of %s due to: %s", host, err)
+	}
+	return nil
+}
+
+// resourceGroup returns the resource group containing the DNS zone of the domain
+func (p *AzureDNSProvider) resourceGroup(domain string) (string, error) {
+	query := fmt.Sprintf("[?name=='%s'].resourceGroup", domain)
+	output, err := util.GetCommandOutput("", "az", "network", "dns", "zone", "list",
+		"--query", query, "--output", "tsv")
+	if err != nil {
+		return "", fmt.Errorf("Failed to find the Azure DNS zone of %s due to: %s", domain, err)
+	}
+	group := strings.TrimSpace(output)
+	if group == "" {
+		return "", fmt.Errorf("No Azure DNS zone found for domain %s", domain)
+	}
+	return group, nil
+}
+
+// relativeName returns the host name relative to the domain as used by the az CLI
+func relativeName(host string, domain string) string {
+	name := strings.TrimSuffix(host, "."+domain)
+	if name == host || name == "" {
+		return "@"
+	}
+	return name
+}
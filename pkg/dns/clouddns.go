@@ -0,0 +1,131 @@
+package dns
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// CloudDNSProvider manages records in Google Cloud DNS via the gcloud CLI so
+// that it reuses the local gcloud credentials and project
+type CloudDNSProvider struct {
+}
+
+// NewCloudDNSProvider creates the provider
+func NewCloudDNSProvider() *CloudDNSProvider {
+	return &CloudDNSProvider{}
+}
+
+// Kind returns the provider kind
+func (p *CloudDNSProvider) Kind() string {
+	return KindCloudDNS
+}
+
+// EnsureRecord creates or updates the record of the host
+func (p *CloudDNSProvider) EnsureRecord(domain string, host string, target string) error {
+	zone, err := p.managedZone(domain)
+	if err != nil {
+		return err
+	}
+	recordType, ttl, value, err := p.currentRecord(zone, host)
+	if err != nil {
+		return err
+	}
+	if recordType == RecordType(target) && value == target {
+		return nil
+	}
+	err = p.runTransaction(zone, func() error {
+		if recordType != "" {
+			err := p.gcloud("dns", "record-sets", "transaction", "remove", "--zone", zone,
+				"--name", host+".", "--type", recordType, "--ttl", strconv.Itoa(ttl), value)
+			if err != nil {
+				return err
+			}
+		}
+		return p.gcloud("dns", "record-sets", "transaction", "add", "--zone", zone,
+			"--name", host+".", "--type", RecordType(target), "--ttl", strconv.Itoa(defaultTTL), target)
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to update the record of %s due to: %s", host, err)
+	}
+	return nil
+}
+
+// RemoveRecord removes the record of the host
+func (p *CloudDNSProvider) RemoveRecord(domain string, host string) error {
+	zone, err := p.managedZone(domain)
+	if err != nil {
+		return err
+	}
+	recordType, ttl, value, err := p.currentRecord(zone, host)
+	if err != nil {
+		return err
+	}
+	if recordType == "" {
+		return nil
+	}
+	err = p.runTransaction(zone, func() error {
+		return p.gcloud("dns", "record-sets", "transaction", "remove", "--zone", zone,
+			"--name", host+".", "--type", recordType, "--ttl", strconv.Itoa(ttl), value)
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to remove the record of %s due to: %s", host, err)
+	}
+	return nil
+}
+
+// managedZone returns the name of the managed zone of the domain
+func (p *CloudDNSProvider) managedZone(domain string) (string, error) {
+	output, err := util.GetCommandOutput("", "gcloud", "dns", "managed-zones", "list",
+		"--filter", "dnsName="+domain+".", "--format", "value(name)")
+	if err != nil {
+		return "", fmt.Errorf("Failed to find the Cloud DNS managed zone of %s due to: %s", domain, err)
+	}
+	zone := strings.TrimSpace(output)
+	if zone == "" {
+		return "", fmt.Errorf("No Cloud DNS managed zone found for domain %s", domain)
+	}
+	return zone, nil
+}
+
+// currentRecord returns the type, TTL and first value of the record of the
+// host or empty values when there is no record yet
+func (p *CloudDNSProvider) currentRecord(zone string, host string) (string, int, string, error) {
+	output, err := util.GetCommandOutput("", "gcloud", "dns", "record-sets", "list",
+		"--zone", zone, "--name", host+".", "--format", "value(type,ttl,rrdatas[0])")
+	if err != nil {
+		return "", 0, "", fmt.Errorf("Failed to find the record of %s due to: %s", host, err)
+	}
+	fields := strings.Fields(strings.TrimSpace(output))
+	if len(fields) < 3 {
+		return "", 0, "", nil
+	}
+	ttl, err := strconv.Atoi(fields[1])
+	if err != nil {
+		ttl = defaultTTL
+	}
+	return fields[0], ttl, fields[2], nil
+}
+
+// runTransaction wraps the given changes in a Cloud DNS transaction aborting
+// it when they fail
+func (p *CloudDNSProvider) runTransaction(zone string, changes func() error) error {
+	err := p.gcloud("dns", "record-sets", "transaction", "start", "--zone", zone)
+	if err != nil {
+		return err
+	}
+	err = changes()
+	if err != nil {
+		p.gcloud("dns", "record-sets", "transaction", "abort", "--zone", zone)
+		return err
+	}
+	return p.gcloud("dns", "record-sets", "transaction", "execute", "--zone", zone)
+}
+
+// gcloud invokes the gcloud CLI discarding the output
+func (p *CloudDNSProvider) gcloud(args ...string) error {
+	_, err := util.GetCommandOutput("", "gcloud", args...)
+	return err
+}
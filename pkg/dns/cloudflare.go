@@ -0,0 +1,175 @@
+package dns
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+const cloudflareAPI = "https://api.cloudflare.com/client/v4"
+
+// CloudflareProvider manages records in Cloudflare via its REST API using an
+// API token
+type CloudflareProvider struct {
+	apiToken string
+}
+
+// NewCloudflareProvider creates the provider using the given API token
+func NewCloudflareProvider(apiToken string) (*CloudflareProvider, error) {
+	if apiToken == "" {
+		return nil, fmt.Errorf("No Cloudflare API token found. Please set $%s", cloudflareTokenEnv)
+	}
+	return &CloudflareProvider{apiToken: apiToken}, nil
+}
+
+// Kind returns the provider kind
+func (p *CloudflareProvider) Kind() string {
+	return KindCloudflare
+}
+
+// cloudflareRecord models a DNS record of the Cloudflare API
+type cloudflareRecord struct {
+	ID      string `json:"id,omitempty"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	TTL     int    `json:"ttl"`
+}
+
+// cloudflareResult models the response envelope of the Cloudflare API
+type cloudflareResult struct {
+	Success bool              `json:"success"`
+	Raw     json.RawMessage   `json:"result"`
+	Errors  []cloudflareError `json:"errors"`
+}
+
+type cloudflareZone struct {
+	ID string `json:"id"`
+}
+
+type cloudflareError struct {
+	Message string `json:"message"`
+}
+
+// EnsureRecord creates or updates the record of the host
+func (p *CloudflareProvider) EnsureRecord(domain string, host string, target string) error {
+	zone, err := p.zoneID(domain)
+	if err != nil {
+		return err
+	}
+	existing, err := p.findRecord(zone, host)
+	if err != nil {
+		return err
+	}
+	record := &cloudflareRecord{
+		Type:    RecordType(target),
+		Name:    host,
+		Content: target,
+		TTL:     defaultTTL,
+	}
+	if existing == nil {
+		err = p.do("POST", "/zones/"+zone+"/dns_records", record, nil)
+	} else {
+		err = p.do("PUT", "/zones/"+zone+"/dns_records/"+existing.ID, record, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to update the record of %s due to: %s", host, err)
+	}
+	return nil
+}
+
+// RemoveRecord removes the record of the host
+func (p *CloudflareProvider) RemoveRecord(domain string, host string) error {
+	zone, err := p.zoneID(domain)
+	if err != nil {
+		return err
+	}
+	existing, err := p.findRecord(zone, host)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return nil
+	}
+	err = p.do("DELETE", "/zones/"+zone+"/dns_records/"+existing.ID, nil, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to remove the record of %s due to: %s", host, err)
+	}
+	return nil
+}
+
+// zoneID returns the ID of the Cloudflare zone of the domain
+func (p *CloudflareProvider) zoneID(domain string) (string, error) {
+	zones := []cloudflareZone{}
+	err := p.do("GET", "/zones?name="+url.QueryEscape(domain), nil, &zones)
+	if err != nil {
+		return "", fmt.Errorf("Failed to find the Cloudflare zone of %s due to: %s", domain, err)
+	}
+	if len(zones) == 0 {
+		return "", fmt.Errorf("No Cloudflare zone found for domain %s", domain)
+	}
+	return zones[0].ID, nil
+}
+
+// findRecord returns the record of the host or nil when there is none yet
+func (p *CloudflareProvider) findRecord(zone string, host string) (*cloudflareRecord, error) {
+	records := []cloudflareRecord{}
+	err := p.do("GET", "/zones/"+zone+"/dns_records?name="+url.QueryEscape(host), nil, &records)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to find the record of %s due to: %s", host, err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	return &records[0], nil
+}
+
+// do invokes the Cloudflare API marshalling the body to JSON and unmarshalling
+// the result into result if it is not nil
+func (p *CloudflareProvider) do(method string, path string, body interface{}, result interface{}) error {
+	reader := bytes.NewReader([]byte{})
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, cloudflareAPI+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.apiToken)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	envelope := &cloudflareResult{}
+	err = json.Unmarshal(data, envelope)
+	if err != nil {
+		return fmt.Errorf("Failed to parse the response of %s %s: %s", method, path, err)
+	}
+	if !envelope.Success {
+		message := ""
+		for _, e := range envelope.Errors {
+			if message != "" {
+				message += "; "
+			}
+			message += e.Message
+		}
+		return fmt.Errorf("%s %s failed: %s", method, path, message)
+	}
+	if result != nil && len(envelope.Raw) > 0 {
+		return json.Unmarshal(envelope.Raw, result)
+	}
+	return nil
+}
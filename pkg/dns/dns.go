@@ -0,0 +1,68 @@
+package dns
+
+import (
+	"net"
+	"os"
+
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// KindRoute53 the kind for AWS Route53
+	KindRoute53 = "route53"
+	// KindCloudDNS the kind for Google Cloud DNS
+	KindCloudDNS = "clouddns"
+	// KindAzureDNS the kind for Azure DNS
+	KindAzureDNS = "azuredns"
+	// KindCloudflare the kind for Cloudflare
+	KindCloudflare = "cloudflare"
+
+	// cloudflareTokenEnv the environment variable holding the Cloudflare API token
+	cloudflareTokenEnv = "CLOUDFLARE_API_TOKEN"
+
+	// defaultTTL the TTL in seconds used for the managed records
+	defaultTTL = 300
+)
+
+// KindDNSProviders the kinds of supported DNS providers
+var KindDNSProviders = []string{KindAzureDNS, KindCloudDNS, KindCloudflare, KindRoute53}
+
+// Provider manages the DNS records of a team domain so that exposed services
+// and preview environments resolve without relying on wildcard DNS services
+type Provider interface {
+	// Kind returns the provider kind
+	Kind() string
+
+	// EnsureRecord creates or updates the record of the fully qualified host
+	// within the domain pointing it at the target IP address or host name
+	EnsureRecord(domain string, host string, target string) error
+
+	// RemoveRecord removes the record of the fully qualified host from the domain
+	RemoveRecord(domain string, host string) error
+}
+
+// CreateDNSProvider creates the DNS provider of the given kind
+func CreateDNSProvider(kind string) (Provider, error) {
+	switch kind {
+	case KindRoute53:
+		return NewRoute53Provider(), nil
+	case KindCloudDNS:
+		return NewCloudDNSProvider(), nil
+	case KindAzureDNS:
+		return NewAzureDNSProvider(), nil
+	case KindCloudflare:
+		return NewCloudflareProvider(os.Getenv(cloudflareTokenEnv))
+	case "":
+		return nil, util.MissingOption("provider")
+	}
+	return nil, util.InvalidArg(kind, KindDNSProviders)
+}
+
+// RecordType returns the DNS record type to use for a target: A for IP
+// addresses and CNAME for host names
+func RecordType(target string) string {
+	if net.ParseIP(target) != nil {
+		return "A"
+	}
+	return "CNAME"
+}
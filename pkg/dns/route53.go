@@ -0,0 +1,78 @@
+package dns
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// Route53Provider manages records in AWS Route53 via the aws CLI so that it
+// reuses the local AWS credentials
+type Route53Provider struct {
+}
+
+// NewRoute53Provider creates the provider
+func NewRoute53Provider() *Route53Provider {
+	return &Route53Provider{}
+}
+
+// Kind returns the provider kind
+func (p *Route53Provider) Kind() string {
+	return KindRoute53
+}
+
+// EnsureRecord creates or updates the record of the host
+func (p *Route53Provider) EnsureRecord(domain string, host string, target string) error {
+	zone, err := p.hostedZoneID(domain)
+	if err != nil {
+		return err
+	}
+	return p.changeRecord(zone, "UPSERT", host, RecordType(target), target)
+}
+
+// RemoveRecord removes the record of the host
+func (p *Route53Provider) RemoveRecord(domain string, host string) error {
+	zone, err := p.hostedZoneID(domain)
+	if err != nil {
+		return err
+	}
+	// Route53 requires the current type and value when deleting a record
+	query := fmt.Sprintf("ResourceRecordSets[?Name=='%s.']|[0].[Type,ResourceRecords[0].Value]", host)
+	output, err := util.GetCommandOutput("", "aws", "route53", "list-resource-record-sets",
+		"--hosted-zone-id", zone, "--query", query, "--output", "text")
+	if err != nil {
+		return fmt.Errorf("Failed to find the record of %s due to: %s", host, err)
+	}
+	fields := strings.Fields(output)
+	if len(fields) < 2 || fields[0] == "None" {
+		return nil
+	}
+	return p.changeRecord(zone, "DELETE", host, fields[0], fields[1])
+}
+
+// hostedZoneID returns the ID of the hosted zone of the domain
+func (p *Route53Provider) hostedZoneID(domain string) (string, error) {
+	output, err := util.GetCommandOutput("", "aws", "route53", "list-hosted-zones-by-name",
+		"--dns-name", domain, "--query", "HostedZones[0].Id", "--output", "text")
+	if err != nil {
+		return "", fmt.Errorf("Failed to find the Route53 hosted zone of %s due to: %s", domain, err)
+	}
+	id := strings.TrimSpace(output)
+	if id == "" || id == "None" {
+		return "", fmt.Errorf("No Route53 hosted zone found for domain %s", domain)
+	}
+	return strings.TrimPrefix(id, "/hostedzone/"), nil
+}
+
+// changeRecord applies a change batch to the hosted zone
+func (p *Route53Provider) changeRecord(zone string, action string, host string, recordType string, value string) error {
+	batch := fmt.Sprintf(`{"Changes":[{"Action":"%s","ResourceRecordSet":{"Name":"%s.","Type":"%s","TTL":%d,"ResourceRecords":[{"Value":"%s"}]}}]}`,
+		action, host, recordType, defaultTTL, value)
+	_, err := util.GetCommandOutput("", "aws", "route53", "change-resource-record-sets",
+		"--hosted-zone-id", zone, "--change-batch", batch)
+	if err != nil {
+		return fmt.Errorf("Failed to %s the record of %s due to: %s", strings.ToLower(action), host, err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package features
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// KindFlagsmith the kind for the Flagsmith feature flag service
+	KindFlagsmith = "flagsmith"
+	// KindLaunchDarkly the kind for the LaunchDarkly feature flag service
+	KindLaunchDarkly = "launchdarkly"
+	// KindUnleash the kind for the Unleash feature flag service
+	KindUnleash = "unleash"
+)
+
+// KindFlagProviders the kinds of supported feature flag providers
+var KindFlagProviders = []string{KindFlagsmith, KindLaunchDarkly, KindUnleash}
+
+// FlagProvider is the interface implemented by the feature flag service adapters
+// so that promotion can coordinate code deployment with feature enablement
+type FlagProvider interface {
+	// Kind returns the provider kind
+	Kind() string
+
+	// EnsureFlag lazily creates the feature flag if it does not exist yet
+	EnsureFlag(name string) error
+
+	// SetFlag enables or disables the feature flag in the given environment
+	SetFlag(name string, environment string, enabled bool) error
+
+	// FlagState returns whether the feature flag is enabled in the given environment
+	FlagState(name string, environment string) (bool, error)
+}
+
+// CreateFlagProvider creates the feature flag provider of the given kind
+func CreateFlagProvider(kind string, serverURL string, apiToken string) (FlagProvider, error) {
+	switch kind {
+	case KindFlagsmith:
+		return NewFlagsmithProvider(serverURL, apiToken), nil
+	case KindLaunchDarkly:
+		return NewLaunchDarklyProvider(serverURL, apiToken), nil
+	case KindUnleash:
+		return NewUnleashProvider(serverURL, apiToken), nil
+	case "":
+		return nil, util.MissingOption("flag-provider")
+	}
+	return nil, util.InvalidArg(kind, KindFlagProviders)
+}
+
+// restClient is a minimal JSON REST client shared by the provider adapters
+type restClient struct {
+	baseURL string
+	headers map[string]string
+}
+
+// do invokes the given method on the path marshalling the body to JSON and
+// unmarshalling any response into result if it is not nil
+func (c *restClient) do(method string, path string, body interface{}, result interface{}) error {
+	fullURL := util.UrlJoin(c.baseURL, path)
+	var reader *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader([]byte{})
+	}
+	req, err := http.NewRequest(method, fullURL, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned status %s: %s", method, fullURL, resp.Status, strings.TrimSpace(string(data)))
+	}
+	if result != nil {
+		return json.Unmarshal(data, result)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package features
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// FlagsmithProvider is the feature flag adapter for a Flagsmith server using its
+// REST API with per environment feature states
+type FlagsmithProvider struct {
+	client *restClient
+}
+
+type flagsmithFeature struct {
+	ID   int    `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+type flagsmithFeatureState struct {
+	ID      int              `json:"id,omitempty"`
+	Enabled bool             `json:"enabled"`
+	Feature flagsmithFeature `json:"feature"`
+}
+
+// NewFlagsmithProvider creates a feature flag provider for a Flagsmith server
+func NewFlagsmithProvider(serverURL string, apiToken string) FlagProvider {
+	return &FlagsmithProvider{
+		client: &restClient{
+			baseURL: serverURL,
+			headers: map[string]string{
+				"Authorization": "Token " + apiToken,
+			},
+		},
+	}
+}
+
+// Kind returns the provider kind
+func (p *FlagsmithProvider) Kind() string {
+	return KindFlagsmith
+}
+
+// EnsureFlag lazily creates the feature if it does not exist yet
+func (p *FlagsmithProvider) EnsureFlag(name string) error {
+	features := []flagsmithFeature{}
+	err := p.client.do(http.MethodGet, "api/v1/features/?search="+name, nil, &features)
+	if err == nil {
+		for _, feature := range features {
+			if feature.Name == name {
+				return nil
+			}
+		}
+	}
+	feature := &flagsmithFeature{
+		Name: name,
+	}
+	return p.client.do(http.MethodPost, "api/v1/features/", feature, nil)
+}
+
+// SetFlag enables or disables the feature in the given environment
+func (p *FlagsmithProvider) SetFlag(name string, environment string, enabled bool) error {
+	state, err := p.featureState(name, environment)
+	if err != nil {
+		return err
+	}
+	state.Enabled = enabled
+	path := fmt.Sprintf("api/v1/environments/%s/featurestates/%d/", environment, state.ID)
+	return p.client.do(http.MethodPut, path, state, nil)
+}
+
+// FlagState returns whether the feature is enabled in the given environment
+func (p *FlagsmithProvider) FlagState(name string, environment string) (bool, error) {
+	state, err := p.featureState(name, environment)
+	if err != nil {
+		return false, err
+	}
+	return state.Enabled, nil
+}
+
+// featureState finds the feature state of the named feature in the environment
+func (p *FlagsmithProvider) featureState(name string, environment string) (*flagsmithFeatureState, error) {
+	states := []flagsmithFeatureState{}
+	path := fmt.Sprintf("api/v1/environments/%s/featurestates/", environment)
+	err := p.client.do(http.MethodGet, path, nil, &states)
+	if err != nil {
+		return nil, err
+	}
+	for i := range states {
+		if states[i].Feature.Name == name {
+			return &states[i], nil
+		}
+	}
+	return nil, fmt.Errorf("Could not find the feature %s in environment %s", name, environment)
+}
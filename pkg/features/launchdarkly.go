@@ -0,0 +1,86 @@
+package features
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// LaunchDarklyProvider is the feature flag adapter for LaunchDarkly using its
+// REST API. Flags are created in the default project with per environment state
+type LaunchDarklyProvider struct {
+	client  *restClient
+	project string
+}
+
+type launchDarklyFlag struct {
+	Key          string                             `json:"key"`
+	Name         string                             `json:"name"`
+	Environments map[string]launchDarklyEnvironment `json:"environments,omitempty"`
+}
+
+type launchDarklyEnvironment struct {
+	On bool `json:"on"`
+}
+
+type launchDarklyPatch struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// NewLaunchDarklyProvider creates a feature flag provider for LaunchDarkly
+func NewLaunchDarklyProvider(serverURL string, apiToken string) FlagProvider {
+	if serverURL == "" {
+		serverURL = "https://app.launchdarkly.com"
+	}
+	return &LaunchDarklyProvider{
+		client: &restClient{
+			baseURL: serverURL,
+			headers: map[string]string{
+				"Authorization": apiToken,
+			},
+		},
+		project: "default",
+	}
+}
+
+// Kind returns the provider kind
+func (p *LaunchDarklyProvider) Kind() string {
+	return KindLaunchDarkly
+}
+
+// EnsureFlag lazily creates the feature flag if it does not exist yet
+func (p *LaunchDarklyProvider) EnsureFlag(name string) error {
+	flag := &launchDarklyFlag{}
+	err := p.client.do(http.MethodGet, "api/v2/flags/"+p.project+"/"+name, nil, flag)
+	if err == nil {
+		return nil
+	}
+	newFlag := &launchDarklyFlag{
+		Key:  name,
+		Name: name,
+	}
+	return p.client.do(http.MethodPost, "api/v2/flags/"+p.project, newFlag, nil)
+}
+
+// SetFlag enables or disables the feature flag in the given environment
+func (p *LaunchDarklyProvider) SetFlag(name string, environment string, enabled bool) error {
+	patch := []launchDarklyPatch{
+		{
+			Op:    "replace",
+			Path:  fmt.Sprintf("/environments/%s/on", environment),
+			Value: enabled,
+		},
+	}
+	return p.client.do(http.MethodPatch, "api/v2/flags/"+p.project+"/"+name, patch, nil)
+}
+
+// FlagState returns whether the feature flag is enabled in the given environment
+func (p *LaunchDarklyProvider) FlagState(name string, environment string) (bool, error) {
+	flag := &launchDarklyFlag{}
+	err := p.client.do(http.MethodGet, "api/v2/flags/"+p.project+"/"+name, nil, flag)
+	if err != nil {
+		return false, err
+	}
+	return flag.Environments[environment].On, nil
+}
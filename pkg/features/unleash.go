@@ -0,0 +1,69 @@
+package features
+
+import (
+	"net/http"
+)
+
+// UnleashProvider is the feature flag adapter for an Unleash server using its
+// admin API. Unleash toggles are global rather than per environment so the
+// environment argument is ignored
+type UnleashProvider struct {
+	client *restClient
+}
+
+type unleashFeature struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Enabled     bool   `json:"enabled"`
+}
+
+// NewUnleashProvider creates a feature flag provider for an Unleash server
+func NewUnleashProvider(serverURL string, apiToken string) FlagProvider {
+	return &UnleashProvider{
+		client: &restClient{
+			baseURL: serverURL,
+			headers: map[string]string{
+				"Authorization": apiToken,
+			},
+		},
+	}
+}
+
+// Kind returns the provider kind
+func (p *UnleashProvider) Kind() string {
+	return KindUnleash
+}
+
+// EnsureFlag lazily creates the feature toggle if it does not exist yet
+func (p *UnleashProvider) EnsureFlag(name string) error {
+	flag := &unleashFeature{}
+	err := p.client.do(http.MethodGet, "api/admin/features/"+name, nil, flag)
+	if err == nil {
+		return nil
+	}
+	feature := &unleashFeature{
+		Name:        name,
+		Description: "Created by jx promote",
+		Enabled:     false,
+	}
+	return p.client.do(http.MethodPost, "api/admin/features", feature, nil)
+}
+
+// SetFlag toggles the feature on or off
+func (p *UnleashProvider) SetFlag(name string, environment string, enabled bool) error {
+	state := "off"
+	if enabled {
+		state = "on"
+	}
+	return p.client.do(http.MethodPost, "api/admin/features/"+name+"/toggle/"+state, nil, nil)
+}
+
+// FlagState returns whether the feature toggle is enabled
+func (p *UnleashProvider) FlagState(name string, environment string) (bool, error) {
+	flag := &unleashFeature{}
+	err := p.client.do(http.MethodGet, "api/admin/features/"+name, nil, flag)
+	if err != nil {
+		return false, err
+	}
+	return flag.Enabled, nil
+}
@@ -0,0 +1,515 @@
+package gits
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// azureDevOpsApiVersion the REST API version to request on every call
+	azureDevOpsApiVersion = "4.1"
+)
+
+// AzureDevOpsProvider implements GitProvider for Azure DevOps (Azure Repos)
+// using a Personal Access Token for authentication. The server URL is
+// expected to include the Azure DevOps organisation, e.g.
+// https://dev.azure.com/myorg, and team projects play the role of
+// organisations
+type AzureDevOpsProvider struct {
+	Username string
+	URL      string
+
+	Server auth.AuthServer
+	User   auth.UserAuth
+
+	client *http.Client
+}
+
+// NewAzureDevOpsProvider creates a provider for Azure DevOps using the users PAT
+func NewAzureDevOpsProvider(server *auth.AuthServer, user *auth.UserAuth) (GitProvider, error) {
+	provider := AzureDevOpsProvider{
+		Server:   *server,
+		User:     *user,
+		Username: user.Username,
+		URL:      strings.TrimSuffix(server.URL, "/"),
+		client:   http.DefaultClient,
+	}
+	return &provider, nil
+}
+
+type azureProject struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type azureProjectList struct {
+	Count int            `json:"count"`
+	Value []azureProject `json:"value"`
+}
+
+type azureRepo struct {
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	RemoteURL string       `json:"remoteUrl"`
+	SSHURL    string       `json:"sshUrl"`
+	WebURL    string       `json:"webUrl"`
+	Project   azureProject `json:"project"`
+}
+
+type azureRepoList struct {
+	Count int         `json:"count"`
+	Value []azureRepo `json:"value"`
+}
+
+type azurePullRequest struct {
+	PullRequestID int    `json:"pullRequestId"`
+	Status        string `json:"status"`
+	MergeStatus   string `json:"mergeStatus"`
+	Repository    struct {
+		WebURL string `json:"webUrl"`
+	} `json:"repository"`
+	LastMergeSourceCommit *struct {
+		CommitID string `json:"commitId"`
+	} `json:"lastMergeSourceCommit"`
+	LastMergeCommit *struct {
+		CommitID string `json:"commitId"`
+	} `json:"lastMergeCommit"`
+	ClosedDate *time.Time `json:"closedDate"`
+}
+
+type azureCommitStatus struct {
+	State       string `json:"state"`
+	Description string `json:"description"`
+	TargetURL   string `json:"targetUrl"`
+	Context     struct {
+		Name  string `json:"name"`
+		Genre string `json:"genre"`
+	} `json:"context"`
+}
+
+type azureCommitStatusList struct {
+	Count int                 `json:"count"`
+	Value []azureCommitStatus `json:"value"`
+}
+
+func (p *AzureDevOpsProvider) doRequest(method string, path string, body interface{}, result interface{}) error {
+	url := path
+	if !strings.HasPrefix(url, "http") {
+		url = p.URL + path
+	}
+	if strings.Contains(url, "?") {
+		url += "&api-version=" + azureDevOpsApiVersion
+	} else {
+		url += "?api-version=" + azureDevOpsApiVersion
+	}
+	var reader *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader([]byte{})
+	}
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return err
+	}
+	// Azure DevOps PATs are passed as the password on basic auth with any user name
+	req.SetBasicAuth(p.Username, p.User.ApiToken)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned status %d: %s", method, url, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if result != nil && len(data) > 0 {
+		return json.Unmarshal(data, result)
+	}
+	return nil
+}
+
+// ListOrganisations returns the team projects in the Azure DevOps organisation
+func (p *AzureDevOpsProvider) ListOrganisations() ([]GitOrganisation, error) {
+	answer := []GitOrganisation{}
+	projects := azureProjectList{}
+	err := p.doRequest(http.MethodGet, "/_apis/projects", nil, &projects)
+	if err != nil {
+		return answer, err
+	}
+	for _, project := range projects.Value {
+		if project.Name != "" {
+			answer = append(answer, GitOrganisation{Login: project.Name})
+		}
+	}
+	return answer, nil
+}
+
+func (p *AzureDevOpsProvider) ListRepositories(org string) ([]*GitRepository, error) {
+	answer := []*GitRepository{}
+	repos := azureRepoList{}
+	err := p.doRequest(http.MethodGet, "/"+org+"/_apis/git/repositories", nil, &repos)
+	if err != nil {
+		return answer, err
+	}
+	for _, repo := range repos.Value {
+		answer = append(answer, toAzureRepo(&repo))
+	}
+	return answer, nil
+}
+
+func (p *AzureDevOpsProvider) CreateRepository(org string, name string, private bool) (*GitRepository, error) {
+	// all Azure Repos repositories are private to the team project
+	body := map[string]interface{}{
+		"name": name,
+	}
+	repo := azureRepo{}
+	err := p.doRequest(http.MethodPost, "/"+org+"/_apis/git/repositories", body, &repo)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create repository %s/%s due to: %s", org, name, err)
+	}
+	return toAzureRepo(&repo), nil
+}
+
+func (p *AzureDevOpsProvider) getRepository(org string, name string) (*azureRepo, error) {
+	repo := azureRepo{}
+	err := p.doRequest(http.MethodGet, "/"+org+"/_apis/git/repositories/"+name, nil, &repo)
+	if err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+func (p *AzureDevOpsProvider) GetRepository(org string, name string) (*GitRepository, error) {
+	repo, err := p.getRepository(org, name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get repository %s/%s due to: %s", org, name, err)
+	}
+	return toAzureRepo(repo), nil
+}
+
+func (p *AzureDevOpsProvider) DeleteRepository(org string, name string) error {
+	repo, err := p.getRepository(org, name)
+	if err != nil {
+		return fmt.Errorf("Failed to delete repository %s/%s due to: %s", org, name, err)
+	}
+	err = p.doRequest(http.MethodDelete, "/"+org+"/_apis/git/repositories/"+repo.ID, nil, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to delete repository %s/%s due to: %s", org, name, err)
+	}
+	return nil
+}
+
+func (p *AzureDevOpsProvider) ForkRepository(originalOrg string, name string, destinationOrg string) (*GitRepository, error) {
+	original, err := p.getRepository(originalOrg, name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fork repository %s/%s due to: %s", originalOrg, name, err)
+	}
+	project := destinationOrg
+	if project == "" {
+		project = originalOrg
+	}
+	projects := azureProjectList{}
+	err = p.doRequest(http.MethodGet, "/_apis/projects", nil, &projects)
+	if err != nil {
+		return nil, err
+	}
+	projectID := ""
+	for _, pr := range projects.Value {
+		if pr.Name == project {
+			projectID = pr.ID
+			break
+		}
+	}
+	if projectID == "" {
+		return nil, fmt.Errorf("Could not find the project %s to fork repository %s into", project, name)
+	}
+	body := map[string]interface{}{
+		"name": name,
+		"project": map[string]string{
+			"id": projectID,
+		},
+		"parentRepository": map[string]interface{}{
+			"id": original.ID,
+			"project": map[string]string{
+				"id": original.Project.ID,
+			},
+		},
+	}
+	repo := azureRepo{}
+	err = p.doRequest(http.MethodPost, "/"+project+"/_apis/git/repositories", body, &repo)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fork repository %s/%s due to: %s", originalOrg, name, err)
+	}
+	return toAzureRepo(&repo), nil
+}
+
+func (p *AzureDevOpsProvider) RenameRepository(org string, name string, newName string) (*GitRepository, error) {
+	original, err := p.getRepository(org, name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to rename repository %s/%s due to: %s", org, name, err)
+	}
+	body := map[string]interface{}{
+		"name": newName,
+	}
+	repo := azureRepo{}
+	err = p.doRequest(http.MethodPatch, "/"+org+"/_apis/git/repositories/"+original.ID, body, &repo)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to rename repository %s/%s due to: %s", org, name, err)
+	}
+	return toAzureRepo(&repo), nil
+}
+
+func (p *AzureDevOpsProvider) ValidateRepositoryName(org string, name string) error {
+	_, err := p.getRepository(org, name)
+	if err == nil {
+		return fmt.Errorf("Repository %s already exists", GitRepoName(org, name))
+	}
+	if strings.Contains(err.Error(), "status 404") {
+		return nil
+	}
+	return err
+}
+
+func (p *AzureDevOpsProvider) CreatePullRequest(data *GitPullRequestArguments) (*GitPullRequest, error) {
+	body := map[string]interface{}{
+		"sourceRefName": "refs/heads/" + data.Head,
+		"targetRefName": "refs/heads/" + data.Base,
+		"title":         data.Title,
+		"description":   data.Body,
+	}
+	pr := azurePullRequest{}
+	err := p.doRequest(http.MethodPost, "/"+data.Owner+"/_apis/git/repositories/"+data.Repo+"/pullrequests", body, &pr)
+	if err != nil {
+		return nil, err
+	}
+	return toAzurePullRequest(data.Owner, data.Repo, &pr), nil
+}
+
+func (p *AzureDevOpsProvider) UpdatePullRequestStatus(pr *GitPullRequest) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	result := azurePullRequest{}
+	err := p.doRequest(http.MethodGet, fmt.Sprintf("/%s/_apis/git/repositories/%s/pullrequests/%d", pr.Owner, pr.Repo, *pr.Number), nil, &result)
+	if err != nil {
+		return err
+	}
+	updated := toAzurePullRequest(pr.Owner, pr.Repo, &result)
+	pr.State = updated.State
+	pr.Merged = updated.Merged
+	pr.MergeCommitSHA = updated.MergeCommitSHA
+	pr.LastCommitSha = updated.LastCommitSha
+	pr.ClosedAt = updated.ClosedAt
+	return nil
+}
+
+func toAzurePullRequest(owner string, repo string, pr *azurePullRequest) *GitPullRequest {
+	merged := pr.Status == "completed"
+	state := pr.Status
+	answer := &GitPullRequest{
+		Owner:  owner,
+		Repo:   repo,
+		Number: &pr.PullRequestID,
+		Merged: &merged,
+		State:  &state,
+	}
+	if pr.Repository.WebURL != "" {
+		answer.URL = util.UrlJoin(pr.Repository.WebURL, "pullrequest", fmt.Sprintf("%d", pr.PullRequestID))
+	}
+	if pr.LastMergeSourceCommit != nil {
+		answer.LastCommitSha = pr.LastMergeSourceCommit.CommitID
+	}
+	if pr.LastMergeCommit != nil {
+		answer.MergeCommitSHA = &pr.LastMergeCommit.CommitID
+	}
+	if pr.ClosedDate != nil {
+		answer.ClosedAt = pr.ClosedDate
+	}
+	return answer
+}
+
+func (p *AzureDevOpsProvider) PullRequestLastCommitStatus(pr *GitPullRequest) (string, error) {
+	statuses, err := p.ListCommitStatus(pr.Owner, pr.Repo, pr.LastCommitSha)
+	if err != nil {
+		return "", err
+	}
+	if len(statuses) == 0 {
+		return "", fmt.Errorf("Could not find a status for repository %s/%s with sha %s", pr.Owner, pr.Repo, pr.LastCommitSha)
+	}
+	return statuses[0].State, nil
+}
+
+func (p *AzureDevOpsProvider) ListCommitStatus(org string, repo string, sha string) ([]*GitRepoStatus, error) {
+	answer := []*GitRepoStatus{}
+	if sha == "" {
+		return answer, fmt.Errorf("Missing commit sha for repository %s/%s", org, repo)
+	}
+	statuses := azureCommitStatusList{}
+	err := p.doRequest(http.MethodGet, "/"+org+"/_apis/git/repositories/"+repo+"/commits/"+sha+"/statuses", nil, &statuses)
+	if err != nil {
+		return answer, err
+	}
+	for _, status := range statuses.Value {
+		answer = append(answer, &GitRepoStatus{
+			Context:     status.Context.Name,
+			State:       toAzureStatusState(status.State),
+			TargetURL:   status.TargetURL,
+			Description: status.Description,
+		})
+	}
+	return answer, nil
+}
+
+// toAzureStatusState maps the Azure DevOps git status states onto the
+// pending/success/error/failure states used by GitRepoStatus
+func toAzureStatusState(state string) string {
+	switch state {
+	case "succeeded":
+		return "success"
+	case "failed":
+		return "failure"
+	case "error":
+		return "error"
+	default:
+		return "pending"
+	}
+}
+
+func (p *AzureDevOpsProvider) MergePullRequest(pr *GitPullRequest, message string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	body := map[string]interface{}{
+		"status": "completed",
+		"completionOptions": map[string]interface{}{
+			"mergeCommitMessage": message,
+		},
+		"lastMergeSourceCommit": map[string]string{
+			"commitId": pr.LastCommitSha,
+		},
+	}
+	return p.doRequest(http.MethodPatch, fmt.Sprintf("/%s/_apis/git/repositories/%s/pullrequests/%d", pr.Owner, pr.Repo, *pr.Number), body, nil)
+}
+
+// CreateWebHook registers a service hook subscription which POSTs git push events to the given URL
+func (p *AzureDevOpsProvider) CreateWebHook(data *GitWebHookArguments) error {
+	owner := data.Owner
+	repo := data.Repo
+	if repo == "" {
+		return fmt.Errorf("Missing property Repo")
+	}
+	webhookUrl := data.URL
+	if webhookUrl == "" {
+		return fmt.Errorf("Missing property URL")
+	}
+	repository, err := p.getRepository(owner, repo)
+	if err != nil {
+		return err
+	}
+	body := map[string]interface{}{
+		"publisherId":      "tfs",
+		"eventType":        "git.push",
+		"resourceVersion":  "1.0",
+		"consumerId":       "webHooks",
+		"consumerActionId": "httpRequest",
+		"publisherInputs": map[string]string{
+			"projectId":  repository.Project.ID,
+			"repository": repository.ID,
+		},
+		"consumerInputs": map[string]string{
+			"url": webhookUrl,
+		},
+	}
+	return p.doRequest(http.MethodPost, "/_apis/hooks/subscriptions", body, nil)
+}
+
+func (p *AzureDevOpsProvider) GetIssue(org string, name string, number int) (*GitIssue, error) {
+	// Azure Repos does not have repository issues; work items are tracked separately
+	return nil, nil
+}
+
+func (p *AzureDevOpsProvider) HasIssues() bool {
+	return false
+}
+
+func (p *AzureDevOpsProvider) AddPRComment(pr *GitPullRequest, comment string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	body := map[string]interface{}{
+		"comments": []map[string]interface{}{
+			{
+				"parentCommentId": 0,
+				"content":         comment,
+				"commentType":     1,
+			},
+		},
+		"status": 1,
+	}
+	return p.doRequest(http.MethodPost, fmt.Sprintf("/%s/_apis/git/repositories/%s/pullrequests/%d/threads", pr.Owner, pr.Repo, *pr.Number), body, nil)
+}
+
+func (p *AzureDevOpsProvider) CreateIssueComment(owner string, repo string, number int, comment string) error {
+	return fmt.Errorf("Azure DevOps does not support issue comments on repositories")
+}
+
+func (p *AzureDevOpsProvider) UpdateRelease(owner string, repo string, tag string, releaseInfo *GitRelease) error {
+	// Azure Repos has no release concept so there is nothing to update
+	return nil
+}
+
+func (p *AzureDevOpsProvider) IsGitHub() bool {
+	return false
+}
+
+func (p *AzureDevOpsProvider) JenkinsWebHookPath(gitURL string, secret string) string {
+	return "/team-events/"
+}
+
+// AzureDevOpsAccessTokenURL returns the URL to create a Personal Access Token for the organisation
+func AzureDevOpsAccessTokenURL(url string) string {
+	return util.UrlJoin(url, "/_usersSettings/tokens")
+}
+
+// Capabilities returns the features supported by this provider
+func (p *AzureDevOpsProvider) Capabilities() GitProviderCapabilities {
+	return GitProviderCapabilities{
+		SupportsWebhooks:     true,
+		SupportsPullRequests: true,
+		SupportsCommitStatus: true,
+		SupportsForks:        true,
+	}
+}
+
+func (p *AzureDevOpsProvider) Label() string {
+	return p.Server.Label()
+}
+
+func toAzureRepo(repo *azureRepo) *GitRepository {
+	return &GitRepository{
+		Name:             repo.Name,
+		AllowMergeCommit: true,
+		CloneURL:         repo.RemoteURL,
+		HTMLURL:          repo.WebURL,
+		SSHURL:           repo.SSHURL,
+	}
+}
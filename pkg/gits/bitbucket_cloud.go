@@ -0,0 +1,527 @@
+package gits
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/auth"
+)
+
+const (
+	// BitbucketCloudURL is the API endpoint for Bitbucket Cloud
+	BitbucketCloudURL = "https://api.bitbucket.org/2.0"
+)
+
+// BitbucketCloudProvider implements GitProvider using the Bitbucket Cloud
+// 2.0 REST API where workspaces play the role of organisations. It is a
+// completely separate implementation to Bitbucket Server which has a very
+// different API
+type BitbucketCloudProvider struct {
+	Username string
+	URL      string
+
+	Server auth.AuthServer
+	User   auth.UserAuth
+
+	client *http.Client
+}
+
+// NewBitbucketCloudProvider creates a provider for bitbucket.org using the
+// users app password or OAuth token as the API credential
+func NewBitbucketCloudProvider(server *auth.AuthServer, user *auth.UserAuth) (GitProvider, error) {
+	provider := BitbucketCloudProvider{
+		Server:   *server,
+		User:     *user,
+		Username: user.Username,
+		URL:      BitbucketCloudURL,
+		client:   http.DefaultClient,
+	}
+	return &provider, nil
+}
+
+type bitbucketPage struct {
+	Next string `json:"next"`
+}
+
+type bitbucketLink struct {
+	Href string `json:"href"`
+	Name string `json:"name"`
+}
+
+type bitbucketLinks struct {
+	HTML   bitbucketLink   `json:"html"`
+	Clone  []bitbucketLink `json:"clone"`
+	Avatar bitbucketLink   `json:"avatar"`
+}
+
+type bitbucketWorkspace struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+type bitbucketWorkspacePage struct {
+	bitbucketPage
+	Values []bitbucketWorkspace `json:"values"`
+}
+
+type bitbucketRepo struct {
+	Name      string         `json:"name"`
+	Slug      string         `json:"slug"`
+	FullName  string         `json:"full_name"`
+	IsPrivate bool           `json:"is_private"`
+	Links     bitbucketLinks `json:"links"`
+}
+
+type bitbucketRepoPage struct {
+	bitbucketPage
+	Values []bitbucketRepo `json:"values"`
+}
+
+type bitbucketPullRequest struct {
+	ID    int    `json:"id"`
+	State string `json:"state"`
+	Links struct {
+		HTML bitbucketLink `json:"html"`
+	} `json:"links"`
+	MergeCommit *struct {
+		Hash string `json:"hash"`
+	} `json:"merge_commit"`
+	Source struct {
+		Commit struct {
+			Hash string `json:"hash"`
+		} `json:"commit"`
+	} `json:"source"`
+}
+
+type bitbucketCommitStatus struct {
+	Key         string `json:"key"`
+	State       string `json:"state"`
+	URL         string `json:"url"`
+	Description string `json:"description"`
+}
+
+type bitbucketCommitStatusPage struct {
+	bitbucketPage
+	Values []bitbucketCommitStatus `json:"values"`
+}
+
+type bitbucketIssue struct {
+	ID      int    `json:"id"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	Links struct {
+		HTML bitbucketLink `json:"html"`
+	} `json:"links"`
+}
+
+func (p *BitbucketCloudProvider) doRequest(method string, path string, body interface{}, result interface{}) error {
+	url := path
+	if !strings.HasPrefix(url, "http") {
+		url = p.URL + path
+	}
+	var reader *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader([]byte{})
+	}
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(p.Username, p.User.ApiToken)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned status %d: %s", method, url, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if result != nil && len(data) > 0 {
+		return json.Unmarshal(data, result)
+	}
+	return nil
+}
+
+func (p *BitbucketCloudProvider) ListOrganisations() ([]GitOrganisation, error) {
+	answer := []GitOrganisation{}
+	path := "/workspaces?role=member"
+	for path != "" {
+		page := bitbucketWorkspacePage{}
+		err := p.doRequest(http.MethodGet, path, nil, &page)
+		if err != nil {
+			return answer, err
+		}
+		for _, ws := range page.Values {
+			if ws.Slug != "" {
+				answer = append(answer, GitOrganisation{Login: ws.Slug})
+			}
+		}
+		path = page.Next
+	}
+	return answer, nil
+}
+
+func (p *BitbucketCloudProvider) ListRepositories(org string) ([]*GitRepository, error) {
+	answer := []*GitRepository{}
+	owner := org
+	if owner == "" {
+		owner = p.Username
+	}
+	path := "/repositories/" + owner
+	for path != "" {
+		page := bitbucketRepoPage{}
+		err := p.doRequest(http.MethodGet, path, nil, &page)
+		if err != nil {
+			return answer, err
+		}
+		for _, repo := range page.Values {
+			answer = append(answer, toBitbucketCloudRepo(&repo))
+		}
+		path = page.Next
+	}
+	return answer, nil
+}
+
+func (p *BitbucketCloudProvider) CreateRepository(org string, name string, private bool) (*GitRepository, error) {
+	owner := org
+	if owner == "" {
+		owner = p.Username
+	}
+	body := map[string]interface{}{
+		"scm":        "git",
+		"is_private": private,
+	}
+	repo := bitbucketRepo{}
+	err := p.doRequest(http.MethodPost, "/repositories/"+owner+"/"+name, body, &repo)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create repository %s/%s due to: %s", owner, name, err)
+	}
+	return toBitbucketCloudRepo(&repo), nil
+}
+
+func (p *BitbucketCloudProvider) GetRepository(org string, name string) (*GitRepository, error) {
+	repo := bitbucketRepo{}
+	err := p.doRequest(http.MethodGet, "/repositories/"+org+"/"+name, nil, &repo)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get repository %s/%s due to: %s", org, name, err)
+	}
+	return toBitbucketCloudRepo(&repo), nil
+}
+
+func (p *BitbucketCloudProvider) DeleteRepository(org string, name string) error {
+	owner := org
+	if owner == "" {
+		owner = p.Username
+	}
+	err := p.doRequest(http.MethodDelete, "/repositories/"+owner+"/"+name, nil, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to delete repository %s/%s due to: %s", owner, name, err)
+	}
+	return nil
+}
+
+func (p *BitbucketCloudProvider) ForkRepository(originalOrg string, name string, destinationOrg string) (*GitRepository, error) {
+	body := map[string]interface{}{}
+	if destinationOrg != "" {
+		body["workspace"] = map[string]string{"slug": destinationOrg}
+	}
+	repo := bitbucketRepo{}
+	err := p.doRequest(http.MethodPost, "/repositories/"+originalOrg+"/"+name+"/forks", body, &repo)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fork repository %s/%s due to: %s", originalOrg, name, err)
+	}
+	return toBitbucketCloudRepo(&repo), nil
+}
+
+func (p *BitbucketCloudProvider) RenameRepository(org string, name string, newName string) (*GitRepository, error) {
+	body := map[string]interface{}{
+		"name": newName,
+	}
+	repo := bitbucketRepo{}
+	err := p.doRequest(http.MethodPut, "/repositories/"+org+"/"+name, body, &repo)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to rename repository %s/%s due to: %s", org, name, err)
+	}
+	return toBitbucketCloudRepo(&repo), nil
+}
+
+func (p *BitbucketCloudProvider) ValidateRepositoryName(org string, name string) error {
+	repo := bitbucketRepo{}
+	err := p.doRequest(http.MethodGet, "/repositories/"+org+"/"+name, nil, &repo)
+	if err == nil {
+		return fmt.Errorf("Repository %s already exists", GitRepoName(org, name))
+	}
+	if strings.Contains(err.Error(), "status 404") {
+		return nil
+	}
+	return err
+}
+
+func (p *BitbucketCloudProvider) CreatePullRequest(data *GitPullRequestArguments) (*GitPullRequest, error) {
+	body := map[string]interface{}{
+		"title":       data.Title,
+		"description": data.Body,
+		"source": map[string]interface{}{
+			"branch": map[string]string{"name": data.Head},
+		},
+		"destination": map[string]interface{}{
+			"branch": map[string]string{"name": data.Base},
+		},
+	}
+	pr := bitbucketPullRequest{}
+	err := p.doRequest(http.MethodPost, "/repositories/"+data.Owner+"/"+data.Repo+"/pullrequests", body, &pr)
+	if err != nil {
+		return nil, err
+	}
+	return p.toPullRequest(data.Owner, data.Repo, &pr), nil
+}
+
+func (p *BitbucketCloudProvider) UpdatePullRequestStatus(pr *GitPullRequest) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	result := bitbucketPullRequest{}
+	err := p.doRequest(http.MethodGet, fmt.Sprintf("/repositories/%s/%s/pullrequests/%d", pr.Owner, pr.Repo, *pr.Number), nil, &result)
+	if err != nil {
+		return err
+	}
+	updated := p.toPullRequest(pr.Owner, pr.Repo, &result)
+	pr.State = updated.State
+	pr.Merged = updated.Merged
+	pr.MergeCommitSHA = updated.MergeCommitSHA
+	pr.LastCommitSha = updated.LastCommitSha
+	if updated.IsClosed() {
+		pr.ClosedAt = updated.ClosedAt
+	}
+	return nil
+}
+
+func (p *BitbucketCloudProvider) toPullRequest(owner string, repo string, pr *bitbucketPullRequest) *GitPullRequest {
+	merged := pr.State == "MERGED"
+	state := pr.State
+	answer := &GitPullRequest{
+		URL:           pr.Links.HTML.Href,
+		Owner:         owner,
+		Repo:          repo,
+		Number:        &pr.ID,
+		Merged:        &merged,
+		State:         &state,
+		LastCommitSha: pr.Source.Commit.Hash,
+	}
+	if pr.MergeCommit != nil {
+		answer.MergeCommitSHA = &pr.MergeCommit.Hash
+	}
+	if state == "MERGED" || state == "DECLINED" {
+		now := time.Now()
+		answer.ClosedAt = &now
+	}
+	return answer
+}
+
+func (p *BitbucketCloudProvider) PullRequestLastCommitStatus(pr *GitPullRequest) (string, error) {
+	statuses, err := p.ListCommitStatus(pr.Owner, pr.Repo, pr.LastCommitSha)
+	if err != nil {
+		return "", err
+	}
+	if len(statuses) == 0 {
+		return "", fmt.Errorf("Could not find a status for repository %s/%s with sha %s", pr.Owner, pr.Repo, pr.LastCommitSha)
+	}
+	return statuses[0].State, nil
+}
+
+func (p *BitbucketCloudProvider) ListCommitStatus(org string, repo string, sha string) ([]*GitRepoStatus, error) {
+	answer := []*GitRepoStatus{}
+	if sha == "" {
+		return answer, fmt.Errorf("Missing commit sha for repository %s/%s", org, repo)
+	}
+	path := "/repositories/" + org + "/" + repo + "/commit/" + sha + "/statuses"
+	for path != "" {
+		page := bitbucketCommitStatusPage{}
+		err := p.doRequest(http.MethodGet, path, nil, &page)
+		if err != nil {
+			return answer, err
+		}
+		for _, status := range page.Values {
+			answer = append(answer, &GitRepoStatus{
+				Context:     status.Key,
+				State:       toBitbucketCloudStatusState(status.State),
+				TargetURL:   status.URL,
+				Description: status.Description,
+			})
+		}
+		path = page.Next
+	}
+	return answer, nil
+}
+
+// toBitbucketCloudStatusState maps the Bitbucket Cloud build status states
+// onto the pending/success/error/failure states used by GitRepoStatus
+func toBitbucketCloudStatusState(state string) string {
+	switch state {
+	case "SUCCESSFUL":
+		return "success"
+	case "FAILED":
+		return "failure"
+	case "STOPPED":
+		return "error"
+	default:
+		return "pending"
+	}
+}
+
+func (p *BitbucketCloudProvider) MergePullRequest(pr *GitPullRequest, message string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	body := map[string]interface{}{
+		"message": message,
+	}
+	return p.doRequest(http.MethodPost, fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/merge", pr.Owner, pr.Repo, *pr.Number), body, nil)
+}
+
+func (p *BitbucketCloudProvider) CreateWebHook(data *GitWebHookArguments) error {
+	owner := data.Owner
+	if owner == "" {
+		owner = p.Username
+	}
+	repo := data.Repo
+	if repo == "" {
+		return fmt.Errorf("Missing property Repo")
+	}
+	webhookUrl := data.URL
+	if webhookUrl == "" {
+		return fmt.Errorf("Missing property URL")
+	}
+	body := map[string]interface{}{
+		"description": "Jenkins X web hook",
+		"url":         webhookUrl,
+		"active":      true,
+		"events": []string{
+			"repo:push",
+			"pullrequest:created",
+			"pullrequest:updated",
+			"pullrequest:fulfilled",
+			"pullrequest:rejected",
+		},
+	}
+	return p.doRequest(http.MethodPost, "/repositories/"+owner+"/"+repo+"/hooks", body, nil)
+}
+
+func (p *BitbucketCloudProvider) GetIssue(org string, name string, number int) (*GitIssue, error) {
+	issue := bitbucketIssue{}
+	err := p.doRequest(http.MethodGet, fmt.Sprintf("/repositories/%s/%s/issues/%d", org, name, number), nil, &issue)
+	if err != nil {
+		if strings.Contains(err.Error(), "status 404") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	state := issue.State
+	return &GitIssue{
+		URL:    issue.Links.HTML.Href,
+		Owner:  org,
+		Repo:   name,
+		Number: &issue.ID,
+		Title:  issue.Title,
+		Body:   issue.Content.Raw,
+		State:  &state,
+	}, nil
+}
+
+func (p *BitbucketCloudProvider) HasIssues() bool {
+	return true
+}
+
+func (p *BitbucketCloudProvider) AddPRComment(pr *GitPullRequest, comment string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	body := map[string]interface{}{
+		"content": map[string]string{"raw": comment},
+	}
+	return p.doRequest(http.MethodPost, fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/comments", pr.Owner, pr.Repo, *pr.Number), body, nil)
+}
+
+func (p *BitbucketCloudProvider) CreateIssueComment(owner string, repo string, number int, comment string) error {
+	body := map[string]interface{}{
+		"content": map[string]string{"raw": comment},
+	}
+	return p.doRequest(http.MethodPost, fmt.Sprintf("/repositories/%s/%s/issues/%d/comments", owner, repo, number), body, nil)
+}
+
+func (p *BitbucketCloudProvider) UpdateRelease(owner string, repo string, tag string, releaseInfo *GitRelease) error {
+	// Bitbucket Cloud has no release concept so there is nothing to update
+	return nil
+}
+
+func (p *BitbucketCloudProvider) IsGitHub() bool {
+	return false
+}
+
+func (p *BitbucketCloudProvider) JenkinsWebHookPath(gitURL string, secret string) string {
+	return "/bitbucket-scmsource-hook/notify"
+}
+
+// BitbucketCloudAccessTokenURL returns the URL to create an app password on bitbucket.org
+func BitbucketCloudAccessTokenURL(url string) string {
+	return "https://bitbucket.org/account/settings/app-passwords/new"
+}
+
+// Capabilities returns the features supported by this provider
+func (p *BitbucketCloudProvider) Capabilities() GitProviderCapabilities {
+	return GitProviderCapabilities{
+		SupportsWebhooks:     true,
+		SupportsPullRequests: true,
+		SupportsCommitStatus: true,
+		SupportsForks:        true,
+	}
+}
+
+func (p *BitbucketCloudProvider) Label() string {
+	return p.Server.Label()
+}
+
+func toBitbucketCloudRepo(repo *bitbucketRepo) *GitRepository {
+	cloneURL := ""
+	sshURL := ""
+	for _, link := range repo.Links.Clone {
+		switch link.Name {
+		case "https":
+			cloneURL = link.Href
+		case "ssh":
+			sshURL = link.Href
+		}
+	}
+	name := repo.Slug
+	if name == "" {
+		name = repo.Name
+	}
+	return &GitRepository{
+		Name:             name,
+		AllowMergeCommit: true,
+		CloneURL:         cloneURL,
+		HTMLURL:          repo.Links.HTML.Href,
+		SSHURL:           sshURL,
+	}
+}
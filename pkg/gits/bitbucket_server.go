@@ -0,0 +1,512 @@
+package gits
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// BitbucketServerProvider implements GitProvider for a self hosted Bitbucket
+// Server (formerly Stash) instance using its 1.0 REST API. Projects play the
+// role of organisations and the API is completely different to the Bitbucket
+// Cloud one so this is a separate implementation
+type BitbucketServerProvider struct {
+	Username string
+	URL      string
+
+	Server auth.AuthServer
+	User   auth.UserAuth
+
+	client *http.Client
+}
+
+// NewBitbucketServerProvider creates a provider for a Bitbucket Server
+// instance using the users personal access token as the API credential
+func NewBitbucketServerProvider(server *auth.AuthServer, user *auth.UserAuth) (GitProvider, error) {
+	u := strings.TrimSuffix(server.URL, "/")
+	if !strings.HasPrefix(u, "http") {
+		u = "https://" + u
+	}
+	provider := BitbucketServerProvider{
+		Server:   *server,
+		User:     *user,
+		Username: user.Username,
+		URL:      u,
+		client:   http.DefaultClient,
+	}
+	return &provider, nil
+}
+
+type bitbucketServerPage struct {
+	IsLastPage    bool `json:"isLastPage"`
+	NextPageStart int  `json:"nextPageStart"`
+}
+
+type bitbucketServerProject struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+type bitbucketServerProjectPage struct {
+	bitbucketServerPage
+	Values []bitbucketServerProject `json:"values"`
+}
+
+type bitbucketServerRepo struct {
+	Name    string                 `json:"name"`
+	Slug    string                 `json:"slug"`
+	Public  bool                   `json:"public"`
+	Project bitbucketServerProject `json:"project"`
+	Links   struct {
+		Clone []bitbucketLink `json:"clone"`
+		Self  []bitbucketLink `json:"self"`
+	} `json:"links"`
+}
+
+type bitbucketServerRepoPage struct {
+	bitbucketServerPage
+	Values []bitbucketServerRepo `json:"values"`
+}
+
+type bitbucketServerRef struct {
+	ID           string `json:"id"`
+	LatestCommit string `json:"latestCommit"`
+}
+
+type bitbucketServerPullRequest struct {
+	ID      int                `json:"id"`
+	Version int                `json:"version"`
+	State   string             `json:"state"`
+	FromRef bitbucketServerRef `json:"fromRef"`
+	Links   struct {
+		Self []bitbucketLink `json:"self"`
+	} `json:"links"`
+}
+
+type bitbucketServerBuildStatus struct {
+	Key         string `json:"key"`
+	State       string `json:"state"`
+	URL         string `json:"url"`
+	Description string `json:"description"`
+}
+
+type bitbucketServerBuildStatusPage struct {
+	bitbucketServerPage
+	Values []bitbucketServerBuildStatus `json:"values"`
+}
+
+func (p *BitbucketServerProvider) doRequest(method string, path string, body interface{}, result interface{}) error {
+	url := p.URL + path
+	var reader *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader([]byte{})
+	}
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(p.Username, p.User.ApiToken)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned status %d: %s", method, url, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if result != nil && len(data) > 0 {
+		return json.Unmarshal(data, result)
+	}
+	return nil
+}
+
+// pagedPath returns the path with the start query parameter for the next page
+func pagedPath(path string, start int) string {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return fmt.Sprintf("%s%sstart=%d", path, separator, start)
+}
+
+// projectKey returns the project key for an organisation defaulting to the
+// personal project of the user when the organisation is empty
+func (p *BitbucketServerProvider) projectKey(org string) string {
+	if org == "" {
+		return "~" + p.Username
+	}
+	return org
+}
+
+func (p *BitbucketServerProvider) repoPath(org string, name string) string {
+	return "/rest/api/1.0/projects/" + p.projectKey(org) + "/repos/" + name
+}
+
+func (p *BitbucketServerProvider) ListOrganisations() ([]GitOrganisation, error) {
+	answer := []GitOrganisation{}
+	path := "/rest/api/1.0/projects"
+	start := 0
+	for {
+		page := bitbucketServerProjectPage{}
+		err := p.doRequest(http.MethodGet, pagedPath(path, start), nil, &page)
+		if err != nil {
+			return answer, err
+		}
+		for _, project := range page.Values {
+			if project.Key != "" {
+				answer = append(answer, GitOrganisation{Login: project.Key})
+			}
+		}
+		if page.IsLastPage {
+			break
+		}
+		start = page.NextPageStart
+	}
+	return answer, nil
+}
+
+func (p *BitbucketServerProvider) ListRepositories(org string) ([]*GitRepository, error) {
+	answer := []*GitRepository{}
+	path := "/rest/api/1.0/projects/" + p.projectKey(org) + "/repos"
+	start := 0
+	for {
+		page := bitbucketServerRepoPage{}
+		err := p.doRequest(http.MethodGet, pagedPath(path, start), nil, &page)
+		if err != nil {
+			return answer, err
+		}
+		for _, repo := range page.Values {
+			answer = append(answer, toBitbucketServerRepo(&repo))
+		}
+		if page.IsLastPage {
+			break
+		}
+		start = page.NextPageStart
+	}
+	return answer, nil
+}
+
+func (p *BitbucketServerProvider) CreateRepository(org string, name string, private bool) (*GitRepository, error) {
+	body := map[string]interface{}{
+		"name":   name,
+		"scmId":  "git",
+		"public": !private,
+	}
+	repo := bitbucketServerRepo{}
+	err := p.doRequest(http.MethodPost, "/rest/api/1.0/projects/"+p.projectKey(org)+"/repos", body, &repo)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create repository %s/%s due to: %s", p.projectKey(org), name, err)
+	}
+	return toBitbucketServerRepo(&repo), nil
+}
+
+func (p *BitbucketServerProvider) GetRepository(org string, name string) (*GitRepository, error) {
+	repo := bitbucketServerRepo{}
+	err := p.doRequest(http.MethodGet, p.repoPath(org, name), nil, &repo)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get repository %s/%s due to: %s", p.projectKey(org), name, err)
+	}
+	return toBitbucketServerRepo(&repo), nil
+}
+
+func (p *BitbucketServerProvider) DeleteRepository(org string, name string) error {
+	err := p.doRequest(http.MethodDelete, p.repoPath(org, name), nil, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to delete repository %s/%s due to: %s", p.projectKey(org), name, err)
+	}
+	return nil
+}
+
+func (p *BitbucketServerProvider) ForkRepository(originalOrg string, name string, destinationOrg string) (*GitRepository, error) {
+	body := map[string]interface{}{}
+	if destinationOrg != "" {
+		body["project"] = map[string]string{"key": destinationOrg}
+	}
+	repo := bitbucketServerRepo{}
+	err := p.doRequest(http.MethodPost, p.repoPath(originalOrg, name), body, &repo)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fork repository %s/%s due to: %s", p.projectKey(originalOrg), name, err)
+	}
+	return toBitbucketServerRepo(&repo), nil
+}
+
+func (p *BitbucketServerProvider) RenameRepository(org string, name string, newName string) (*GitRepository, error) {
+	body := map[string]interface{}{
+		"name": newName,
+	}
+	repo := bitbucketServerRepo{}
+	err := p.doRequest(http.MethodPut, p.repoPath(org, name), body, &repo)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to rename repository %s/%s due to: %s", p.projectKey(org), name, err)
+	}
+	return toBitbucketServerRepo(&repo), nil
+}
+
+func (p *BitbucketServerProvider) ValidateRepositoryName(org string, name string) error {
+	repo := bitbucketServerRepo{}
+	err := p.doRequest(http.MethodGet, p.repoPath(org, name), nil, &repo)
+	if err == nil {
+		return fmt.Errorf("Repository %s already exists", GitRepoName(org, name))
+	}
+	if strings.Contains(err.Error(), "status 404") {
+		return nil
+	}
+	return err
+}
+
+func (p *BitbucketServerProvider) CreatePullRequest(data *GitPullRequestArguments) (*GitPullRequest, error) {
+	body := map[string]interface{}{
+		"title":       data.Title,
+		"description": data.Body,
+		"fromRef": map[string]interface{}{
+			"id": "refs/heads/" + data.Head,
+		},
+		"toRef": map[string]interface{}{
+			"id": "refs/heads/" + data.Base,
+		},
+	}
+	pr := bitbucketServerPullRequest{}
+	err := p.doRequest(http.MethodPost, p.repoPath(data.Owner, data.Repo)+"/pull-requests", body, &pr)
+	if err != nil {
+		return nil, err
+	}
+	return p.toPullRequest(data.Owner, data.Repo, &pr), nil
+}
+
+func (p *BitbucketServerProvider) UpdatePullRequestStatus(pr *GitPullRequest) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	result := bitbucketServerPullRequest{}
+	err := p.doRequest(http.MethodGet, fmt.Sprintf("%s/pull-requests/%d", p.repoPath(pr.Owner, pr.Repo), *pr.Number), nil, &result)
+	if err != nil {
+		return err
+	}
+	updated := p.toPullRequest(pr.Owner, pr.Repo, &result)
+	pr.State = updated.State
+	pr.Merged = updated.Merged
+	pr.LastCommitSha = updated.LastCommitSha
+	if updated.IsClosed() {
+		pr.ClosedAt = updated.ClosedAt
+	}
+	return nil
+}
+
+func (p *BitbucketServerProvider) toPullRequest(owner string, repo string, pr *bitbucketServerPullRequest) *GitPullRequest {
+	merged := pr.State == "MERGED"
+	state := pr.State
+	url := ""
+	if len(pr.Links.Self) > 0 {
+		url = pr.Links.Self[0].Href
+	}
+	answer := &GitPullRequest{
+		URL:           url,
+		Owner:         owner,
+		Repo:          repo,
+		Number:        &pr.ID,
+		Merged:        &merged,
+		State:         &state,
+		LastCommitSha: pr.FromRef.LatestCommit,
+	}
+	if state == "MERGED" || state == "DECLINED" {
+		now := time.Now()
+		answer.ClosedAt = &now
+	}
+	return answer
+}
+
+func (p *BitbucketServerProvider) PullRequestLastCommitStatus(pr *GitPullRequest) (string, error) {
+	statuses, err := p.ListCommitStatus(pr.Owner, pr.Repo, pr.LastCommitSha)
+	if err != nil {
+		return "", err
+	}
+	if len(statuses) == 0 {
+		return "", fmt.Errorf("Could not find a status for repository %s/%s with sha %s", pr.Owner, pr.Repo, pr.LastCommitSha)
+	}
+	return statuses[0].State, nil
+}
+
+func (p *BitbucketServerProvider) ListCommitStatus(org string, repo string, sha string) ([]*GitRepoStatus, error) {
+	answer := []*GitRepoStatus{}
+	if sha == "" {
+		return answer, fmt.Errorf("Missing commit sha for repository %s/%s", org, repo)
+	}
+	// the build status API of Bitbucket Server is keyed by commit only
+	path := "/rest/build-status/1.0/commits/" + sha
+	start := 0
+	for {
+		page := bitbucketServerBuildStatusPage{}
+		err := p.doRequest(http.MethodGet, pagedPath(path, start), nil, &page)
+		if err != nil {
+			return answer, err
+		}
+		for _, status := range page.Values {
+			answer = append(answer, &GitRepoStatus{
+				Context:     status.Key,
+				State:       toBitbucketServerStatusState(status.State),
+				TargetURL:   status.URL,
+				Description: status.Description,
+			})
+		}
+		if page.IsLastPage {
+			break
+		}
+		start = page.NextPageStart
+	}
+	return answer, nil
+}
+
+// toBitbucketServerStatusState maps the Bitbucket Server build states onto the
+// pending/success/error/failure states used by GitRepoStatus
+func toBitbucketServerStatusState(state string) string {
+	switch state {
+	case "SUCCESSFUL":
+		return "success"
+	case "FAILED":
+		return "failure"
+	default:
+		return "pending"
+	}
+}
+
+func (p *BitbucketServerProvider) MergePullRequest(pr *GitPullRequest, message string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	// merging needs the current version of the pull request
+	current := bitbucketServerPullRequest{}
+	path := fmt.Sprintf("%s/pull-requests/%d", p.repoPath(pr.Owner, pr.Repo), *pr.Number)
+	err := p.doRequest(http.MethodGet, path, nil, &current)
+	if err != nil {
+		return err
+	}
+	return p.doRequest(http.MethodPost, fmt.Sprintf("%s/merge?version=%d", path, current.Version), nil, nil)
+}
+
+func (p *BitbucketServerProvider) CreateWebHook(data *GitWebHookArguments) error {
+	repo := data.Repo
+	if repo == "" {
+		return fmt.Errorf("Missing property Repo")
+	}
+	webhookUrl := data.URL
+	if webhookUrl == "" {
+		return fmt.Errorf("Missing property URL")
+	}
+	body := map[string]interface{}{
+		"name":   "Jenkins X web hook",
+		"url":    webhookUrl,
+		"active": true,
+		"events": []string{
+			"repo:refs_changed",
+			"pr:opened",
+			"pr:modified",
+			"pr:merged",
+			"pr:declined",
+		},
+	}
+	return p.doRequest(http.MethodPost, p.repoPath(data.Owner, repo)+"/webhooks", body, nil)
+}
+
+func (p *BitbucketServerProvider) GetIssue(org string, name string, number int) (*GitIssue, error) {
+	// Bitbucket Server has no issue tracker
+	return nil, nil
+}
+
+func (p *BitbucketServerProvider) HasIssues() bool {
+	return false
+}
+
+func (p *BitbucketServerProvider) AddPRComment(pr *GitPullRequest, comment string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	body := map[string]interface{}{
+		"text": comment,
+	}
+	return p.doRequest(http.MethodPost, fmt.Sprintf("%s/pull-requests/%d/comments", p.repoPath(pr.Owner, pr.Repo), *pr.Number), body, nil)
+}
+
+func (p *BitbucketServerProvider) CreateIssueComment(owner string, repo string, number int, comment string) error {
+	return fmt.Errorf("Bitbucket Server does not support issues")
+}
+
+func (p *BitbucketServerProvider) UpdateRelease(owner string, repo string, tag string, releaseInfo *GitRelease) error {
+	// Bitbucket Server has no release concept so there is nothing to update
+	return nil
+}
+
+func (p *BitbucketServerProvider) IsGitHub() bool {
+	return false
+}
+
+func (p *BitbucketServerProvider) JenkinsWebHookPath(gitURL string, secret string) string {
+	return "/bitbucket-scmsource-hook/notify"
+}
+
+// BitbucketServerAccessTokenURL returns the URL to create a personal access
+// token on a Bitbucket Server instance
+func BitbucketServerAccessTokenURL(url string) string {
+	return util.UrlJoin(url, "/plugins/servlet/access-tokens/manage")
+}
+
+// Capabilities returns the features supported by this provider
+func (p *BitbucketServerProvider) Capabilities() GitProviderCapabilities {
+	return GitProviderCapabilities{
+		SupportsWebhooks:     true,
+		SupportsPullRequests: true,
+		SupportsCommitStatus: true,
+		SupportsForks:        true,
+	}
+}
+
+func (p *BitbucketServerProvider) Label() string {
+	return p.Server.Label()
+}
+
+func toBitbucketServerRepo(repo *bitbucketServerRepo) *GitRepository {
+	cloneURL := ""
+	sshURL := ""
+	for _, link := range repo.Links.Clone {
+		switch link.Name {
+		case "http", "https":
+			cloneURL = link.Href
+		case "ssh":
+			sshURL = link.Href
+		}
+	}
+	htmlURL := ""
+	if len(repo.Links.Self) > 0 {
+		htmlURL = repo.Links.Self[0].Href
+	}
+	name := repo.Slug
+	if name == "" {
+		name = repo.Name
+	}
+	return &GitRepository{
+		Name:             name,
+		AllowMergeCommit: true,
+		CloneURL:         cloneURL,
+		HTMLURL:          htmlURL,
+		SSHURL:           sshURL,
+	}
+}
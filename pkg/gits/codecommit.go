@@ -0,0 +1,323 @@
+package gits
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// CodeCommitProvider implements GitProvider for AWS CodeCommit by shelling
+// out to the aws CLI which handles the IAM/SigV4 signing for us - the same
+// way we wrap gcloud and az for cluster creation. The server URL is expected
+// to be a CodeCommit git endpoint such as
+// https://git-codecommit.eu-west-1.amazonaws.com
+type CodeCommitProvider struct {
+	Username string
+	Region   string
+
+	Server auth.AuthServer
+	User   auth.UserAuth
+}
+
+// NewCodeCommitProvider creates a provider for AWS CodeCommit using the aws CLI
+func NewCodeCommitProvider(server *auth.AuthServer, user *auth.UserAuth) (GitProvider, error) {
+	provider := CodeCommitProvider{
+		Server:   *server,
+		User:     *user,
+		Username: user.Username,
+		Region:   codeCommitRegion(server.URL),
+	}
+	return &provider, nil
+}
+
+// codeCommitRegion extracts the AWS region from a CodeCommit git endpoint URL
+func codeCommitRegion(url string) string {
+	text := strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://")
+	parts := strings.Split(text, ".")
+	if len(parts) > 2 && parts[0] == "git-codecommit" {
+		return parts[1]
+	}
+	return ""
+}
+
+func (p *CodeCommitProvider) aws(args ...string) (string, error) {
+	if p.Region != "" {
+		args = append(args, "--region", p.Region)
+	}
+	return util.GetCommandOutput("", "aws", args...)
+}
+
+func (p *CodeCommitProvider) awsJSON(result interface{}, args ...string) error {
+	output, err := p.aws(args...)
+	if err != nil {
+		return fmt.Errorf("Failed to invoke aws %s due to: %s: %s", strings.Join(args, " "), err, output)
+	}
+	if result != nil && output != "" {
+		return json.Unmarshal([]byte(output), result)
+	}
+	return nil
+}
+
+type codeCommitRepoMetadata struct {
+	RepositoryName string `json:"repositoryName"`
+	CloneURLHTTP   string `json:"cloneUrlHttp"`
+	CloneURLSSH    string `json:"cloneUrlSsh"`
+	Arn            string `json:"Arn"`
+}
+
+type codeCommitPullRequest struct {
+	PullRequestID      string `json:"pullRequestId"`
+	PullRequestStatus  string `json:"pullRequestStatus"`
+	PullRequestTargets []struct {
+		SourceCommit  string `json:"sourceCommit"`
+		MergeMetadata *struct {
+			IsMerged      bool   `json:"isMerged"`
+			MergeCommitID string `json:"mergeCommitId"`
+		} `json:"mergeMetadata"`
+	} `json:"pullRequestTargets"`
+}
+
+// ListOrganisations returns an empty list as CodeCommit has no organisation concept;
+// repositories all live in the AWS account
+func (p *CodeCommitProvider) ListOrganisations() ([]GitOrganisation, error) {
+	return []GitOrganisation{}, nil
+}
+
+func (p *CodeCommitProvider) ListRepositories(org string) ([]*GitRepository, error) {
+	answer := []*GitRepository{}
+	list := struct {
+		Repositories []struct {
+			RepositoryName string `json:"repositoryName"`
+		} `json:"repositories"`
+	}{}
+	err := p.awsJSON(&list, "codecommit", "list-repositories")
+	if err != nil {
+		return answer, err
+	}
+	for _, r := range list.Repositories {
+		repo, err := p.GetRepository(org, r.RepositoryName)
+		if err != nil {
+			return answer, err
+		}
+		answer = append(answer, repo)
+	}
+	return answer, nil
+}
+
+func (p *CodeCommitProvider) CreateRepository(org string, name string, private bool) (*GitRepository, error) {
+	// all CodeCommit repositories are private to the AWS account
+	result := struct {
+		RepositoryMetadata codeCommitRepoMetadata `json:"repositoryMetadata"`
+	}{}
+	err := p.awsJSON(&result, "codecommit", "create-repository", "--repository-name", name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create repository %s due to: %s", name, err)
+	}
+	return toCodeCommitRepo(&result.RepositoryMetadata), nil
+}
+
+func (p *CodeCommitProvider) GetRepository(org string, name string) (*GitRepository, error) {
+	result := struct {
+		RepositoryMetadata codeCommitRepoMetadata `json:"repositoryMetadata"`
+	}{}
+	err := p.awsJSON(&result, "codecommit", "get-repository", "--repository-name", name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get repository %s due to: %s", name, err)
+	}
+	return toCodeCommitRepo(&result.RepositoryMetadata), nil
+}
+
+func (p *CodeCommitProvider) DeleteRepository(org string, name string) error {
+	err := p.awsJSON(nil, "codecommit", "delete-repository", "--repository-name", name)
+	if err != nil {
+		return fmt.Errorf("Failed to delete repository %s due to: %s", name, err)
+	}
+	return nil
+}
+
+func (p *CodeCommitProvider) ForkRepository(originalOrg string, name string, destinationOrg string) (*GitRepository, error) {
+	return nil, fmt.Errorf("CodeCommit does not support forking repositories")
+}
+
+func (p *CodeCommitProvider) RenameRepository(org string, name string, newName string) (*GitRepository, error) {
+	err := p.awsJSON(nil, "codecommit", "update-repository-name", "--old-name", name, "--new-name", newName)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to rename repository %s due to: %s", name, err)
+	}
+	return p.GetRepository(org, newName)
+}
+
+func (p *CodeCommitProvider) ValidateRepositoryName(org string, name string) error {
+	_, err := p.GetRepository(org, name)
+	if err == nil {
+		return fmt.Errorf("Repository %s already exists", name)
+	}
+	if strings.Contains(err.Error(), "RepositoryDoesNotExistException") {
+		return nil
+	}
+	return err
+}
+
+func (p *CodeCommitProvider) CreatePullRequest(data *GitPullRequestArguments) (*GitPullRequest, error) {
+	result := struct {
+		PullRequest codeCommitPullRequest `json:"pullRequest"`
+	}{}
+	targets := fmt.Sprintf("repositoryName=%s,sourceReference=%s,destinationReference=%s", data.Repo, data.Head, data.Base)
+	args := []string{"codecommit", "create-pull-request", "--title", data.Title, "--targets", targets}
+	if data.Body != "" {
+		args = append(args, "--description", data.Body)
+	}
+	err := p.awsJSON(&result, args...)
+	if err != nil {
+		return nil, err
+	}
+	return toCodeCommitPullRequest(data.Owner, data.Repo, &result.PullRequest), nil
+}
+
+func (p *CodeCommitProvider) UpdatePullRequestStatus(pr *GitPullRequest) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	result := struct {
+		PullRequest codeCommitPullRequest `json:"pullRequest"`
+	}{}
+	err := p.awsJSON(&result, "codecommit", "get-pull-request", "--pull-request-id", strconv.Itoa(*pr.Number))
+	if err != nil {
+		return err
+	}
+	updated := toCodeCommitPullRequest(pr.Owner, pr.Repo, &result.PullRequest)
+	pr.State = updated.State
+	pr.Merged = updated.Merged
+	pr.MergeCommitSHA = updated.MergeCommitSHA
+	pr.LastCommitSha = updated.LastCommitSha
+	return nil
+}
+
+func toCodeCommitPullRequest(owner string, repo string, pr *codeCommitPullRequest) *GitPullRequest {
+	number, _ := strconv.Atoi(pr.PullRequestID)
+	merged := false
+	state := pr.PullRequestStatus
+	answer := &GitPullRequest{
+		Owner:  owner,
+		Repo:   repo,
+		Number: &number,
+		State:  &state,
+	}
+	for _, target := range pr.PullRequestTargets {
+		answer.LastCommitSha = target.SourceCommit
+		if target.MergeMetadata != nil && target.MergeMetadata.IsMerged {
+			merged = true
+			sha := target.MergeMetadata.MergeCommitID
+			answer.MergeCommitSHA = &sha
+		}
+	}
+	answer.Merged = &merged
+	return answer
+}
+
+func (p *CodeCommitProvider) PullRequestLastCommitStatus(pr *GitPullRequest) (string, error) {
+	return "", fmt.Errorf("CodeCommit does not support commit statuses")
+}
+
+func (p *CodeCommitProvider) ListCommitStatus(org string, repo string, sha string) ([]*GitRepoStatus, error) {
+	return []*GitRepoStatus{}, nil
+}
+
+func (p *CodeCommitProvider) MergePullRequest(pr *GitPullRequest, message string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	return p.awsJSON(nil, "codecommit", "merge-pull-request-by-fast-forward", "--pull-request-id", strconv.Itoa(*pr.Number), "--repository-name", pr.Repo)
+}
+
+// CreateWebHook creates an EventBridge rule matching the repository events. A
+// forwarding target (e.g. a lambda bridging events to the webhook URL) still
+// needs to be attached to the rule as EventBridge cannot POST to arbitrary
+// URLs directly
+func (p *CodeCommitProvider) CreateWebHook(data *GitWebHookArguments) error {
+	repo := data.Repo
+	if repo == "" {
+		return fmt.Errorf("Missing property Repo")
+	}
+	pattern := fmt.Sprintf(`{"source":["aws.codecommit"],"detail":{"repositoryName":["%s"]}}`, repo)
+	ruleName := "jenkins-x-" + repo
+	return p.awsJSON(nil, "events", "put-rule", "--name", ruleName, "--event-pattern", pattern,
+		"--description", "Jenkins X web hook bridge for "+data.URL)
+}
+
+func (p *CodeCommitProvider) GetIssue(org string, name string, number int) (*GitIssue, error) {
+	return nil, nil
+}
+
+func (p *CodeCommitProvider) HasIssues() bool {
+	return false
+}
+
+func (p *CodeCommitProvider) AddPRComment(pr *GitPullRequest, comment string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	return p.awsJSON(nil, "codecommit", "post-comment-for-pull-request", "--pull-request-id", strconv.Itoa(*pr.Number),
+		"--repository-name", pr.Repo, "--content", comment)
+}
+
+func (p *CodeCommitProvider) CreateIssueComment(owner string, repo string, number int, comment string) error {
+	return fmt.Errorf("CodeCommit does not support issues")
+}
+
+func (p *CodeCommitProvider) UpdateRelease(owner string, repo string, tag string, releaseInfo *GitRelease) error {
+	// CodeCommit has no release concept so there is nothing to update
+	return nil
+}
+
+func (p *CodeCommitProvider) IsGitHub() bool {
+	return false
+}
+
+func (p *CodeCommitProvider) JenkinsWebHookPath(gitURL string, secret string) string {
+	return "/git/notifyCommit?url=" + gitURL
+}
+
+// CodeCommitAccessTokenURL returns the IAM console page for creating git credentials
+func CodeCommitAccessTokenURL(url string) string {
+	return "https://console.aws.amazon.com/iam/home#/security_credentials"
+}
+
+// Capabilities returns the features supported by this provider
+func (p *CodeCommitProvider) Capabilities() GitProviderCapabilities {
+	return GitProviderCapabilities{
+		SupportsWebhooks:     false,
+		SupportsPullRequests: true,
+		SupportsCommitStatus: false,
+		SupportsForks:        false,
+	}
+}
+
+func (p *CodeCommitProvider) Label() string {
+	return p.Server.Label()
+}
+
+// SetupCodeCommitCredentialHelper configures git in the given directory to use
+// the aws CLI credential helper so that clones and pushes are signed with the
+// users IAM credentials rather than needing a stored password
+func SetupCodeCommitCredentialHelper(dir string) error {
+	err := GitCmd(dir, "config", "credential.helper", "!aws codecommit credential-helper $@")
+	if err != nil {
+		return err
+	}
+	return GitCmd(dir, "config", "credential.UseHttpPath", "true")
+}
+
+func toCodeCommitRepo(repo *codeCommitRepoMetadata) *GitRepository {
+	return &GitRepository{
+		Name:             repo.RepositoryName,
+		AllowMergeCommit: true,
+		CloneURL:         repo.CloneURLHTTP,
+		HTMLURL:          repo.CloneURLHTTP,
+		SSHURL:           repo.CloneURLSSH,
+	}
+}
@@ -0,0 +1,313 @@
+package gits
+
+import (
+	"fmt"
+)
+
+// FakeRepository is the in memory state of a repository in the FakeProvider
+type FakeRepository struct {
+	GitRepository
+
+	Organisation  string
+	Private       bool
+	PullRequests  map[int]*GitPullRequest
+	Issues        map[int]*GitIssue
+	Commits       map[string][]*GitRepoStatus
+	Releases      map[string]*GitRelease
+	PRComments    map[int][]string
+	IssueComments map[int][]string
+	WebHooks      []*GitWebHookArguments
+
+	nextNumber int
+}
+
+// FakeProvider is an in memory GitProvider implementation so that commands
+// which talk to a git provider can be tested hermetically
+type FakeProvider struct {
+	// GitHub makes the provider report itself as GitHub so that commands take
+	// their github code paths
+	GitHub bool
+	// Host is the git host name used in the URLs of created repositories
+	Host string
+	// User is the current user the provider is authenticated as
+	User GitUser
+	// Repositories is the in memory repository state indexed by organisation
+	Repositories map[string][]*FakeRepository
+}
+
+// NewFakeProvider creates a fake git provider populated with the given organisations
+func NewFakeProvider(organisations ...string) *FakeProvider {
+	provider := &FakeProvider{
+		GitHub:       true,
+		Host:         "fake.git",
+		User:         GitUser{Login: "fakeuser", Name: "Fake User"},
+		Repositories: map[string][]*FakeRepository{},
+	}
+	for _, organisation := range organisations {
+		provider.Repositories[organisation] = []*FakeRepository{}
+	}
+	return provider
+}
+
+// NewFakeRepository creates a repository in the fake provider so that tests can
+// seed state before running a command
+func (f *FakeProvider) NewFakeRepository(org string, name string) *FakeRepository {
+	repo := &FakeRepository{
+		GitRepository: GitRepository{
+			Name:             name,
+			AllowMergeCommit: true,
+			HTMLURL:          "https://" + f.Host + "/" + org + "/" + name,
+			CloneURL:         "https://" + f.Host + "/" + org + "/" + name + ".git",
+			SSHURL:           "git@" + f.Host + ":" + org + "/" + name + ".git",
+		},
+		Organisation:  org,
+		PullRequests:  map[int]*GitPullRequest{},
+		Issues:        map[int]*GitIssue{},
+		Commits:       map[string][]*GitRepoStatus{},
+		Releases:      map[string]*GitRelease{},
+		PRComments:    map[int][]string{},
+		IssueComments: map[int][]string{},
+		nextNumber:    1,
+	}
+	f.Repositories[org] = append(f.Repositories[org], repo)
+	return repo
+}
+
+// findRepository returns the fake repository for the given organisation and name
+func (f *FakeProvider) findRepository(org string, name string) (*FakeRepository, error) {
+	for _, repo := range f.Repositories[org] {
+		if repo.Name == name {
+			return repo, nil
+		}
+	}
+	return nil, fmt.Errorf("No repository found for %s/%s", org, name)
+}
+
+func (f *FakeProvider) ListOrganisations() ([]GitOrganisation, error) {
+	answer := []GitOrganisation{}
+	for org := range f.Repositories {
+		answer = append(answer, GitOrganisation{Login: org})
+	}
+	return answer, nil
+}
+
+func (f *FakeProvider) ListRepositories(org string) ([]*GitRepository, error) {
+	answer := []*GitRepository{}
+	for _, repo := range f.Repositories[org] {
+		answer = append(answer, &repo.GitRepository)
+	}
+	return answer, nil
+}
+
+func (f *FakeProvider) CreateRepository(org string, name string, private bool) (*GitRepository, error) {
+	err := f.ValidateRepositoryName(org, name)
+	if err != nil {
+		return nil, err
+	}
+	repo := f.NewFakeRepository(org, name)
+	repo.Private = private
+	return &repo.GitRepository, nil
+}
+
+func (f *FakeProvider) GetRepository(org string, name string) (*GitRepository, error) {
+	repo, err := f.findRepository(org, name)
+	if err != nil {
+		return nil, err
+	}
+	return &repo.GitRepository, nil
+}
+
+func (f *FakeProvider) DeleteRepository(org string, name string) error {
+	repos := f.Repositories[org]
+	for i, repo := range repos {
+		if repo.Name == name {
+			f.Repositories[org] = append(repos[:i], repos[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("No repository found for %s/%s", org, name)
+}
+
+func (f *FakeProvider) ForkRepository(originalOrg string, name string, destinationOrg string) (*GitRepository, error) {
+	if destinationOrg == "" {
+		destinationOrg = f.User.Login
+	}
+	_, err := f.findRepository(originalOrg, name)
+	if err != nil {
+		return nil, err
+	}
+	fork := f.NewFakeRepository(destinationOrg, name)
+	return &fork.GitRepository, nil
+}
+
+func (f *FakeProvider) RenameRepository(org string, name string, newName string) (*GitRepository, error) {
+	repo, err := f.findRepository(org, name)
+	if err != nil {
+		return nil, err
+	}
+	repo.Name = newName
+	repo.HTMLURL = "https://" + f.Host + "/" + org + "/" + newName
+	repo.CloneURL = repo.HTMLURL + ".git"
+	return &repo.GitRepository, nil
+}
+
+func (f *FakeProvider) ValidateRepositoryName(org string, name string) error {
+	_, err := f.findRepository(org, name)
+	if err == nil {
+		return fmt.Errorf("Repository %s/%s already exists", org, name)
+	}
+	return nil
+}
+
+func (f *FakeProvider) CreatePullRequest(data *GitPullRequestArguments) (*GitPullRequest, error) {
+	repo, err := f.findRepository(data.Owner, data.Repo)
+	if err != nil {
+		return nil, err
+	}
+	number := repo.nextNumber
+	repo.nextNumber++
+	state := "open"
+	pr := &GitPullRequest{
+		URL:     repo.HTMLURL + "/pull/" + fmt.Sprintf("%d", number),
+		Owner:   data.Owner,
+		Repo:    data.Repo,
+		Title:   data.Title,
+		Author:  f.User.Login,
+		HeadRef: data.Head,
+		BaseRef: data.Base,
+		Number:  &number,
+		State:   &state,
+	}
+	repo.PullRequests[number] = pr
+	return pr, nil
+}
+
+func (f *FakeProvider) UpdatePullRequestStatus(pr *GitPullRequest) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %s", pr.URL)
+	}
+	repo, err := f.findRepository(pr.Owner, pr.Repo)
+	if err != nil {
+		return err
+	}
+	existing := repo.PullRequests[*pr.Number]
+	if existing == nil {
+		return fmt.Errorf("No pull request found for %s/%s number %d", pr.Owner, pr.Repo, *pr.Number)
+	}
+	*pr = *existing
+	return nil
+}
+
+func (f *FakeProvider) PullRequestLastCommitStatus(pr *GitPullRequest) (string, error) {
+	statuses, err := f.ListCommitStatus(pr.Owner, pr.Repo, pr.LastCommitSha)
+	if err != nil {
+		return "", err
+	}
+	for _, status := range statuses {
+		return status.State, nil
+	}
+	return "", fmt.Errorf("No commit status found for repository %s/%s and sha %s", pr.Owner, pr.Repo, pr.LastCommitSha)
+}
+
+func (f *FakeProvider) ListCommitStatus(org string, repo string, sha string) ([]*GitRepoStatus, error) {
+	repository, err := f.findRepository(org, repo)
+	if err != nil {
+		return nil, err
+	}
+	return repository.Commits[sha], nil
+}
+
+func (f *FakeProvider) MergePullRequest(pr *GitPullRequest, message string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %s", pr.URL)
+	}
+	repo, err := f.findRepository(pr.Owner, pr.Repo)
+	if err != nil {
+		return err
+	}
+	existing := repo.PullRequests[*pr.Number]
+	if existing == nil {
+		return fmt.Errorf("No pull request found for %s/%s number %d", pr.Owner, pr.Repo, *pr.Number)
+	}
+	merged := true
+	state := "closed"
+	existing.Merged = &merged
+	existing.State = &state
+	return nil
+}
+
+func (f *FakeProvider) CreateWebHook(data *GitWebHookArguments) error {
+	repo, err := f.findRepository(data.Owner, data.Repo)
+	if err != nil {
+		return err
+	}
+	repo.WebHooks = append(repo.WebHooks, data)
+	return nil
+}
+
+func (f *FakeProvider) IsGitHub() bool {
+	return f.GitHub
+}
+
+func (f *FakeProvider) GetIssue(org string, name string, number int) (*GitIssue, error) {
+	repo, err := f.findRepository(org, name)
+	if err != nil {
+		return nil, err
+	}
+	issue := repo.Issues[number]
+	if issue == nil {
+		return nil, fmt.Errorf("No issue found for %s/%s number %d", org, name, number)
+	}
+	return issue, nil
+}
+
+func (f *FakeProvider) HasIssues() bool {
+	return true
+}
+
+func (f *FakeProvider) AddPRComment(pr *GitPullRequest, comment string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %s", pr.URL)
+	}
+	repo, err := f.findRepository(pr.Owner, pr.Repo)
+	if err != nil {
+		return err
+	}
+	repo.PRComments[*pr.Number] = append(repo.PRComments[*pr.Number], comment)
+	return nil
+}
+
+func (f *FakeProvider) CreateIssueComment(owner string, repo string, number int, comment string) error {
+	repository, err := f.findRepository(owner, repo)
+	if err != nil {
+		return err
+	}
+	repository.IssueComments[number] = append(repository.IssueComments[number], comment)
+	return nil
+}
+
+func (f *FakeProvider) UpdateRelease(owner string, repo string, tag string, releaseInfo *GitRelease) error {
+	repository, err := f.findRepository(owner, repo)
+	if err != nil {
+		return err
+	}
+	repository.Releases[tag] = releaseInfo
+	return nil
+}
+
+func (f *FakeProvider) JenkinsWebHookPath(gitURL string, secret string) string {
+	return "/fake-webhook/"
+}
+
+func (f *FakeProvider) Capabilities() GitProviderCapabilities {
+	return GitProviderCapabilities{
+		SupportsWebhooks:     true,
+		SupportsPullRequests: true,
+		SupportsCommitStatus: true,
+		SupportsForks:        true,
+	}
+}
+
+func (f *FakeProvider) Label() string {
+	return "FakeGit"
+}
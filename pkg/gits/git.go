@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/jenkins-x/jx/pkg/auth"
@@ -129,6 +130,78 @@ func HasChanges(dir string) (bool, error) {
 	return len(text) > 0, nil
 }
 
+// GitAheadBehindUpstream returns the number of commits the current branch is ahead
+// of and behind its upstream branch, along with whether an upstream is configured
+func GitAheadBehindUpstream(dir string) (int, int, bool, error) {
+	text, err := util.GetCommandOutput(dir, "git", "rev-list", "--left-right", "--count", "@{upstream}...HEAD")
+	if err != nil {
+		// no upstream branch configured yet
+		return 0, 0, false, nil
+	}
+	fields := strings.Fields(text)
+	if len(fields) != 2 {
+		return 0, 0, true, fmt.Errorf("Could not parse the git rev-list output %s in %s", text, dir)
+	}
+	behind, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, 0, true, err
+	}
+	ahead, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, 0, true, err
+	}
+	return ahead, behind, true, nil
+}
+
+// GitStash stashes the uncommitted changes in the working tree
+func GitStash(dir string) error {
+	return GitCmd(dir, "stash")
+}
+
+// GitRemoveFromIndex unstages any files matching the given pattern without
+// touching the working tree
+func GitRemoveFromIndex(dir string, pattern string) error {
+	return GitCmd(dir, "rm", "-r", "--cached", "--ignore-unmatch", pattern)
+}
+
+// GitLFSTrack tracks the given pattern with git-lfs, updating the .gitattributes file
+func GitLFSTrack(dir string, pattern string) error {
+	return GitCmd(dir, "lfs", "track", pattern)
+}
+
+// GitListStagedFiles returns the files currently staged in the index
+func GitListStagedFiles(dir string) ([]string, error) {
+	text, err := util.GetCommandOutput(dir, "git", "diff", "--cached", "--name-only")
+	if err != nil {
+		return nil, err
+	}
+	answer := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			answer = append(answer, line)
+		}
+	}
+	return answer, nil
+}
+
+// GitListChangedFilesFromBranch returns the files changed on the current
+// branch since it diverged from the given branch
+func GitListChangedFilesFromBranch(dir string, branch string) ([]string, error) {
+	text, err := util.GetCommandOutput(dir, "git", "diff", "--name-only", branch+"...HEAD")
+	if err != nil {
+		return nil, err
+	}
+	answer := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			answer = append(answer, line)
+		}
+	}
+	return answer, nil
+}
+
 func GitCommitIfChanges(dir string, message string) error {
 	changed, err := HasChanges(dir)
 	if err != nil {
@@ -164,6 +237,55 @@ func GitCmd(dir string, args ...string) error {
 	return nil
 }
 
+// GetDefaultGitUserName tries to detect the git user name to default prompts to
+// by reading the github.user and user.name settings of the git configuration and
+// any hub configuration file
+func GetDefaultGitUserName() string {
+	name := gitConfigValue("github.user")
+	if name != "" {
+		return name
+	}
+	name = hubConfigUser()
+	if name != "" {
+		return name
+	}
+	return gitConfigValue("user.name")
+}
+
+// gitConfigValue returns the value of the given git configuration key or an empty string
+func gitConfigValue(key string) string {
+	value, err := util.GetCommandOutput("", "git", "config", "--get", key)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(value)
+}
+
+// hubConfigUser returns the user recorded in a hub or gh configuration file if there is one
+func hubConfigUser() string {
+	home := util.HomeDir()
+	files := []string{
+		filepath.Join(home, ".config", "hub"),
+		filepath.Join(home, ".config", "gh", "hosts.yml"),
+	}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if strings.HasPrefix(line, "user:") {
+				user := strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "user:")), "\"")
+				if user != "" {
+					return user
+				}
+			}
+		}
+	}
+	return ""
+}
+
 // GitCreatePushURL creates the git repository URL with the username and password encoded for HTTPS based URLs
 func GitCreatePushURL(cloneURL string, userAuth *auth.UserAuth) (string, error) {
 	u, err := url.Parse(cloneURL)
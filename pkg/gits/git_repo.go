@@ -23,6 +23,8 @@ type GitRepositoryOptions struct {
 	ServerURL string
 	Username  string
 	ApiToken  string
+	Owner     string
+	Private   bool
 }
 
 // GetRepository returns the repository if it already exists
@@ -79,14 +81,16 @@ func PickNewGitRepository(out io.Writer, batchMode bool, authConfigSvc auth.Auth
 	if userAuth.IsInvalid() {
 		PrintCreateRepositoryGenerateAccessToken(server, out)
 
-		// TODO could we guess this based on the users ~/.git for github?
-		defaultUserName := ""
+		defaultUserName := GetDefaultGitUserName()
 		err = config.EditUserAuth(server.Label(), userAuth, defaultUserName, true, batchMode)
 		if err != nil {
 			return nil, err
 		}
 
-		// TODO lets verify the auth works
+		err = ValidateUserAuth(server, userAuth, out)
+		if err != nil {
+			return nil, err
+		}
 
 		err = authConfigSvc.SaveUserAuth(url, userAuth)
 		if err != nil {
@@ -104,9 +108,18 @@ func PickNewGitRepository(out io.Writer, batchMode bool, authConfigSvc auth.Auth
 	if err != nil {
 		return nil, err
 	}
-	org, err := PickOrganisation(provider, gitUsername)
-	if err != nil {
-		return nil, err
+	org := repoOptions.Owner
+	if org == "" {
+		if batchMode {
+			return nil, fmt.Errorf("No owner specified for the new repository. Please specify the organisation or user to own the repository when running in batch mode")
+		}
+		org, err = PickOrganisation(provider, gitUsername)
+		if err != nil {
+			return nil, err
+		}
+	} else if org == gitUsername {
+		// the personal account of the user is modelled as an empty organisation
+		org = ""
 	}
 	owner := org
 	if org == "" {
@@ -116,7 +129,11 @@ func PickNewGitRepository(out io.Writer, batchMode bool, authConfigSvc auth.Auth
 	if batchMode {
 		repoName = defaultRepoName
 		if repoName == "" {
-			repoName = "dummy"
+			return nil, fmt.Errorf("No repository name specified. Please specify a name for the new repository when running in batch mode")
+		}
+		err = provider.ValidateRepositoryName(owner, repoName)
+		if err != nil {
+			return nil, err
 		}
 	} else {
 		prompt := &survey.Input{
@@ -143,7 +160,7 @@ func PickNewGitRepository(out io.Writer, batchMode bool, authConfigSvc auth.Auth
 	}
 	fullName := GitRepoName(org, repoName)
 	fmt.Fprintf(out, "\n\nCreating repository %s\n", util.ColorInfo(fullName))
-	privateRepo := false
+	privateRepo := repoOptions.Private
 
 	return &CreateRepoData{
 		Organisation: org,
@@ -154,3 +171,22 @@ func PickNewGitRepository(out io.Writer, batchMode bool, authConfigSvc auth.Auth
 		GitProvider:  provider,
 	}, err
 }
+
+// ValidateUserAuth verifies the API token works against the git provider before
+// it is saved so broken tokens are reported instead of silently stored
+func ValidateUserAuth(server *auth.AuthServer, userAuth *auth.UserAuth, out io.Writer) error {
+	provider, err := CreateProvider(server, userAuth)
+	if err != nil {
+		return err
+	}
+	validator, ok := provider.(GitUserAuthValidator)
+	if !ok {
+		return nil
+	}
+	err = validator.ValidateUserAuth()
+	if err != nil {
+		return fmt.Errorf("Failed to verify the API token of user %s on %s: %s", userAuth.Username, server.URL, err)
+	}
+	fmt.Fprintf(out, "Verified the API token of user %s on %s\n", util.ColorInfo(userAuth.Username), util.ColorInfo(server.URL))
+	return nil
+}
@@ -2,32 +2,51 @@ package gits
 
 import (
 	"fmt"
-	"github.com/jenkins-x/jx/pkg/util"
 	"net/url"
 	"strings"
+
+	"github.com/jenkins-x/jx/pkg/util"
 )
 
 const (
 	GitHubHost = "github.com"
-
-	gitPrefix = "git@"
 )
 
 type GitRepositoryInfo struct {
 	URL          string
 	Scheme       string
 	Host         string
+	Port         string
 	Organisation string
-	Name         string
+
+	// SubGroups are any nested groups between the organisation and the
+	// repository name such as GitLab nested groups
+	SubGroups []string
+
+	Name string
+
+	// User is any user name embedded in the URL such as in SSH style URLs
+	User string
 }
 
 func (i *GitRepositoryInfo) IsGitHub() bool {
 	return GitHubHost == i.Host
 }
 
+// OrganisationFolders returns the organisation followed by any nested groups
+// which are mapped to nested Jenkins folders when importing a project
+func (i *GitRepositoryInfo) OrganisationFolders() []string {
+	return append([]string{i.Organisation}, i.SubGroups...)
+}
+
+// FullPath returns the organisation, any nested groups and the repository name separated by /
+func (i *GitRepositoryInfo) FullPath() string {
+	return strings.Join(append(i.OrganisationFolders(), i.Name), "/")
+}
+
 // PullRequestURL returns the URL of a pull request of the given name/number
 func (i *GitRepositoryInfo) PullRequestURL(prName string) string {
-	return util.UrlJoin("https://"+i.Host, i.Organisation, i.Name, "pull", prName)
+	return util.UrlJoin("https://"+i.Host, i.FullPath(), "pull", prName)
 }
 
 // HttpCloneURL returns the HTTPS git URL this repository
@@ -37,7 +56,7 @@ func (i *GitRepositoryInfo) HttpCloneURL() string {
 
 // HttpURL returns the URL to browse this repository in a web browser
 func (i *GitRepositoryInfo) HttpURL() string {
-	return util.UrlJoin("https://"+i.Host, i.Organisation, i.Name)
+	return util.UrlJoin("https://"+i.Host, i.FullPath())
 }
 
 // HostURL returns the URL to the host
@@ -50,53 +69,62 @@ func (i *GitRepositoryInfo) HostURL() string {
 }
 
 // ParseGitURL attempts to parse the given text as a URL or git URL-like string to determine
-// the protocol, host, organisation and name
+// the protocol, host, organisation, any nested groups and the repository name.
+// It copes with SSH and scp style URLs, ports, URLs missing a .git suffix and
+// URLs containing embedded credentials
 func ParseGitURL(text string) (*GitRepositoryInfo, error) {
 	answer := GitRepositoryInfo{
 		URL: text,
 	}
 	u, err := url.Parse(text)
-	if err == nil && u != nil {
-		answer.Host = u.Host
-
-		// lets default to github
-		if answer.Host == "" {
-			answer.Host = GitHubHost
-		}
-		if answer.Scheme == "" {
-			answer.Scheme = "https"
-		}
+	if err == nil && u != nil && u.Host != "" {
 		answer.Scheme = u.Scheme
+		answer.Host = u.Hostname()
+		answer.Port = u.Port()
+		if u.User != nil {
+			answer.User = u.User.Username()
+		}
 		return parsePath(u.Path, &answer)
 	}
 
-	// handle git@ kinds of URIs
-	if strings.HasPrefix(text, gitPrefix) {
-		t := strings.TrimPrefix(text, gitPrefix)
-		t = strings.TrimPrefix(t, "/")
-		t = strings.TrimPrefix(t, "/")
-		t = strings.TrimSuffix(t, "/")
-		t = strings.TrimSuffix(t, ".git")
-
-		arr := util.RegexpSplit(t, ":|/")
-		if len(arr) >= 3 {
+	// handle scp style URIs such as git@host:org/repo.git
+	if !strings.Contains(text, "://") {
+		t := text
+		user := ""
+		idx := strings.Index(t, "@")
+		if idx >= 0 {
+			user = t[:idx]
+			t = t[idx+1:]
+		}
+		idx = strings.Index(t, ":")
+		if idx >= 0 {
 			answer.Scheme = "git"
-			answer.Host = arr[0]
-			answer.Organisation = arr[1]
-			answer.Name = arr[2]
-			return &answer, nil
+			answer.Host = t[:idx]
+			answer.User = user
+			return parsePath(t[idx+1:], &answer)
+		}
+		if user == "" && strings.Contains(t, "/") {
+			// lets default to github
+			answer.Scheme = "https"
+			answer.Host = GitHubHost
+			return parsePath(t, &answer)
 		}
 	}
 	return nil, fmt.Errorf("Could not parse git url %s", text)
 }
 
 func parsePath(path string, info *GitRepositoryInfo) (*GitRepositoryInfo, error) {
-	arr := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	trimPath := strings.TrimPrefix(path, "/")
+	trimPath = strings.TrimSuffix(trimPath, "/")
+	trimPath = strings.TrimSuffix(trimPath, ".git")
+	arr := strings.Split(trimPath, "/")
 	if len(arr) >= 2 {
 		info.Organisation = arr[0]
-		info.Name = strings.TrimSuffix(arr[1], ".git")
+		info.Name = arr[len(arr)-1]
+		if len(arr) > 2 {
+			info.SubGroups = arr[1 : len(arr)-1]
+		}
 		return info, nil
-	} else {
-		return info, fmt.Errorf("Invalid path %s could not determine organisation and repository name")
 	}
+	return info, fmt.Errorf("Invalid path %s could not determine organisation and repository name", path)
 }
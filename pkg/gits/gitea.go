@@ -187,7 +187,7 @@ func (p *GiteaProvider) CreateWebHook(data *GitWebHookArguments) error {
 		Config: config,
 		Events: []string{"*"},
 	}
-	fmt.Printf("Creating github webhook for %s/%s for url %s\n", owner, repo, webhookUrl)
+	fmt.Printf("Creating gitea webhook for %s/%s for url %s\n", owner, repo, webhookUrl)
 	_, err = p.Client.CreateRepoHook(owner, repo, hook)
 	if err != nil {
 		return fmt.Errorf("Failed to create webhook for %s/%s with %#v due to: %s", owner, repo, hook, err)
@@ -259,10 +259,10 @@ func (p *GiteaProvider) UpdatePullRequestStatus(pr *GitPullRequest) error {
 
 func (p *GiteaProvider) GetIssue(org string, name string, number int) (*GitIssue, error) {
 	i, err := p.Client.GetIssue(org, name, int64(number))
-	if strings.Contains(err.Error(), "404") {
-		return nil, nil
-	}
 	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return nil, nil
+		}
 		return nil, err
 	}
 	state := string(i.State)
@@ -379,6 +379,16 @@ func (p *GiteaProvider) RenameRepository(org string, name string, newName string
 	return nil, fmt.Errorf("Rename of repositories is not supported for gitea")
 }
 
+// ValidateUserAuth checks the API token authenticates against the server
+// before it is saved in the auth config
+func (p *GiteaProvider) ValidateUserAuth() error {
+	_, err := p.Client.ListMyOrgs()
+	if err != nil {
+		return fmt.Errorf("the server rejected the token: %s", err)
+	}
+	return nil
+}
+
 func (p *GiteaProvider) ValidateRepositoryName(org string, name string) error {
 	_, err := p.Client.GetRepo(org, name)
 	if err == nil {
@@ -452,6 +462,16 @@ func GiteaAccessTokenURL(url string) string {
 	return util.UrlJoin(url, "/user/settings/applications")
 }
 
+// Capabilities returns the features supported by this provider
+func (p *GiteaProvider) Capabilities() GitProviderCapabilities {
+	return GitProviderCapabilities{
+		SupportsWebhooks:     true,
+		SupportsPullRequests: true,
+		SupportsCommitStatus: true,
+		SupportsForks:        true,
+	}
+}
+
 func (p *GiteaProvider) Label() string {
 	return p.Server.Label()
 }
@@ -72,6 +72,17 @@ func (p *GitHubProvider) ListOrganisations() ([]GitOrganisation, error) {
 }
 
 func (p *GitHubProvider) ListRepositories(org string) ([]*GitRepository, error) {
+	// try a single GraphQL query per page first as it is far fewer API calls
+	// than the REST API; fall back to REST if GraphQL is unavailable
+	details, err := p.ListRepositoriesWithDetails(org)
+	if err == nil {
+		answer := []*GitRepository{}
+		for _, d := range details {
+			repo := d.GitRepository
+			answer = append(answer, &repo)
+		}
+		return answer, nil
+	}
 	owner := org
 	if owner == "" {
 		owner = p.Username
@@ -132,6 +143,90 @@ func (p *GitHubProvider) DeleteRepository(org string, name string) error {
 	return err
 }
 
+// BranchProtectionArguments describes the branch protection settings to apply
+// to a branch or the settings currently applied to it
+type BranchProtectionArguments struct {
+	Branch                  string
+	RequiredContexts        []string
+	RequireUpToDate         bool
+	RequireReviews          bool
+	DismissStaleReviews     bool
+	RequireCodeOwnerReviews bool
+	EnforceAdmins           bool
+}
+
+// ProtectBranch enables branch protection on the given branch requiring the status
+// checks to pass before merging
+func (p *GitHubProvider) ProtectBranch(org string, name string, branch string) error {
+	return p.ApplyBranchProtection(org, name, &BranchProtectionArguments{
+		Branch:           branch,
+		RequiredContexts: []string{},
+		RequireUpToDate:  true,
+	})
+}
+
+// ApplyBranchProtection applies the given branch protection settings to a branch
+func (p *GitHubProvider) ApplyBranchProtection(org string, name string, protection *BranchProtectionArguments) error {
+	owner := org
+	if owner == "" {
+		owner = p.Username
+	}
+	contexts := protection.RequiredContexts
+	if contexts == nil {
+		contexts = []string{}
+	}
+	request := &github.ProtectionRequest{
+		RequiredStatusChecks: &github.RequiredStatusChecks{
+			Strict:   protection.RequireUpToDate,
+			Contexts: contexts,
+		},
+		EnforceAdmins: protection.EnforceAdmins,
+	}
+	if protection.RequireReviews {
+		request.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{
+			DismissStaleReviews:     protection.DismissStaleReviews,
+			RequireCodeOwnerReviews: protection.RequireCodeOwnerReviews,
+		}
+	}
+	_, _, err := p.Client.Repositories.UpdateBranchProtection(p.Context, owner, name, protection.Branch, request)
+	if err != nil {
+		return fmt.Errorf("Failed to protect branch %s on %s/%s due to: %s", protection.Branch, owner, name, err)
+	}
+	return nil
+}
+
+// GetBranchProtection returns the protection settings of the given branch or
+// nil if the branch is not protected
+func (p *GitHubProvider) GetBranchProtection(org string, name string, branch string) (*BranchProtectionArguments, error) {
+	owner := org
+	if owner == "" {
+		owner = p.Username
+	}
+	protection, response, err := p.Client.Repositories.GetBranchProtection(p.Context, owner, name, branch)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to get the protection of branch %s on %s/%s due to: %s", branch, owner, name, err)
+	}
+	answer := &BranchProtectionArguments{
+		Branch: branch,
+	}
+	if protection.RequiredStatusChecks != nil {
+		answer.RequiredContexts = protection.RequiredStatusChecks.Contexts
+		answer.RequireUpToDate = protection.RequiredStatusChecks.Strict
+	}
+	if protection.RequiredPullRequestReviews != nil {
+		answer.RequireReviews = true
+		answer.DismissStaleReviews = protection.RequiredPullRequestReviews.DismissStaleReviews
+		answer.RequireCodeOwnerReviews = protection.RequiredPullRequestReviews.RequireCodeOwnerReviews
+	}
+	if protection.EnforceAdmins != nil {
+		answer.EnforceAdmins = protection.EnforceAdmins.Enabled
+	}
+	return answer, nil
+}
+
 func toGitHubRepo(name string, repo *github.Repository) *GitRepository {
 	return &GitRepository{
 		Name:             name,
@@ -261,6 +356,228 @@ func (p *GitHubProvider) CreatePullRequest(data *GitPullRequestArguments) (*GitP
 	}, nil
 }
 
+// ListOpenPullRequests returns the open pull requests of the repository
+func (p *GitHubProvider) ListOpenPullRequests(owner string, repo string) ([]*GitPullRequest, error) {
+	answer := []*GitPullRequest{}
+	options := &github.PullRequestListOptions{
+		State: "open",
+	}
+	prs, _, err := p.Client.PullRequests.List(p.Context, owner, repo, options)
+	if err != nil {
+		return answer, err
+	}
+	for _, pr := range prs {
+		labels := []string{}
+		for _, label := range pr.Labels {
+			labels = append(labels, notNullString(label.Name))
+		}
+		gitPR := &GitPullRequest{
+			URL:       notNullString(pr.HTMLURL),
+			Owner:     owner,
+			Repo:      repo,
+			Title:     notNullString(pr.Title),
+			Labels:    labels,
+			Number:    pr.Number,
+			Mergeable: pr.Mergeable,
+			Merged:    pr.Merged,
+			State:     pr.State,
+		}
+		if pr.Head != nil {
+			gitPR.LastCommitSha = notNullString(pr.Head.SHA)
+			gitPR.HeadRef = notNullString(pr.Head.Ref)
+			if pr.Head.Repo != nil && pr.Head.Repo.Owner != nil {
+				gitPR.HeadOwner = notNullString(pr.Head.Repo.Owner.Login)
+			}
+		}
+		if pr.Base != nil {
+			gitPR.BaseRef = notNullString(pr.Base.Ref)
+		}
+		if pr.User != nil {
+			gitPR.Author = notNullString(pr.User.Login)
+		}
+		answer = append(answer, gitPR)
+	}
+	return answer, nil
+}
+
+// GitPullRequestFile represents one changed file of a pull request
+type GitPullRequestFile struct {
+	Filename  string
+	Additions int
+	Deletions int
+}
+
+// ListRepositoryLabels returns the labels defined on the repository
+func (p *GitHubProvider) ListRepositoryLabels(org string, name string) ([]GitLabel, error) {
+	answer := []GitLabel{}
+	labels, _, err := p.Client.Issues.ListLabels(p.Context, org, name, nil)
+	if err != nil {
+		return answer, err
+	}
+	for _, label := range labels {
+		answer = append(answer, GitLabel{
+			Name:  notNullString(label.Name),
+			Color: notNullString(label.Color),
+		})
+	}
+	return answer, nil
+}
+
+// ListRepositoryTopics returns the topics of the repository
+func (p *GitHubProvider) ListRepositoryTopics(org string, name string) ([]string, error) {
+	topics, _, err := p.Client.Repositories.ListAllTopics(p.Context, org, name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list the topics of %s/%s due to: %s", org, name, err)
+	}
+	return topics, nil
+}
+
+// UpdateRepositoryTopics replaces the topics of the repository
+func (p *GitHubProvider) UpdateRepositoryTopics(org string, name string, topics []string) error {
+	_, _, err := p.Client.Repositories.ReplaceAllTopics(p.Context, org, name, topics)
+	if err != nil {
+		return fmt.Errorf("Failed to update the topics of %s/%s due to: %s", org, name, err)
+	}
+	return nil
+}
+
+// ListWebHooks returns the webhooks registered on the repository
+func (p *GitHubProvider) ListWebHooks(org string, name string) ([]*GitWebHook, error) {
+	answer := []*GitWebHook{}
+	pageSize := 100
+	options := &github.ListOptions{
+		Page:    0,
+		PerPage: pageSize,
+	}
+	for {
+		hooks, _, err := p.Client.Repositories.ListHooks(p.Context, org, name, options)
+		if err != nil {
+			return answer, fmt.Errorf("Failed to list the webhooks of %s/%s due to: %s", org, name, err)
+		}
+		for _, hook := range hooks {
+			url := ""
+			if hook.Config != nil {
+				url, _ = hook.Config["url"].(string)
+			}
+			answer = append(answer, &GitWebHook{
+				ID:  hook.GetID(),
+				URL: url,
+			})
+		}
+		if len(hooks) < pageSize || len(hooks) == 0 {
+			break
+		}
+		options.Page += 1
+	}
+	return answer, nil
+}
+
+// DeleteWebHook removes the webhook with the given ID from the repository
+func (p *GitHubProvider) DeleteWebHook(org string, name string, id int64) error {
+	_, err := p.Client.Repositories.DeleteHook(p.Context, org, name, id)
+	if err != nil {
+		return fmt.Errorf("Failed to delete webhook %d on %s/%s due to: %s", id, org, name, err)
+	}
+	return nil
+}
+
+// CreateOrUpdateLabel creates the label on the repository or updates its colour
+// if it already exists
+func (p *GitHubProvider) CreateOrUpdateLabel(org string, name string, label GitLabel) error {
+	ghLabel := &github.Label{
+		Name:  github.String(label.Name),
+		Color: github.String(label.Color),
+	}
+	_, response, err := p.Client.Issues.EditLabel(p.Context, org, name, label.Name, ghLabel)
+	if err == nil {
+		return nil
+	}
+	if response == nil || response.StatusCode != 404 {
+		return fmt.Errorf("Failed to update label %s on %s/%s due to: %s", label.Name, org, name, err)
+	}
+	_, _, err = p.Client.Issues.CreateLabel(p.Context, org, name, ghLabel)
+	if err != nil {
+		return fmt.Errorf("Failed to create label %s on %s/%s due to: %s", label.Name, org, name, err)
+	}
+	return nil
+}
+
+// AddPRLabels adds the labels to the pull request
+func (p *GitHubProvider) AddPRLabels(pr *GitPullRequest, labels []string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	_, _, err := p.Client.Issues.AddLabelsToIssue(p.Context, pr.Owner, pr.Repo, *pr.Number, labels)
+	if err != nil {
+		return fmt.Errorf("Failed to label PR #%d of %s/%s due to: %s", *pr.Number, pr.Owner, pr.Repo, err)
+	}
+	return nil
+}
+
+// RemovePRLabel removes the label from the pull request
+func (p *GitHubProvider) RemovePRLabel(pr *GitPullRequest, label string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	_, err := p.Client.Issues.RemoveLabelForIssue(p.Context, pr.Owner, pr.Repo, *pr.Number, label)
+	if err != nil {
+		return fmt.Errorf("Failed to remove label %s from PR #%d of %s/%s due to: %s", label, *pr.Number, pr.Owner, pr.Repo, err)
+	}
+	return nil
+}
+
+// ListPullRequestFiles returns the files changed by the pull request
+func (p *GitHubProvider) ListPullRequestFiles(pr *GitPullRequest) ([]GitPullRequestFile, error) {
+	answer := []GitPullRequestFile{}
+	if pr.Number == nil {
+		return answer, fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	files, _, err := p.Client.PullRequests.ListFiles(p.Context, pr.Owner, pr.Repo, *pr.Number, nil)
+	if err != nil {
+		return answer, err
+	}
+	for _, file := range files {
+		answer = append(answer, GitPullRequestFile{
+			Filename:  notNullString(file.Filename),
+			Additions: asInt(file.Additions),
+			Deletions: asInt(file.Deletions),
+		})
+	}
+	return answer, nil
+}
+
+// ListRequestedReviewers returns the user names whose review has been requested
+// on the pull request
+func (p *GitHubProvider) ListRequestedReviewers(pr *GitPullRequest) ([]string, error) {
+	answer := []string{}
+	if pr.Number == nil {
+		return answer, fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	reviewers, _, err := p.Client.PullRequests.ListReviewers(p.Context, pr.Owner, pr.Repo, *pr.Number, nil)
+	if err != nil {
+		return answer, err
+	}
+	for _, user := range reviewers.Users {
+		answer = append(answer, notNullString(user.Login))
+	}
+	return answer, nil
+}
+
+// RequestReviewers asks the given users to review the pull request
+func (p *GitHubProvider) RequestReviewers(pr *GitPullRequest, reviewers []string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	request := github.ReviewersRequest{
+		Reviewers: reviewers,
+	}
+	_, _, err := p.Client.PullRequests.RequestReviewers(p.Context, pr.Owner, pr.Repo, *pr.Number, request)
+	if err != nil {
+		return fmt.Errorf("Failed to request reviews on PR #%d of %s/%s due to: %s", *pr.Number, pr.Owner, pr.Repo, err)
+	}
+	return nil
+}
+
 func (p *GitHubProvider) UpdatePullRequestStatus(pr *GitPullRequest) error {
 	if pr.Number == nil {
 		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
@@ -433,6 +750,27 @@ func (p *GitHubProvider) ValidateRepositoryName(org string, name string) error {
 	return err
 }
 
+// ValidateUserAuth checks the API token works against the server and has the
+// scopes required to manage repositories
+func (p *GitHubProvider) ValidateUserAuth() error {
+	user, resp, err := p.Client.Users.Get(p.Context, "")
+	if err != nil {
+		return fmt.Errorf("the server rejected the token: %s", err)
+	}
+	login := ""
+	if user != nil && user.Login != nil {
+		login = *user.Login
+	}
+	if p.Username != "" && login != "" && !strings.EqualFold(login, p.Username) {
+		return fmt.Errorf("the token belongs to user %s not %s", login, p.Username)
+	}
+	scopes := resp.Header.Get("X-OAuth-Scopes")
+	if scopes != "" && !strings.Contains(scopes, "repo") {
+		return fmt.Errorf("the token is missing the repo scope. It only has: %s", scopes)
+	}
+	return nil
+}
+
 func (p *GitHubProvider) UpdateRelease(owner string, repo string, tag string, releaseInfo *GitRelease) error {
 	release := &github.RepositoryRelease{}
 	rel, r, err := p.Client.Repositories.GetReleaseByTag(p.Context, owner, repo, tag)
@@ -534,6 +872,16 @@ func GitHubAccessTokenURL(url string) string {
 	return fmt.Sprintf("https://%s/settings/tokens/new?scopes=repo,read:user,user:email,write:repo_hook", url)
 }
 
+// Capabilities returns the features supported by this provider
+func (p *GitHubProvider) Capabilities() GitProviderCapabilities {
+	return GitProviderCapabilities{
+		SupportsWebhooks:     true,
+		SupportsPullRequests: true,
+		SupportsCommitStatus: true,
+		SupportsForks:        true,
+	}
+}
+
 func (p *GitHubProvider) Label() string {
 	return p.Server.Label()
 }
@@ -551,3 +899,10 @@ func asText(text *string) string {
 	}
 	return ""
 }
+
+func asInt(i *int) int {
+	if i != nil {
+		return *i
+	}
+	return 0
+}
@@ -0,0 +1,239 @@
+package gits
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// githubGraphQLURL is the GraphQL endpoint of github.com
+const githubGraphQLURL = "https://api.github.com/graphql"
+
+// GitHubRepositoryDetails is the repository information fetched in a single
+// GraphQL call: the plain repository data plus the default branch, the topics
+// and whether the current user administers it, which would otherwise take one
+// REST call each per repository
+type GitHubRepositoryDetails struct {
+	GitRepository
+
+	DefaultBranch string
+	Topics        []string
+	Admin         bool
+}
+
+// graphQLRequest is the JSON body of a GraphQL query
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// graphQLResponse is the JSON envelope of a GraphQL reply
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// graphQLQuery posts the query to the GraphQL endpoint and unmarshals the
+// data of the reply into the result
+func (p *GitHubProvider) graphQLQuery(query string, variables map[string]interface{}, result interface{}) error {
+	body, err := json.Marshal(&graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequest(http.MethodPost, p.graphQLURL(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "bearer "+p.User.ApiToken)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("GraphQL query failed: status %s", response.Status)
+	}
+	envelope := &graphQLResponse{}
+	err = json.NewDecoder(response.Body).Decode(envelope)
+	if err != nil {
+		return err
+	}
+	if len(envelope.Errors) > 0 {
+		messages := []string{}
+		for _, e := range envelope.Errors {
+			messages = append(messages, e.Message)
+		}
+		return fmt.Errorf("GraphQL query failed: %s", strings.Join(messages, "; "))
+	}
+	return json.Unmarshal(envelope.Data, result)
+}
+
+// graphQLURL returns the GraphQL endpoint of the server, supporting GitHub
+// Enterprise instances as well as github.com
+func (p *GitHubProvider) graphQLURL() string {
+	url := p.Server.URL
+	if url == "" || strings.Contains(url, "github.com") {
+		return githubGraphQLURL
+	}
+	return strings.TrimSuffix(url, "/") + "/api/graphql"
+}
+
+// listRepositoriesQuery pages through the repositories of an owner fetching
+// the default branch, topics and viewer permission in the same call
+const listRepositoriesQuery = `
+query($owner: String!, $cursor: String) {
+  repositoryOwner(login: $owner) {
+    repositories(first: 100, after: $cursor, ownerAffiliations: OWNER) {
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+      nodes {
+        name
+        url
+        sshUrl
+        viewerPermission
+        defaultBranchRef {
+          name
+        }
+        mergeCommitAllowed
+        repositoryTopics(first: 50) {
+          nodes {
+            topic {
+              name
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// ListRepositoriesWithDetails lists the repositories of the owner with their
+// default branch, topics and admin permission in one GraphQL call per page
+func (p *GitHubProvider) ListRepositoriesWithDetails(org string) ([]*GitHubRepositoryDetails, error) {
+	owner := org
+	if owner == "" {
+		owner = p.Username
+	}
+	answer := []*GitHubRepositoryDetails{}
+	var data struct {
+		RepositoryOwner *struct {
+			Repositories struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []struct {
+					Name             string `json:"name"`
+					URL              string `json:"url"`
+					SSHURL           string `json:"sshUrl"`
+					ViewerPermission string `json:"viewerPermission"`
+					DefaultBranchRef *struct {
+						Name string `json:"name"`
+					} `json:"defaultBranchRef"`
+					MergeCommitAllowed bool `json:"mergeCommitAllowed"`
+					RepositoryTopics   struct {
+						Nodes []struct {
+							Topic struct {
+								Name string `json:"name"`
+							} `json:"topic"`
+						} `json:"nodes"`
+					} `json:"repositoryTopics"`
+				} `json:"nodes"`
+			} `json:"repositories"`
+		} `json:"repositoryOwner"`
+	}
+	variables := map[string]interface{}{
+		"owner": owner,
+	}
+	for {
+		err := p.graphQLQuery(listRepositoriesQuery, variables, &data)
+		if err != nil {
+			return answer, err
+		}
+		if data.RepositoryOwner == nil {
+			return answer, fmt.Errorf("No GitHub user or organisation found for %s", owner)
+		}
+		repositories := &data.RepositoryOwner.Repositories
+		for _, node := range repositories.Nodes {
+			details := &GitHubRepositoryDetails{
+				GitRepository: GitRepository{
+					Name:             node.Name,
+					HTMLURL:          node.URL,
+					CloneURL:         node.URL + ".git",
+					SSHURL:           node.SSHURL,
+					AllowMergeCommit: node.MergeCommitAllowed,
+				},
+				Admin: node.ViewerPermission == "ADMIN",
+			}
+			if node.DefaultBranchRef != nil {
+				details.DefaultBranch = node.DefaultBranchRef.Name
+			}
+			for _, topic := range node.RepositoryTopics.Nodes {
+				details.Topics = append(details.Topics, topic.Topic.Name)
+			}
+			answer = append(answer, details)
+		}
+		if !repositories.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = repositories.PageInfo.EndCursor
+	}
+	return answer, nil
+}
+
+// BatchPullRequestStatuses returns the combined commit status of the head of
+// each pull request keyed by number, using aliased fields so all the pull
+// requests are looked up in one GraphQL call
+func (p *GitHubProvider) BatchPullRequestStatuses(org string, repo string, numbers []int) (map[int]string, error) {
+	answer := map[int]string{}
+	if len(numbers) == 0 {
+		return answer, nil
+	}
+	owner := org
+	if owner == "" {
+		owner = p.Username
+	}
+	buffer := &bytes.Buffer{}
+	fmt.Fprintf(buffer, "query {\n  repository(owner: %q, name: %q) {\n", owner, repo)
+	for _, number := range numbers {
+		fmt.Fprintf(buffer, "    pr%d: pullRequest(number: %d) { commits(last: 1) { nodes { commit { status { state } } } } }\n", number, number)
+	}
+	fmt.Fprintf(buffer, "  }\n}")
+
+	var data struct {
+		Repository map[string]struct {
+			Commits struct {
+				Nodes []struct {
+					Commit struct {
+						Status *struct {
+							State string `json:"state"`
+						} `json:"status"`
+					} `json:"commit"`
+				} `json:"nodes"`
+			} `json:"commits"`
+		} `json:"repository"`
+	}
+	err := p.graphQLQuery(buffer.String(), nil, &data)
+	if err != nil {
+		return answer, err
+	}
+	for _, number := range numbers {
+		node, ok := data.Repository[fmt.Sprintf("pr%d", number)]
+		if !ok {
+			continue
+		}
+		for _, commit := range node.Commits.Nodes {
+			if commit.Commit.Status != nil {
+				answer[number] = strings.ToLower(commit.Commit.Status.State)
+			}
+		}
+	}
+	return answer, nil
+}
@@ -70,9 +70,61 @@ type GitProvider interface {
 
 	JenkinsWebHookPath(gitURL string, secret string) string
 
+	// Capabilities returns the optional features this provider supports so that
+	// commands can degrade gracefully rather than erroring
+	Capabilities() GitProviderCapabilities
+
 	Label() string
 }
 
+// GitProviderCapabilities describes which optional features a git provider
+// supports. Commands should check these and warn + skip rather than fail
+// when a provider lacks a feature
+type GitProviderCapabilities struct {
+	SupportsWebhooks     bool
+	SupportsPullRequests bool
+	SupportsCommitStatus bool
+	SupportsForks        bool
+}
+
+// ManagedRepoTopic is the repository topic used to mark repositories which are
+// imported into and managed by the platform so that org wide tooling can
+// discover them
+const ManagedRepoTopic = "jenkins-x"
+
+// GitTopicsProvider is an optional interface for git providers which support
+// tagging repositories with topics or labels
+type GitTopicsProvider interface {
+	// ListRepositoryTopics returns the topics of the repository
+	ListRepositoryTopics(org string, name string) ([]string, error)
+
+	// UpdateRepositoryTopics replaces the topics of the repository
+	UpdateRepositoryTopics(org string, name string, topics []string) error
+}
+
+// GitWebHook represents a webhook registered on a repository
+type GitWebHook struct {
+	ID  int64
+	URL string
+}
+
+// GitWebHookManager is an optional interface for git providers which can list
+// and remove the webhooks registered on a repository
+type GitWebHookManager interface {
+	// ListWebHooks returns the webhooks registered on the repository
+	ListWebHooks(org string, name string) ([]*GitWebHook, error)
+
+	// DeleteWebHook removes the webhook with the given ID from the repository
+	DeleteWebHook(org string, name string, id int64) error
+}
+
+// GitUserAuthValidator is an optional interface for git providers which can
+// verify a user's API token against the server before it is saved
+type GitUserAuthValidator interface {
+	// ValidateUserAuth checks the API token works and has the scopes required to manage repositories
+	ValidateUserAuth() error
+}
+
 type GitOrganisation struct {
 	Login string
 }
@@ -89,6 +141,12 @@ type GitPullRequest struct {
 	URL            string
 	Owner          string
 	Repo           string
+	Title          string
+	Author         string
+	Labels         []string
+	HeadRef        string
+	HeadOwner      string
+	BaseRef        string
 	Number         *int
 	Mergeable      *bool
 	Merged         *bool
@@ -177,21 +235,36 @@ func (pr *GitPullRequest) IsClosed() bool {
 }
 
 func CreateProvider(server *auth.AuthServer, user *auth.UserAuth) (GitProvider, error) {
-	switch server.Kind {
-	case "gitea":
-		return NewGiteaProvider(server, user)
-	default:
-		return NewGitHubProvider(server, user)
+	if server.Kind == "" {
+		// record the kind on the server so it is saved with the auth config
+		// rather than being inferred again next time
+		server.Kind = SaasGitKind(server.URL)
+		if server.Kind == "" {
+			server.Kind = KindGitHub
+		}
+	}
+	registration := GitProviderRegistrationForKind(server.Kind)
+	if registration == nil {
+		return nil, fmt.Errorf("Unsupported git provider kind %s for server %s. Supported kinds: %s", server.Kind, server.URL, strings.Join(GitProviderKinds(), ", "))
+	}
+	return registration.Factory(server, user)
+}
+
+// ProviderCapabilities returns the capabilities of the given git provider kind
+func ProviderCapabilities(kind string) GitProviderCapabilities {
+	registration := GitProviderRegistrationForKind(kind)
+	if registration == nil {
+		registration = GitProviderRegistrationForKind(KindGitHub)
 	}
+	return registration.Capabilities
 }
 
 func ProviderAccessTokenURL(kind string, url string) string {
-	switch kind {
-	case "gitea":
-		return GiteaAccessTokenURL(url)
-	default:
-		return GitHubAccessTokenURL(url)
+	registration := GitProviderRegistrationForKind(kind)
+	if registration == nil {
+		registration = GitProviderRegistrationForKind(KindGitHub)
 	}
+	return registration.AccessTokenURL(url)
 }
 
 // PickOrganisation picks an organisations login if there is one available
@@ -231,7 +304,7 @@ func PickOrganisation(provider GitProvider, userName string) (string, error) {
 	return orgName, nil
 }
 
-func PickRepositories(provider GitProvider, owner string, message string, selectAll bool, filter string) ([]*GitRepository, error) {
+func PickRepositories(provider GitProvider, owner string, message string, batchMode bool, selectAll bool, filter string) ([]*GitRepository, error) {
 	answer := []*GitRepository{}
 	repos, err := provider.ListRepositories(owner)
 	if err != nil {
@@ -252,6 +325,16 @@ func PickRepositories(provider GitProvider, owner string, message string, select
 	}
 	sort.Strings(allRepoNames)
 
+	if batchMode {
+		if !selectAll && filter == "" {
+			return answer, fmt.Errorf("Please specify the --all or --filter option to select the repositories when running in batch mode")
+		}
+		for _, n := range allRepoNames {
+			answer = append(answer, repoMap[n])
+		}
+		return answer, nil
+	}
+
 	prompt := &survey.MultiSelect{
 		Message: message,
 		Options: allRepoNames,
@@ -313,6 +396,9 @@ func (i *GitRepositoryInfo) CreateProviderForUser(server *auth.AuthServer, user
 	if i.Host == GitHubHost {
 		return NewGitHubProvider(server, user)
 	}
+	if server.Kind != "" {
+		return CreateProvider(server, user)
+	}
 	return nil, fmt.Errorf("Git provider not supported for host %s", i.Host)
 }
 
@@ -323,7 +409,7 @@ func (i *GitRepositoryInfo) CreateProvider(authConfigSvc auth.AuthConfigService)
 	userAuths := authConfigSvc.Config().FindUserAuths(url)
 	if len(userAuths) == 1 {
 		auth := userAuths[0]
-		return NewGitHubProvider(server, auth)
+		return CreateProvider(server, auth)
 	}
 	return nil, fmt.Errorf("Git provider not supported for host %s", i.Host)
 }
@@ -0,0 +1,128 @@
+package gits
+
+import (
+	"sort"
+
+	"github.com/jenkins-x/jx/pkg/auth"
+)
+
+const (
+	// KindAzureDevOps the kind for Azure DevOps / VSTS servers
+	KindAzureDevOps = "azuredevops"
+	// KindBitBucketCloud the kind for bitbucket.org
+	KindBitBucketCloud = "bitbucketcloud"
+	// KindBitBucketServer the kind for self hosted Bitbucket Server
+	KindBitBucketServer = "bitbucketserver"
+	// KindCodeCommit the kind for AWS CodeCommit
+	KindCodeCommit = "codecommit"
+	// KindGitea the kind for Gitea servers
+	KindGitea = "gitea"
+	// KindGitHub the kind for github.com and GitHub Enterprise servers
+	KindGitHub = "github"
+)
+
+// GitProviderFactory creates a git provider for the given server and user auth
+type GitProviderFactory func(server *auth.AuthServer, user *auth.UserAuth) (GitProvider, error)
+
+// GitProviderRegistration describes a git provider kind compiled into the
+// binary. Third parties can support additional git servers by registering a
+// new kind from an init function in their own package
+type GitProviderRegistration struct {
+	// Kind is the identifier stored in the auth config and GitService resources
+	Kind string
+
+	// Factory creates the provider for a server of this kind
+	Factory GitProviderFactory
+
+	// Capabilities describes which optional features the provider supports
+	Capabilities GitProviderCapabilities
+
+	// AccessTokenURL returns the page on the server where an API token can be created
+	AccessTokenURL func(url string) string
+}
+
+var gitProviderRegistry = map[string]*GitProviderRegistration{}
+
+func init() {
+	fullCapabilities := GitProviderCapabilities{
+		SupportsWebhooks:     true,
+		SupportsPullRequests: true,
+		SupportsCommitStatus: true,
+		SupportsForks:        true,
+	}
+	RegisterGitProvider(GitProviderRegistration{
+		Kind:           KindAzureDevOps,
+		Factory:        NewAzureDevOpsProvider,
+		Capabilities:   fullCapabilities,
+		AccessTokenURL: AzureDevOpsAccessTokenURL,
+	})
+	RegisterGitProvider(GitProviderRegistration{
+		Kind:           KindBitBucketCloud,
+		Factory:        NewBitbucketCloudProvider,
+		Capabilities:   fullCapabilities,
+		AccessTokenURL: BitbucketCloudAccessTokenURL,
+	})
+	RegisterGitProvider(GitProviderRegistration{
+		Kind:           KindBitBucketServer,
+		Factory:        NewBitbucketServerProvider,
+		Capabilities:   fullCapabilities,
+		AccessTokenURL: BitbucketServerAccessTokenURL,
+	})
+	RegisterGitProvider(GitProviderRegistration{
+		Kind:           KindCodeCommit,
+		Factory:        NewCodeCommitProvider,
+		Capabilities:   GitProviderCapabilities{SupportsPullRequests: true},
+		AccessTokenURL: CodeCommitAccessTokenURL,
+	})
+	RegisterGitProvider(GitProviderRegistration{
+		Kind:           KindGitea,
+		Factory:        NewGiteaProvider,
+		Capabilities:   fullCapabilities,
+		AccessTokenURL: GiteaAccessTokenURL,
+	})
+	RegisterGitProvider(GitProviderRegistration{
+		Kind:           KindGitHub,
+		Factory:        NewGitHubProvider,
+		Capabilities:   fullCapabilities,
+		AccessTokenURL: GitHubAccessTokenURL,
+	})
+}
+
+// RegisterGitProvider registers a git provider kind so that servers of that
+// kind can be created via CreateProvider. Registering an already registered
+// kind replaces the previous registration
+func RegisterGitProvider(registration GitProviderRegistration) {
+	r := registration
+	gitProviderRegistry[r.Kind] = &r
+}
+
+// GitProviderRegistrationForKind returns the registration of the given git
+// provider kind or nil if the kind is not registered
+func GitProviderRegistrationForKind(kind string) *GitProviderRegistration {
+	return gitProviderRegistry[kind]
+}
+
+// GitProviderKinds returns the sorted kinds of all the registered git providers
+func GitProviderKinds() []string {
+	kinds := []string{}
+	for kind := range gitProviderRegistry {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// SaasGitKind returns the git provider kind of well known SaaS git hosting
+// sites or the empty string if the URL is not recognised
+func SaasGitKind(gitServiceUrl string) string {
+	switch gitServiceUrl {
+	case "github.com", "http://github.com", "https://github.com":
+		return KindGitHub
+	case "bitbucket.org", "http://bitbucket.org", "https://bitbucket.org":
+		return KindBitBucketCloud
+	case "gitea.com", "http://gitea.com", "https://gitea.com":
+		return KindGitea
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,118 @@
+package jenkins
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// AgentScheduling describes how the Jenkins build agent pods should be
+// scheduled so that builds can run on spot / preemptible node pools
+type AgentScheduling struct {
+	NodeSelector  string
+	Tolerations   []string
+	PriorityClass string
+}
+
+// podTemplateBlockPattern matches a whole pod template element in the Jenkins
+// config.xml so that its scheduling settings can be updated in place
+var podTemplateBlockPattern = regexp.MustCompile(`(?s)<` + podTemplateElement + `>.*?</` + podTemplateElement + `>`)
+
+// ApplyAgentScheduling updates every pod template in the given Jenkins
+// config.xml with the node selector, tolerations and priority class of the
+// given scheduling so that build agents land on the desired node pool
+func ApplyAgentScheduling(configXml string, scheduling *AgentScheduling) (string, error) {
+	if scheduling == nil {
+		return configXml, nil
+	}
+	yaml, err := SchedulingYaml(scheduling)
+	if err != nil {
+		return configXml, err
+	}
+	answer := podTemplateBlockPattern.ReplaceAllStringFunc(configXml, func(block string) string {
+		if scheduling.NodeSelector != "" {
+			block = setPodTemplateElement(block, "nodeSelector", scheduling.NodeSelector)
+		}
+		if yaml != "" {
+			block = setPodTemplateElement(block, "yaml", yaml)
+		}
+		return block
+	})
+	return answer, nil
+}
+
+// SchedulingYaml returns the pod yaml snippet the kubernetes plugin merges
+// into the agent pods to apply the tolerations and priority class, as those
+// have no first class fields in the pod template configuration
+func SchedulingYaml(scheduling *AgentScheduling) (string, error) {
+	if len(scheduling.Tolerations) == 0 && scheduling.PriorityClass == "" {
+		return "", nil
+	}
+	buffer := &bytes.Buffer{}
+	buffer.WriteString("spec:\n")
+	if scheduling.PriorityClass != "" {
+		buffer.WriteString("  priorityClassName: " + scheduling.PriorityClass + "\n")
+	}
+	if len(scheduling.Tolerations) > 0 {
+		buffer.WriteString("  tolerations:\n")
+		for _, toleration := range scheduling.Tolerations {
+			key, value, effect, err := parseToleration(toleration)
+			if err != nil {
+				return "", err
+			}
+			buffer.WriteString("  - key: \"" + key + "\"\n")
+			if value != "" {
+				buffer.WriteString("    operator: \"Equal\"\n")
+				buffer.WriteString("    value: \"" + value + "\"\n")
+			} else {
+				buffer.WriteString("    operator: \"Exists\"\n")
+			}
+			if effect != "" {
+				buffer.WriteString("    effect: \"" + effect + "\"\n")
+			}
+		}
+	}
+	return buffer.String(), nil
+}
+
+// parseToleration parses a toleration of the key=value:Effect form where both
+// the value and the effect are optional
+func parseToleration(toleration string) (string, string, string, error) {
+	text := strings.TrimSpace(toleration)
+	effect := ""
+	idx := strings.LastIndex(text, ":")
+	if idx >= 0 {
+		effect = text[idx+1:]
+		text = text[:idx]
+	}
+	key := text
+	value := ""
+	idx = strings.Index(text, "=")
+	if idx >= 0 {
+		key = text[:idx]
+		value = text[idx+1:]
+	}
+	if key == "" {
+		return "", "", "", fmt.Errorf("Invalid toleration %s. Please use the key=value:Effect form such as cloud.google.com/gke-preemptible=true:NoSchedule", toleration)
+	}
+	return key, value, effect, nil
+}
+
+// setPodTemplateElement replaces the value of the given child element inside a
+// pod template block, adding the element when it is not present yet
+func setPodTemplateElement(block string, name string, value string) string {
+	buffer := &bytes.Buffer{}
+	xml.EscapeText(buffer, []byte(value))
+	escaped := buffer.String()
+	element := "<" + name + ">" + escaped + "</" + name + ">"
+	pattern := regexp.MustCompile(`(?s)<` + name + `\s*/>|<` + name + `>.*?</` + name + `>`)
+	if pattern.MatchString(block) {
+		return pattern.ReplaceAllStringFunc(block, func(string) string {
+			return element
+		})
+	}
+	closing := "</" + podTemplateElement + ">"
+	return strings.Replace(block, closing, "  "+element+"\n"+closing, 1)
+}
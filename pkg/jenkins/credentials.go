@@ -0,0 +1,96 @@
+package jenkins
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/golang-jenkins"
+	"gopkg.in/AlecAivazis/survey.v1"
+)
+
+// createCredentialOption is the pick list entry which creates a new credential
+const createCredentialOption = "< create a new credential >"
+
+// PickPipelineCredentials returns the id of the Jenkins credentials the
+// pipelines of the given git host should use. The existing credentials are
+// queried through the Jenkins credentials API so the user can pick one of
+// them or choose to create a new username/token credential for the server
+func PickPipelineCredentials(jenk *gojenkins.Jenkins, host string, batchMode bool) (string, error) {
+	suggested := DefaultJenkinsCredentialsPrefix + "git"
+	ids := credentialIDs(jenk)
+	for _, id := range ids {
+		if credentialMatchesHost(id, host) {
+			suggested = id
+			break
+		}
+	}
+	if batchMode || len(ids) == 0 {
+		return suggested, nil
+	}
+	defaultOption := createCredentialOption
+	for _, id := range ids {
+		if id == suggested {
+			defaultOption = suggested
+			break
+		}
+	}
+	answer := ""
+	prompt := &survey.Select{
+		Message: fmt.Sprintf("Which Jenkins credentials should the pipelines of %s use?", host),
+		Options: append(ids, createCredentialOption),
+		Default: defaultOption,
+	}
+	err := survey.AskOne(prompt, &answer, nil)
+	if err != nil {
+		return "", err
+	}
+	if answer == createCredentialOption {
+		return DefaultJenkinsCredentialsPrefix + hostLabel(host), nil
+	}
+	return answer, nil
+}
+
+// credentialIDs returns the sorted ids of the credentials in the system
+// domain. Errors are ignored so that older Jenkins masters without the
+// credentials API still fall back to the default credential id
+func credentialIDs(jenk *gojenkins.Jenkins) []string {
+	answer := []string{}
+	list, err := jenk.GetCredentials()
+	if err != nil {
+		return answer
+	}
+	for _, c := range list.Credentials {
+		if c.Id != "" {
+			answer = append(answer, c.Id)
+		}
+	}
+	sort.Strings(answer)
+	return answer
+}
+
+// credentialMatchesHost returns true if the credential id looks like it
+// belongs to the git host, such as jenkins-x-github for github.com
+func credentialMatchesHost(id string, host string) bool {
+	if !strings.HasPrefix(id, DefaultJenkinsCredentialsPrefix) {
+		return false
+	}
+	suffix := strings.TrimPrefix(id, DefaultJenkinsCredentialsPrefix)
+	return suffix != "" && strings.Contains(strings.ToLower(host), strings.ToLower(suffix))
+}
+
+// hostLabel returns the first label of the host such as github for github.com
+// so it can be used in a credential id
+func hostLabel(host string) string {
+	label := strings.ToLower(host)
+	if idx := strings.Index(label, ":"); idx >= 0 {
+		label = label[:idx]
+	}
+	if idx := strings.Index(label, "."); idx >= 0 {
+		label = label[:idx]
+	}
+	if label == "" {
+		return "git"
+	}
+	return label
+}
@@ -0,0 +1,65 @@
+package jenkins
+
+import (
+	"sort"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+)
+
+// The environment variable contract injected into every pipeline so that
+// Jenkinsfiles do not need to hard code platform specific values such as
+// $JENKINS_X_DOCKER_REGISTRY_SERVICE_HOST. See docs/environment-variables.md
+const (
+	// EnvVarAppName the name of the application being built
+	EnvVarAppName = "APP_NAME"
+
+	// EnvVarOrg the organisation the application belongs to
+	EnvVarOrg = "ORG"
+
+	// EnvVarGitURL the git URL of the repository being built
+	EnvVarGitURL = "GIT_URL"
+
+	// EnvVarDockerRegistry the host and port of the docker registry to push images to
+	EnvVarDockerRegistry = "DOCKER_REGISTRY"
+
+	// EnvVarPreviewNamespace the namespace used for preview environments of pull requests
+	EnvVarPreviewNamespace = "PREVIEW_NAMESPACE"
+
+	// EnvVarVersion the version of the application being released
+	EnvVarVersion = "VERSION"
+)
+
+// ProjectEnvVars returns the environment variables injected into the jobs of the
+// given project at import time. DOCKER_REGISTRY, PREVIEW_NAMESPACE and VERSION are
+// resolved by the platform or the pipeline itself at build time
+func ProjectEnvVars(info *gits.GitRepositoryInfo) map[string]string {
+	return map[string]string{
+		EnvVarAppName: info.Name,
+		EnvVarOrg:     info.Organisation,
+		EnvVarGitURL:  info.HttpCloneURL(),
+	}
+}
+
+// envVarsFolderProperties returns the folder properties which inject the given
+// environment variables into every build within the job
+func envVarsFolderProperties(vars map[string]string) *EnvVarFolderProperties {
+	if len(vars) == 0 {
+		return nil
+	}
+	keys := []string{}
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	properties := []EnvVarStringProperty{}
+	for _, k := range keys {
+		properties = append(properties, EnvVarStringProperty{
+			Key:   k,
+			Value: vars[k],
+		})
+	}
+	return &EnvVarFolderProperties{
+		Plugin:     "folder-properties@1.0",
+		Properties: properties,
+	}
+}
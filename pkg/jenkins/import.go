@@ -1,18 +1,38 @@
 package jenkins
 
 import (
+	"bytes"
 	"fmt"
 	"io"
-	"net/url"
+	"strings"
+	"time"
 
 	"github.com/jenkins-x/golang-jenkins"
 	"github.com/jenkins-x/jx/pkg/auth"
 	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/util"
+	"gopkg.in/AlecAivazis/survey.v1"
 )
 
-// ImportProject imports a MultiBranchProject into Jeknins for the given git URL
-func ImportProject(out io.Writer, jenk *gojenkins.Jenkins, gitURL string, jenkinsfile string, credentials string, failIfExists bool, gitProvider gits.GitProvider, authConfigSvc auth.AuthConfigService) error {
+const (
+	// JobExistsAsk prompts the user what to do when the job already exists
+	JobExistsAsk = "ask"
+	// JobExistsReuse keeps the existing job and triggers a new scan of it
+	JobExistsReuse = "reuse"
+	// JobExistsReplace replaces the configuration of the existing job with the newly generated one
+	JobExistsReplace = "replace"
+	// JobExistsRename creates the job under a different name
+	JobExistsRename = "rename"
+	// JobExistsSkip leaves the existing job untouched
+	JobExistsSkip = "skip"
+)
+
+// JobExistsModes the modes of handling a job which already exists in Jenkins
+var JobExistsModes = []string{JobExistsReuse, JobExistsReplace, JobExistsRename, JobExistsSkip}
+
+// ImportProject imports a MultiBranchProject into Jeknins for the given git URL.
+// An empty jobName defaults to the repository name
+func ImportProject(out io.Writer, jenk *gojenkins.Jenkins, gitURL string, jobName string, jenkinsfile string, credentials string, failIfExists bool, jobExistsMode string, batchMode bool, gitProvider gits.GitProvider, authConfigSvc auth.AuthConfigService, behaviours *BranchSourceBehaviours) error {
 	if gitURL == "" {
 		return fmt.Errorf("No Git repository URL found!")
 	}
@@ -20,15 +40,21 @@ func ImportProject(out io.Writer, jenk *gojenkins.Jenkins, gitURL string, jenkin
 	if err != nil {
 		return fmt.Errorf("Failed to parse git URL %s due to: %s", gitURL, err)
 	}
+	if jobName == "" {
+		jobName = gitInfo.Name
+	}
 
 	if credentials == "" {
-		credentials = DefaultJenkinsCredentialsPrefix + "git"
+		credentials, err = PickPipelineCredentials(jenk, gitInfo.Host, batchMode)
+		if err != nil {
+			return err
+		}
 	}
 	_, err = jenk.GetCredential(credentials)
 	if err != nil {
 		config := authConfigSvc.Config()
 		server := config.GetOrCreateServer(gitInfo.Host)
-		user, err := config.PickServerUserAuth(server, "user name for the Jenkins Pipeline", false)
+		user, err := config.PickServerUserAuth(server, "user name for the Jenkins Pipeline", batchMode)
 		if err != nil {
 			return err
 		}
@@ -37,54 +63,140 @@ func ImportProject(out io.Writer, jenk *gojenkins.Jenkins, gitURL string, jenkin
 		if err != nil {
 			return fmt.Errorf("error creating jenkins credential %s at %s %v", credentials, jenk.BaseURL(), err)
 		}
+		fmt.Fprintf(out, "Created the Jenkins credential %s for user %s\n", util.ColorInfo(credentials), util.ColorInfo(user.Username))
 	}
 
-	org := gitInfo.Organisation
-	folder, err := jenk.GetJob(org)
-	if err != nil {
-		// could not find folder so lets try create it
-		jobUrl := util.UrlJoin(jenk.BaseURL(), jenk.GetJobURLPath(org))
-		folderXml := CreateFolderXml(jobUrl, org)
-		//fmt.Fprintf(out, "XML: %s\n", folderXml)
-		err = jenk.CreateJobWithXML(folderXml, org)
+	// create the folder for the organisation along with a nested folder for
+	// each nested group in the repository path
+	folders := gitInfo.OrganisationFolders()
+	for i, folderName := range folders {
+		folderPath := strings.Join(folders[:i+1], "/")
+		folder, err := jenk.GetJob(folderPath)
 		if err != nil {
-			return fmt.Errorf("Failed to create the %s folder in jenkins: %s", org, err)
-		}
-		//fmt.Fprintf(out, "Created Jenkins folder: %s\n", org)
-	} else {
-		c := folder.Class
-		if c != "com.cloudbees.hudson.plugins.folder.Folder" {
-			fmt.Fprintf(out, "Warning the folder %s is of class %s", org, c)
+			// could not find folder so lets try create it
+			jobUrl := util.UrlJoin(jenk.BaseURL(), jenk.GetJobURLPath(folderPath))
+			folderXml, err := CreateFolderXml(jobUrl, folderName)
+			if err != nil {
+				return err
+			}
+			//fmt.Fprintf(out, "XML: %s\n", folderXml)
+			if i == 0 {
+				err = jenk.CreateJobWithXML(folderXml, folderName)
+			} else {
+				err = jenk.CreateFolderJobWithXML(folderXml, strings.Join(folders[:i], "/job/"), folderName)
+			}
+			if err != nil {
+				return fmt.Errorf("Failed to create the %s folder in jenkins: %s", folderPath, err)
+			}
+			//fmt.Fprintf(out, "Created Jenkins folder: %s\n", folderPath)
+		} else {
+			c := folder.Class
+			if c != "com.cloudbees.hudson.plugins.folder.Folder" {
+				fmt.Fprintf(out, "Warning the folder %s is of class %s", folderPath, c)
+			}
 		}
 	}
-	projectXml := CreateMultiBranchProjectXml(gitInfo, gitProvider, credentials, jenkinsfile)
-	jobName := gitInfo.Name
-	job, err := jenk.GetJobByPath(org, jobName)
+	projectXml, err := CreateMultiBranchProjectXml(gitInfo, gitProvider, credentials, jenkinsfile, behaviours)
+	if err != nil {
+		return err
+	}
+	folderPath := strings.Join(folders, "/")
+	jobPath := append(folders, jobName)
+	createJob := true
+	job, err := jenk.GetJobByPath(jobPath...)
 	if err == nil {
+		createJob = false
 		if failIfExists {
 			return fmt.Errorf("Job already exists in Jenkins at %s", job.Url)
-		} else {
+		}
+		mode := jobExistsMode
+		if mode == "" || mode == JobExistsAsk {
+			if batchMode {
+				return fmt.Errorf("Job already exists in Jenkins at %s. Please specify how to handle it with --job-exists: %s", job.Url, strings.Join(JobExistsModes, ", "))
+			}
+			prompt := &survey.Select{
+				Message: fmt.Sprintf("Job already exists in Jenkins at %s. What would you like to do?", job.Url),
+				Options: JobExistsModes,
+				Default: JobExistsReuse,
+			}
+			err = survey.AskOne(prompt, &mode, nil)
+			if err != nil {
+				return err
+			}
+		}
+		switch mode {
+		case JobExistsSkip:
 			fmt.Fprintf(out, "Job already exists in Jenkins at %s\n", job.Url)
+		case JobExistsReuse:
+			fmt.Fprintf(out, "Reusing the existing job %s\n", util.ColorInfo(job.Url))
+			err = jenk.TriggerBranchIndexing(jobPath...)
+			if err != nil {
+				return fmt.Errorf("Failed to trigger branch indexing of %s due to %s", job.Url, err)
+			}
+		case JobExistsReplace:
+			oldConfig, err := jenk.GetJobConfigXML(jobPath...)
+			if err != nil {
+				fmt.Fprintf(out, "Warning: could not load the current configuration of job %s: %s\n", job.Url, err)
+			} else {
+				diff := configXmlDiff(normalizeConfigXml(oldConfig), projectXml)
+				if diff == "" {
+					fmt.Fprintf(out, "The configuration of job %s is already up to date\n", util.ColorInfo(job.Url))
+					break
+				}
+				fmt.Fprintf(out, "Changes to the configuration of job %s:\n%s\n", job.Url, diff)
+			}
+			err = jenk.UpdateJobWithXML(projectXml, jobPath...)
+			if err != nil {
+				return fmt.Errorf("Failed to update the configuration of job %s due to: %s", job.Url, err)
+			}
+			fmt.Fprintf(out, "Replaced the configuration of job %s\n", util.ColorInfo(job.Url))
+			err = jenk.TriggerBranchIndexing(jobPath...)
+			if err != nil {
+				return fmt.Errorf("Failed to trigger branch indexing of %s due to %s", job.Url, err)
+			}
+		case JobExistsRename:
+			newName := ""
+			input := &survey.Input{
+				Message: "Name for the new job:",
+				Default: jobName + "-2",
+			}
+			err = survey.AskOne(input, &newName, survey.Required)
+			if err != nil {
+				return err
+			}
+			jobName = newName
+			jobPath = append(folders, jobName)
+			createJob = true
+		default:
+			return util.InvalidArg(mode, JobExistsModes)
 		}
-	} else {
+	}
+	if createJob {
 		//fmt.Fprintf(out, "Creating MultiBranchProject %s from XML: %s\n", jobName, projectXml)
-		err = jenk.CreateFolderJobWithXML(projectXml, org, jobName)
+		err = jenk.CreateFolderJobWithXML(projectXml, strings.Join(folders, "/job/"), jobName)
 		if err != nil {
-			return fmt.Errorf("Failed to create MultiBranchProject job %s in folder %s due to: %s", jobName, org, err)
+			return fmt.Errorf("Failed to create MultiBranchProject job %s in folder %s due to: %s", jobName, folderPath, err)
 		}
-		job, err = jenk.GetJobByPath(org, jobName)
+		job, err = jenk.GetJobByPath(jobPath...)
 		if err != nil {
-			return fmt.Errorf("Failed to find the MultiBranchProject job %s in folder %s due to: %s", jobName, org, err)
+			return fmt.Errorf("Failed to find the MultiBranchProject job %s in folder %s due to: %s", jobName, folderPath, err)
 		}
 		fmt.Fprintf(out, "Created Jenkins Project: %s\n", util.ColorInfo(job.Url))
-		params := url.Values{}
-		err = jenk.Build(job, params)
+		err = jenk.TriggerBranchIndexing(jobPath...)
+		if err != nil {
+			return fmt.Errorf("Failed to trigger branch indexing of %s due to %s", job.Url, err)
+		}
+		err = waitForMasterBranchBuild(out, jenk, jobPath)
 		if err != nil {
-			return fmt.Errorf("Failed to trigger job %s due to %s", job.Url, err)
+			return err
 		}
 	}
 
-	// register the webhook
+	// register the webhook if the provider supports them
+	if !gitProvider.Capabilities().SupportsWebhooks {
+		fmt.Fprintf(out, "WARNING: the git provider %s does not support webhooks so you will need to trigger builds another way\n", gitProvider.Label())
+		return nil
+	}
 	suffix := gitProvider.JenkinsWebHookPath(gitURL, "")
 	webhookUrl := util.UrlJoin(jenk.BaseURL(), suffix)
 	webhook := &gits.GitWebHookArguments{
@@ -94,3 +206,60 @@ func ImportProject(out io.Writer, jenk *gojenkins.Jenkins, gitURL string, jenkin
 	}
 	return gitProvider.CreateWebHook(webhook)
 }
+
+// waitForMasterBranchBuild waits for branch indexing to discover the master branch
+// of the multi branch project and reports the first build once it starts
+func waitForMasterBranchBuild(out io.Writer, jenk *gojenkins.Jenkins, jobPath []string) error {
+	masterPath := append(append([]string{}, jobPath...), "master")
+	fmt.Fprintf(out, "Waiting for the branch indexing to discover the master branch...\n")
+	var job gojenkins.Job
+	var err error
+	for i := 0; i < 60; i++ {
+		job, err = jenk.GetJobByPath(masterPath...)
+		if err == nil {
+			break
+		}
+		time.Sleep(2 * time.Second)
+	}
+	if err != nil {
+		return fmt.Errorf("Branch indexing did not discover a master branch job: %s", err)
+	}
+	fmt.Fprintf(out, "Discovered the master branch job %s\n", util.ColorInfo(job.Url))
+	for i := 0; i < 60; i++ {
+		build, err := jenk.GetLastBuild(job)
+		if err == nil {
+			fmt.Fprintf(out, "The first build %s has started\n", util.ColorInfo(build.Url))
+			return nil
+		}
+		time.Sleep(2 * time.Second)
+	}
+	fmt.Fprintf(out, "No build of the master branch has started yet. You can watch its progress at %s\n", job.Url)
+	return nil
+}
+
+// configXmlDiff returns a simple line based diff of the old and new config XML
+// so that users can see what replacing a job configuration would change
+func configXmlDiff(oldXml string, newXml string) string {
+	oldLines := strings.Split(strings.TrimSpace(oldXml), "\n")
+	newLines := strings.Split(strings.TrimSpace(newXml), "\n")
+	oldSet := map[string]bool{}
+	for _, line := range oldLines {
+		oldSet[strings.TrimSpace(line)] = true
+	}
+	newSet := map[string]bool{}
+	for _, line := range newLines {
+		newSet[strings.TrimSpace(line)] = true
+	}
+	buffer := &bytes.Buffer{}
+	for _, line := range oldLines {
+		if !newSet[strings.TrimSpace(line)] {
+			buffer.WriteString(util.ColorError("- "+line) + "\n")
+		}
+	}
+	for _, line := range newLines {
+		if !oldSet[strings.TrimSpace(line)] {
+			buffer.WriteString(util.ColorInfo("+ "+line) + "\n")
+		}
+	}
+	return buffer.String()
+}
@@ -4,135 +4,287 @@ import (
 	"github.com/jenkins-x/jx/pkg/gits"
 )
 
-func CreateFolderXml(folderUrl string, name string) string {
-	return `<?xml version='1.0' encoding='UTF-8'?>
-<com.cloudbees.hudson.plugins.folder.Folder plugin="cloudbees-folder@6.2.1">
-  <actions>
-    <io.jenkins.blueocean.service.embedded.BlueOceanUrlAction plugin="blueocean-rest-impl@1.3.3">
-      <blueOceanUrlObject class="io.jenkins.blueocean.service.embedded.BlueOceanUrlObjectImpl">
-        <mappedUrl>blue/organizations/jenkins</mappedUrl>
-        <modelObject class="com.cloudbees.hudson.plugins.folder.Folder" reference="../../../.."/>
-      </blueOceanUrlObject>
-    </io.jenkins.blueocean.service.embedded.BlueOceanUrlAction>
-  </actions>
-  <description></description>
-  <properties>
-    <org.jenkinsci.plugins.pipeline.modeldefinition.config.FolderConfig plugin="pipeline-model-definition@1.2.4">
-      <dockerLabel></dockerLabel>
-      <registry plugin="docker-commons@1.9"/>
-    </org.jenkinsci.plugins.pipeline.modeldefinition.config.FolderConfig>
-  </properties>
-  <folderViews class="com.cloudbees.hudson.plugins.folder.views.DefaultFolderViewHolder">
-    <views>
-      <hudson.model.AllView>
-        <owner class="com.cloudbees.hudson.plugins.folder.Folder" reference="../../../.."/>
-        <name>All</name>
-        <filterExecutors>false</filterExecutors>
-        <filterQueue>false</filterQueue>
-        <properties class="hudson.model.View$PropertyList"/>
-      </hudson.model.AllView>
-    </views>
-    <tabBar class="hudson.views.DefaultViewsTabBar"/>
-  </folderViews>
-  <healthMetrics>
-    <com.cloudbees.hudson.plugins.folder.health.WorstChildHealthMetric>
-      <nonRecursive>false</nonRecursive>
-    </com.cloudbees.hudson.plugins.folder.health.WorstChildHealthMetric>
-  </healthMetrics>
-  <icon class="com.cloudbees.hudson.plugins.folder.icons.StockFolderIcon"/>
-</com.cloudbees.hudson.plugins.folder.Folder>
-`
+const (
+	// ForkPRTrustNone disables building pull requests from forks
+	ForkPRTrustNone = "none"
+	// ForkPRTrustContributors builds fork pull requests from repository contributors
+	ForkPRTrustContributors = "contributors"
+	// ForkPRTrustPermissions builds fork pull requests from users with write permission
+	ForkPRTrustPermissions = "permissions"
+	// ForkPRTrustEveryone builds all fork pull requests
+	ForkPRTrustEveryone = "everyone"
+)
+
+// ForkPRTrustPolicies the valid trust policies for building pull requests from forks
+var ForkPRTrustPolicies = []string{ForkPRTrustNone, ForkPRTrustContributors, ForkPRTrustPermissions, ForkPRTrustEveryone}
+
+// BranchSourceBehaviours configures the optional discovery behaviours of a
+// multibranch project source
+type BranchSourceBehaviours struct {
+	// BuildTags discovers and builds tags as well as branches
+	BuildTags bool
+	// ForkPRTrust is the trust policy for building pull requests from forks.
+	// Defaults to none so that fork pull requests are not built
+	ForkPRTrust string
+	// OnlyProtectedBranches restricts branch discovery to protected branches
+	OnlyProtectedBranches bool
+}
+
+// NewFolderJob returns the typed configuration of the folder job created for
+// an organisation at import time
+func NewFolderJob() *FolderJob {
+	folderRef := ClassRef{
+		Class:     "com.cloudbees.hudson.plugins.folder.Folder",
+		Reference: "../../../..",
+	}
+	return &FolderJob{
+		Plugin: "cloudbees-folder@6.2.1",
+		Actions: FolderActions{
+			BlueOceanUrlAction: &BlueOceanUrlAction{
+				Plugin: "blueocean-rest-impl@1.3.3",
+				BlueOceanUrlObject: BlueOceanUrlObject{
+					Class:       "io.jenkins.blueocean.service.embedded.BlueOceanUrlObjectImpl",
+					MappedUrl:   "blue/organizations/jenkins",
+					ModelObject: folderRef,
+				},
+			},
+		},
+		Properties: JobProperties{
+			PipelineFolderConfig: &PipelineFolderConfig{
+				Plugin:   "pipeline-model-definition@1.2.4",
+				Registry: ClassRef{Plugin: "docker-commons@1.9"},
+			},
+		},
+		FolderViews: FolderViews{
+			Class: "com.cloudbees.hudson.plugins.folder.views.DefaultFolderViewHolder",
+			Views: &ViewList{
+				AllViews: []AllView{
+					{
+						Owner:      folderRef,
+						Name:       "All",
+						Properties: ClassRef{Class: "hudson.model.View$PropertyList"},
+					},
+				},
+			},
+			TabBar: &ClassRef{Class: "hudson.views.DefaultViewsTabBar"},
+		},
+		HealthMetrics: HealthMetrics{
+			WorstChildHealthMetric: &WorstChildHealthMetric{},
+		},
+		Icon: JobIcon{Class: "com.cloudbees.hudson.plugins.folder.icons.StockFolderIcon"},
+	}
+}
+
+// CreateFolderXml returns the config.xml of the folder job for an organisation
+func CreateFolderXml(folderUrl string, name string) (string, error) {
+	return MarshalJobXml(NewFolderJob())
 }
 
-func createBranchSource(info *gits.GitRepositoryInfo, gitProvider gits.GitProvider, credentials string) string {
-	credXml := ""
-	if credentials != "" {
-		credXml = `		  <credentialsId>` + credentials + `</credentialsId>
-`
+// newBranchSource returns the branch source of a multibranch project for the
+// given repository using the github branch source when the provider supports it
+func newBranchSource(info *gits.GitRepositoryInfo, gitProvider gits.GitProvider, credentials string, behaviours *BranchSourceBehaviours) BranchSourceDefinition {
+	if behaviours == nil {
+		behaviours = &BranchSourceBehaviours{}
+	}
+	if serverUrl, ok := bitbucketServerUrl(gitProvider); ok {
+		return newBitbucketBranchSource(info, serverUrl, credentials, behaviours)
 	}
 	if gitProvider.IsGitHub() {
-		return `
-	    <source class="org.jenkinsci.plugins.github_branch_source.GitHubSCMSource" plugin="github-branch-source@2.3.1">
-		  <id>b50ee5d4-cb45-42de-9140-d79330bab9ac</id>` + credXml + `
-		  <repoOwner>` + info.Organisation + `</repoOwner>
-		  <repository>` + info.Name + `</repository>
-		  <traits>
-			<org.jenkinsci.plugins.github__branch__source.BranchDiscoveryTrait>
-			  <strategyId>1</strategyId>
-			</org.jenkinsci.plugins.github__branch__source.BranchDiscoveryTrait>
-			<org.jenkinsci.plugins.github__branch__source.OriginPullRequestDiscoveryTrait>
-			  <strategyId>1</strategyId>
-			</org.jenkinsci.plugins.github__branch__source.OriginPullRequestDiscoveryTrait>
-			<org.jenkinsci.plugins.github__branch__source.ForkPullRequestDiscoveryTrait>
-			  <strategyId>1</strategyId>
-			  <trust class="org.jenkinsci.plugins.github_branch_source.ForkPullRequestDiscoveryTrait$TrustContributors"/>
-			</org.jenkinsci.plugins.github__branch__source.ForkPullRequestDiscoveryTrait>
-			<jenkins.scm.impl.trait.RegexSCMHeadFilterTrait plugin="scm-api@2.2.6">
-			  <regex>master|PR-.*|feature.*</regex>
-			</jenkins.scm.impl.trait.RegexSCMHeadFilterTrait>
-		  </traits>
-		</source>
-`
-	}
-	return `
-<source class="jenkins.plugins.git.GitSCMSource" plugin="git@3.7.0">
-  <id>3ee777bd-6590-4b97-ac65-1ab01e7062ad</id>
-  <remote>` + info.URL + `</remote>
-` + credXml + `
-<traits>
-	<jenkins.plugins.git.traits.BranchDiscoveryTrait/>
-  </traits>
-</source>
-<strategy class="jenkins.branch.DefaultBranchPropertyStrategy">
-  <properties class="empty-list"/>
-</strategy>
-`
+		traits := BranchSourceTraits{
+			BranchDiscovery:            &StrategyTrait{StrategyID: 1},
+			OriginPullRequestDiscovery: &StrategyTrait{StrategyID: 1},
+		}
+		trustClass := forkPRTrustClass(behaviours.ForkPRTrust)
+		if trustClass != "" {
+			traits.ForkPullRequestDiscovery = &ForkPullRequestDiscoveryTrait{
+				StrategyID: 1,
+				Trust: ClassRef{
+					Class: "org.jenkinsci.plugins.github_branch_source.ForkPullRequestDiscoveryTrait$" + trustClass,
+				},
+			}
+		}
+		if behaviours.BuildTags {
+			traits.TagDiscovery = &Trait{}
+		}
+		if behaviours.OnlyProtectedBranches {
+			traits.ProtectedBranchesFilter = &Trait{}
+		} else {
+			traits.RegexFilter = &RegexSCMHeadFilterTrait{
+				Plugin: "scm-api@2.2.6",
+				Regex:  "master|PR-.*|feature.*",
+			}
+		}
+		return BranchSourceDefinition{
+			Class:         "org.jenkinsci.plugins.github_branch_source.GitHubSCMSource",
+			Plugin:        "github-branch-source@2.3.1",
+			ID:            "b50ee5d4-cb45-42de-9140-d79330bab9ac",
+			CredentialsID: credentials,
+			RepoOwner:     info.Organisation,
+			Repository:    info.Name,
+			Traits:        traits,
+		}
+	}
+	// other providers such as gitea use the generic git branch source which
+	// discovers branches over plain git so works against any git server
+	traits := BranchSourceTraits{
+		GitBranchDiscovery: &Trait{},
+	}
+	if behaviours.BuildTags {
+		traits.GitTagDiscovery = &Trait{}
+	}
+	if !behaviours.OnlyProtectedBranches {
+		traits.RegexFilter = &RegexSCMHeadFilterTrait{
+			Plugin: "scm-api@2.2.6",
+			Regex:  "master|PR-.*|feature.*",
+		}
+	}
+	return BranchSourceDefinition{
+		Class:         "jenkins.plugins.git.GitSCMSource",
+		Plugin:        "git@3.7.0",
+		ID:            "3ee777bd-6590-4b97-ac65-1ab01e7062ad",
+		CredentialsID: credentials,
+		Remote:        info.URL,
+		Traits:        traits,
+	}
+}
+
+// bitbucketServerUrl returns the server URL of the Bitbucket instance the
+// given provider talks to or false when the provider is not a Bitbucket one
+func bitbucketServerUrl(gitProvider gits.GitProvider) (string, bool) {
+	switch p := gitProvider.(type) {
+	case *gits.BitbucketCloudProvider:
+		return "https://bitbucket.org", true
+	case *gits.BitbucketServerProvider:
+		return p.URL, true
+	}
+	return "", false
+}
+
+// newBitbucketBranchSource returns a branch source using the bitbucket branch
+// source plugin which works against both Bitbucket Cloud and Bitbucket Server
+func newBitbucketBranchSource(info *gits.GitRepositoryInfo, serverUrl string, credentials string, behaviours *BranchSourceBehaviours) BranchSourceDefinition {
+	traits := BranchSourceTraits{
+		BitbucketBranchDiscovery:            &StrategyTrait{StrategyID: 1},
+		BitbucketOriginPullRequestDiscovery: &StrategyTrait{StrategyID: 1},
+	}
+	trustClass := bitbucketForkPRTrustClass(behaviours.ForkPRTrust)
+	if trustClass != "" {
+		traits.BitbucketForkPullRequestDiscovery = &ForkPullRequestDiscoveryTrait{
+			StrategyID: 1,
+			Trust: ClassRef{
+				Class: "com.cloudbees.jenkins.plugins.bitbucket.ForkPullRequestDiscoveryTrait$" + trustClass,
+			},
+		}
+	}
+	if behaviours.BuildTags {
+		traits.BitbucketTagDiscovery = &Trait{}
+	}
+	if !behaviours.OnlyProtectedBranches {
+		traits.RegexFilter = &RegexSCMHeadFilterTrait{
+			Plugin: "scm-api@2.2.6",
+			Regex:  "master|PR-.*|feature.*",
+		}
+	}
+	return BranchSourceDefinition{
+		Class:         "com.cloudbees.jenkins.plugins.bitbucket.BitbucketSCMSource",
+		Plugin:        "cloudbees-bitbucket-branch-source@2.2.10",
+		ID:            "31a4f8e9-2de8-4a63-9d66-ad17b03db53a",
+		CredentialsID: credentials,
+		RepoOwner:     info.Organisation,
+		Repository:    info.Name,
+		ServerURL:     serverUrl,
+		Traits:        traits,
+	}
+}
+
+// bitbucketForkPRTrustClass returns the bitbucket branch source trust class
+// for the given fork pull request trust policy or an empty string if fork pull
+// requests should not be built at all
+func bitbucketForkPRTrustClass(trust string) string {
+	switch trust {
+	case ForkPRTrustContributors, ForkPRTrustPermissions:
+		return "TrustTeamForks"
+	case ForkPRTrustEveryone:
+		return "TrustEveryone"
+	}
+	return ""
+}
+
+// forkPRTrustClass returns the github branch source trust class for the given
+// fork pull request trust policy or an empty string if fork pull requests
+// should not be built at all
+func forkPRTrustClass(trust string) string {
+	switch trust {
+	case ForkPRTrustContributors:
+		return "TrustContributors"
+	case ForkPRTrustPermissions:
+		return "TrustPermission"
+	case ForkPRTrustEveryone:
+		return "TrustEveryone"
+	}
+	return ""
+}
+
+// NewMultiBranchProject returns the typed configuration of a multibranch
+// project job for the given repository
+func NewMultiBranchProject(info *gits.GitRepositoryInfo, gitProvider gits.GitProvider, credentials string, jenkinsfile string, behaviours *BranchSourceBehaviours) *MultiBranchProject {
+	ownerRef := &ClassRef{
+		Class:     "org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject",
+		Reference: "../..",
+	}
+	return &MultiBranchProject{
+		Plugin: "workflow-multibranch@2.16",
+		Properties: JobProperties{
+			PipelineFolderConfig: &PipelineFolderConfig{
+				Plugin:   "pipeline-model-definition@1.2.4",
+				Registry: ClassRef{Plugin: "docker-commons@1.9"},
+			},
+			EnvVarProperties: envVarsFolderProperties(ProjectEnvVars(info)),
+		},
+		FolderViews: FolderViews{
+			Class:  "jenkins.branch.MultiBranchProjectViewHolder",
+			Plugin: "branch-api@2.0.15",
+			Owner:  ownerRef,
+		},
+		HealthMetrics: HealthMetrics{
+			WorstChildHealthMetric: &WorstChildHealthMetric{Plugin: "cloudbees-folder@6.2.1"},
+		},
+		Icon: JobIcon{
+			Class:  "jenkins.branch.MetadataActionFolderIcon",
+			Plugin: "branch-api@2.0.15",
+			Owner:  ownerRef,
+		},
+		OrphanedItemStrategy: OrphanedItemStrategy{
+			Class:             "com.cloudbees.hudson.plugins.folder.computed.DefaultOrphanedItemStrategy",
+			Plugin:            "cloudbees-folder@6.2.1",
+			PruneDeadBranches: true,
+			DaysToKeep:        -1,
+			NumToKeep:         -1,
+		},
+		Sources: MultiBranchSources{
+			Class:  "jenkins.branch.MultiBranchProject$BranchSourceList",
+			Plugin: "branch-api@2.0.15",
+			Data: []BranchSource{
+				{
+					Source: newBranchSource(info, gitProvider, credentials, behaviours),
+					Strategy: &BranchPropertyStrategy{
+						Class:      "jenkins.branch.DefaultBranchPropertyStrategy",
+						Properties: ClassRef{Class: "empty-list"},
+					},
+				},
+			},
+			Owner: ownerRef,
+		},
+		Factory: WorkflowBranchProjectFactory{
+			Class:      "org.jenkinsci.plugins.workflow.multibranch.WorkflowBranchProjectFactory",
+			Owner:      ownerRef,
+			ScriptPath: jenkinsfile,
+		},
+	}
 }
 
-func CreateMultiBranchProjectXml(info *gits.GitRepositoryInfo, gitProvider gits.GitProvider, credentials string, jenkinsfile string) string {
-	return `<?xml version='1.0' encoding='UTF-8'?>
-<org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject plugin="workflow-multibranch@2.16">
-  <actions/>
-  <description></description>
-  <properties>
-	<org.jenkinsci.plugins.pipeline.modeldefinition.config.FolderConfig plugin="pipeline-model-definition@1.2.4">
-	  <dockerLabel></dockerLabel>
-	  <registry plugin="docker-commons@1.9"/>
-	</org.jenkinsci.plugins.pipeline.modeldefinition.config.FolderConfig>
-  </properties>
-  <folderViews class="jenkins.branch.MultiBranchProjectViewHolder" plugin="branch-api@2.0.15">
-	<owner class="org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject" reference="../.."/>
-  </folderViews>
-  <healthMetrics>
-	<com.cloudbees.hudson.plugins.folder.health.WorstChildHealthMetric plugin="cloudbees-folder@6.2.1">
-	  <nonRecursive>false</nonRecursive>
-	</com.cloudbees.hudson.plugins.folder.health.WorstChildHealthMetric>
-  </healthMetrics>
-  <icon class="jenkins.branch.MetadataActionFolderIcon" plugin="branch-api@2.0.15">
-	<owner class="org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject" reference="../.."/>
-  </icon>
-  <orphanedItemStrategy class="com.cloudbees.hudson.plugins.folder.computed.DefaultOrphanedItemStrategy" plugin="cloudbees-folder@6.2.1">
-	<pruneDeadBranches>true</pruneDeadBranches>
-	<daysToKeep>-1</daysToKeep>
-	<numToKeep>-1</numToKeep>
-  </orphanedItemStrategy>
-  <triggers/>
-  <disabled>false</disabled>
-  <sources class="jenkins.branch.MultiBranchProject$BranchSourceList" plugin="branch-api@2.0.15">
-	<data>
-	  <jenkins.branch.BranchSource>
-` + createBranchSource(info, gitProvider, credentials) + `
-		<strategy class="jenkins.branch.DefaultBranchPropertyStrategy">
-		  <properties class="empty-list"/>
-		</strategy>
-	  </jenkins.branch.BranchSource>
-	</data>
-	<owner class="org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject" reference="../.."/>
-  </sources>
-  <factory class="org.jenkinsci.plugins.workflow.multibranch.WorkflowBranchProjectFactory">
-	<owner class="org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject" reference="../.."/>
-	<scriptPath>` + jenkinsfile + `</scriptPath>
-  </factory>
-</org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject>
-`
+// CreateMultiBranchProjectXml returns the config.xml of a multibranch project
+// job for the given repository
+func CreateMultiBranchProjectXml(info *gits.GitRepositoryInfo, gitProvider gits.GitProvider, credentials string, jenkinsfile string, behaviours *BranchSourceBehaviours) (string, error) {
+	return MarshalJobXml(NewMultiBranchProject(info, gitProvider, credentials, jenkinsfile, behaviours))
 }
@@ -0,0 +1,299 @@
+package jenkins
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// This file contains the typed representation of the Jenkins job config.xml
+// documents which are created at import time. Marshalling structs with
+// encoding/xml instead of concatenating string templates keeps new options
+// type safe and lets the configuration of an existing job be decoded again
+// for diffing and updating.
+
+// xmlHeader is the document header Jenkins uses for its config.xml files
+const xmlHeader = "<?xml version='1.0' encoding='UTF-8'?>\n"
+
+// ClassRef is an element which only carries the class, plugin and reference
+// attributes such as <owner class="..." reference="../.."/>
+type ClassRef struct {
+	Class     string `xml:"class,attr,omitempty"`
+	Plugin    string `xml:"plugin,attr,omitempty"`
+	Reference string `xml:"reference,attr,omitempty"`
+}
+
+// Trait is a branch source trait which has no configuration of its own
+type Trait struct {
+}
+
+// FolderJob is the config.xml document of a folder job
+type FolderJob struct {
+	XMLName       xml.Name      `xml:"com.cloudbees.hudson.plugins.folder.Folder"`
+	Plugin        string        `xml:"plugin,attr,omitempty"`
+	Actions       FolderActions `xml:"actions"`
+	Description   string        `xml:"description"`
+	Properties    JobProperties `xml:"properties"`
+	FolderViews   FolderViews   `xml:"folderViews"`
+	HealthMetrics HealthMetrics `xml:"healthMetrics"`
+	Icon          JobIcon       `xml:"icon"`
+}
+
+// FolderActions are the actions of a folder job
+type FolderActions struct {
+	BlueOceanUrlAction *BlueOceanUrlAction `xml:"io.jenkins.blueocean.service.embedded.BlueOceanUrlAction,omitempty"`
+}
+
+// BlueOceanUrlAction links the job to its Blue Ocean URL
+type BlueOceanUrlAction struct {
+	Plugin             string             `xml:"plugin,attr,omitempty"`
+	BlueOceanUrlObject BlueOceanUrlObject `xml:"blueOceanUrlObject"`
+}
+
+// BlueOceanUrlObject maps the job to a URL within Blue Ocean
+type BlueOceanUrlObject struct {
+	Class       string   `xml:"class,attr,omitempty"`
+	MappedUrl   string   `xml:"mappedUrl"`
+	ModelObject ClassRef `xml:"modelObject"`
+}
+
+// JobProperties are the properties of a folder or multibranch project job
+type JobProperties struct {
+	PipelineFolderConfig *PipelineFolderConfig   `xml:"org.jenkinsci.plugins.pipeline.modeldefinition.config.FolderConfig,omitempty"`
+	EnvVarProperties     *EnvVarFolderProperties `xml:"com.mig82.folders.properties.FolderProperties,omitempty"`
+}
+
+// PipelineFolderConfig is the declarative pipeline configuration of a folder
+type PipelineFolderConfig struct {
+	Plugin      string   `xml:"plugin,attr,omitempty"`
+	DockerLabel string   `xml:"dockerLabel"`
+	Registry    ClassRef `xml:"registry"`
+}
+
+// EnvVarFolderProperties injects environment variables into every build
+// within the folder via the folder-properties plugin
+type EnvVarFolderProperties struct {
+	Plugin     string                 `xml:"plugin,attr,omitempty"`
+	Properties []EnvVarStringProperty `xml:"properties>com.mig82.folders.properties.StringProperty"`
+}
+
+// EnvVarStringProperty is a single environment variable injected into builds
+type EnvVarStringProperty struct {
+	Key   string `xml:"key"`
+	Value string `xml:"value"`
+}
+
+// FolderViews holds the views of a folder or multibranch project job
+type FolderViews struct {
+	Class  string    `xml:"class,attr,omitempty"`
+	Plugin string    `xml:"plugin,attr,omitempty"`
+	Views  *ViewList `xml:"views,omitempty"`
+	TabBar *ClassRef `xml:"tabBar,omitempty"`
+	Owner  *ClassRef `xml:"owner,omitempty"`
+}
+
+// ViewList holds the views defined within a folder
+type ViewList struct {
+	AllViews []AllView `xml:"hudson.model.AllView"`
+}
+
+// AllView is the default view showing all jobs within a folder
+type AllView struct {
+	Owner           ClassRef `xml:"owner"`
+	Name            string   `xml:"name"`
+	FilterExecutors bool     `xml:"filterExecutors"`
+	FilterQueue     bool     `xml:"filterQueue"`
+	Properties      ClassRef `xml:"properties"`
+}
+
+// HealthMetrics holds the health metrics of a folder job
+type HealthMetrics struct {
+	WorstChildHealthMetric *WorstChildHealthMetric `xml:"com.cloudbees.hudson.plugins.folder.health.WorstChildHealthMetric,omitempty"`
+}
+
+// WorstChildHealthMetric reports the health of the least healthy child job
+type WorstChildHealthMetric struct {
+	Plugin       string `xml:"plugin,attr,omitempty"`
+	NonRecursive bool   `xml:"nonRecursive"`
+}
+
+// JobIcon is the icon of a folder or multibranch project job
+type JobIcon struct {
+	Class  string    `xml:"class,attr,omitempty"`
+	Plugin string    `xml:"plugin,attr,omitempty"`
+	Owner  *ClassRef `xml:"owner,omitempty"`
+}
+
+// MultiBranchProject is the config.xml document of a multibranch project job
+type MultiBranchProject struct {
+	XMLName              xml.Name                     `xml:"org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject"`
+	Plugin               string                       `xml:"plugin,attr,omitempty"`
+	Actions              struct{}                     `xml:"actions"`
+	Description          string                       `xml:"description"`
+	Properties           JobProperties                `xml:"properties"`
+	FolderViews          FolderViews                  `xml:"folderViews"`
+	HealthMetrics        HealthMetrics                `xml:"healthMetrics"`
+	Icon                 JobIcon                      `xml:"icon"`
+	OrphanedItemStrategy OrphanedItemStrategy         `xml:"orphanedItemStrategy"`
+	Triggers             struct{}                     `xml:"triggers"`
+	Disabled             bool                         `xml:"disabled"`
+	Sources              MultiBranchSources           `xml:"sources"`
+	Factory              WorkflowBranchProjectFactory `xml:"factory"`
+}
+
+// OrphanedItemStrategy configures how branches whose heads have been deleted
+// are cleaned up
+type OrphanedItemStrategy struct {
+	Class             string `xml:"class,attr,omitempty"`
+	Plugin            string `xml:"plugin,attr,omitempty"`
+	PruneDeadBranches bool   `xml:"pruneDeadBranches"`
+	DaysToKeep        int    `xml:"daysToKeep"`
+	NumToKeep         int    `xml:"numToKeep"`
+}
+
+// MultiBranchSources holds the branch sources of a multibranch project
+type MultiBranchSources struct {
+	Class  string         `xml:"class,attr,omitempty"`
+	Plugin string         `xml:"plugin,attr,omitempty"`
+	Data   []BranchSource `xml:"data>jenkins.branch.BranchSource"`
+	Owner  *ClassRef      `xml:"owner,omitempty"`
+}
+
+// BranchSource is a single branch source of a multibranch project
+type BranchSource struct {
+	Source   BranchSourceDefinition  `xml:"source"`
+	Strategy *BranchPropertyStrategy `xml:"strategy,omitempty"`
+}
+
+// BranchSourceDefinition defines where the branches of a multibranch project
+// are discovered from. The class attribute selects the git or github branch
+// source implementation so only the fields it understands are populated
+type BranchSourceDefinition struct {
+	Class         string             `xml:"class,attr,omitempty"`
+	Plugin        string             `xml:"plugin,attr,omitempty"`
+	ID            string             `xml:"id,omitempty"`
+	CredentialsID string             `xml:"credentialsId,omitempty"`
+	RepoOwner     string             `xml:"repoOwner,omitempty"`
+	Repository    string             `xml:"repository,omitempty"`
+	ServerURL     string             `xml:"serverUrl,omitempty"`
+	Remote        string             `xml:"remote,omitempty"`
+	Traits        BranchSourceTraits `xml:"traits"`
+}
+
+// BranchSourceTraits are the discovery behaviours of a branch source. Each
+// field is nil when the trait is not configured
+type BranchSourceTraits struct {
+	BranchDiscovery            *StrategyTrait                 `xml:"org.jenkinsci.plugins.github__branch__source.BranchDiscoveryTrait,omitempty"`
+	OriginPullRequestDiscovery *StrategyTrait                 `xml:"org.jenkinsci.plugins.github__branch__source.OriginPullRequestDiscoveryTrait,omitempty"`
+	ForkPullRequestDiscovery   *ForkPullRequestDiscoveryTrait `xml:"org.jenkinsci.plugins.github__branch__source.ForkPullRequestDiscoveryTrait,omitempty"`
+	TagDiscovery               *Trait                         `xml:"org.jenkinsci.plugins.github__branch__source.TagDiscoveryTrait,omitempty"`
+	ProtectedBranchesFilter    *Trait                         `xml:"org.jenkinsci.plugins.github__branch__source.ProtectedBranchesSCMHeadFilterTrait,omitempty"`
+	RegexFilter                *RegexSCMHeadFilterTrait       `xml:"jenkins.scm.impl.trait.RegexSCMHeadFilterTrait,omitempty"`
+	GitBranchDiscovery         *Trait                         `xml:"jenkins.plugins.git.traits.BranchDiscoveryTrait,omitempty"`
+	GitTagDiscovery            *Trait                         `xml:"jenkins.plugins.git.traits.TagDiscoveryTrait,omitempty"`
+
+	BitbucketBranchDiscovery            *StrategyTrait                 `xml:"com.cloudbees.jenkins.plugins.bitbucket.BranchDiscoveryTrait,omitempty"`
+	BitbucketOriginPullRequestDiscovery *StrategyTrait                 `xml:"com.cloudbees.jenkins.plugins.bitbucket.OriginPullRequestDiscoveryTrait,omitempty"`
+	BitbucketForkPullRequestDiscovery   *ForkPullRequestDiscoveryTrait `xml:"com.cloudbees.jenkins.plugins.bitbucket.ForkPullRequestDiscoveryTrait,omitempty"`
+	BitbucketTagDiscovery               *Trait                         `xml:"com.cloudbees.jenkins.plugins.bitbucket.TagDiscoveryTrait,omitempty"`
+}
+
+// StrategyTrait is a discovery trait configured with a strategy id
+type StrategyTrait struct {
+	StrategyID int `xml:"strategyId"`
+}
+
+// ForkPullRequestDiscoveryTrait discovers pull requests from forks with the
+// given trust policy
+type ForkPullRequestDiscoveryTrait struct {
+	StrategyID int      `xml:"strategyId"`
+	Trust      ClassRef `xml:"trust"`
+}
+
+// RegexSCMHeadFilterTrait filters the discovered branches by a regular expression
+type RegexSCMHeadFilterTrait struct {
+	Plugin string `xml:"plugin,attr,omitempty"`
+	Regex  string `xml:"regex"`
+}
+
+// BranchPropertyStrategy configures the properties applied to discovered branches
+type BranchPropertyStrategy struct {
+	Class      string   `xml:"class,attr,omitempty"`
+	Properties ClassRef `xml:"properties"`
+}
+
+// WorkflowBranchProjectFactory configures where the Jenkinsfile of each branch lives
+type WorkflowBranchProjectFactory struct {
+	Class      string    `xml:"class,attr,omitempty"`
+	Owner      *ClassRef `xml:"owner,omitempty"`
+	ScriptPath string    `xml:"scriptPath"`
+}
+
+// MarshalJobXml marshals the given typed job configuration into the config.xml
+// text to post to Jenkins
+func MarshalJobXml(job interface{}) (string, error) {
+	data, err := xml.MarshalIndent(job, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("Failed to marshal the job configuration to XML due to: %s", err)
+	}
+	return xmlHeader + string(data) + "\n", nil
+}
+
+// ParseMultiBranchProjectXml decodes the config.xml of an existing multibranch
+// project job so that it can be modified and posted back to Jenkins
+func ParseMultiBranchProjectXml(configXml string) (*MultiBranchProject, error) {
+	project := &MultiBranchProject{}
+	err := xml.Unmarshal([]byte(configXml), project)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse the multibranch project XML due to: %s", err)
+	}
+	return project, nil
+}
+
+// GitURL returns the git URL of the first branch source of the project or the
+// empty string when it cannot be determined
+func (p *MultiBranchProject) GitURL() string {
+	for _, source := range p.Sources.Data {
+		definition := &source.Source
+		if definition.Remote != "" {
+			return definition.Remote
+		}
+		if definition.RepoOwner != "" && definition.Repository != "" {
+			server := definition.ServerURL
+			if server == "" {
+				// the github branch source does not store a server URL for github.com
+				server = "https://" + gits.GitHubHost
+			}
+			return util.UrlJoin(server, definition.RepoOwner, definition.Repository)
+		}
+	}
+	return ""
+}
+
+// CredentialsID returns the Jenkins credentials id of the first branch source
+// of the project or the empty string when there is none
+func (p *MultiBranchProject) CredentialsID() string {
+	for _, source := range p.Sources.Data {
+		if source.Source.CredentialsID != "" {
+			return source.Source.CredentialsID
+		}
+	}
+	return ""
+}
+
+// normalizeConfigXml round trips the given job config through the typed structs
+// so that diffing it against a newly generated configuration ignores formatting
+// differences. The text is returned unchanged when it cannot be decoded
+func normalizeConfigXml(configXml string) string {
+	project, err := ParseMultiBranchProjectXml(configXml)
+	if err != nil {
+		return configXml
+	}
+	text, err := MarshalJobXml(project)
+	if err != nil {
+		return configXml
+	}
+	return text
+}
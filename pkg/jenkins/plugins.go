@@ -0,0 +1,96 @@
+package jenkins
+
+import (
+	"strconv"
+	"strings"
+)
+
+// PluginRequirement describes a plugin and the minimum version the platform expects
+// to be installed on a Jenkins master
+type PluginRequirement struct {
+	Name    string
+	Version string
+}
+
+// RequiredPlugins is the recommended plugin manifest for team masters. The versions
+// are the minimum versions the platform is tested against
+var RequiredPlugins = []PluginRequirement{
+	{Name: "blueocean", Version: "1.4.1"},
+	{Name: "config-file-provider", Version: "2.18"},
+	{Name: "credentials-binding", Version: "1.15"},
+	{Name: "git", Version: "3.7.0"},
+	{Name: "github-branch-source", Version: "2.3.2"},
+	{Name: "kubernetes", Version: "1.2"},
+	{Name: "pipeline-utility-steps", Version: "2.0.1"},
+	{Name: "workflow-aggregator", Version: "2.5"},
+}
+
+// VulnerablePluginVersions maps a plugin name to the versions which have a published
+// security advisory
+var VulnerablePluginVersions = map[string][]string{
+	"blueocean":            {"1.3.0", "1.3.1"},
+	"github-branch-source": {"2.3.0"},
+	"mailer":               {"1.19"},
+	"script-security":      {"1.39", "1.40"},
+}
+
+// IsVulnerablePluginVersion returns true if there is a published security advisory
+// for the given plugin version
+func IsVulnerablePluginVersion(name string, version string) bool {
+	for _, v := range VulnerablePluginVersions[name] {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiredPluginVersion returns the recommended version of the named plugin or an
+// empty string if it is not in the manifest
+func RequiredPluginVersion(name string) string {
+	for _, p := range RequiredPlugins {
+		if p.Name == name {
+			return p.Version
+		}
+	}
+	return ""
+}
+
+// ComparePluginVersions compares two dotted plugin version strings returning -1, 0 or 1
+// if the first version is older, equal or newer than the second
+func ComparePluginVersions(a string, b string) int {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+	count := len(as)
+	if len(bs) > count {
+		count = len(bs)
+	}
+	for i := 0; i < count; i++ {
+		av := ""
+		bv := ""
+		if i < len(as) {
+			av = as[i]
+		}
+		if i < len(bs) {
+			bv = bs[i]
+		}
+		an, aerr := strconv.Atoi(av)
+		bn, berr := strconv.Atoi(bv)
+		if aerr == nil && berr == nil {
+			if an < bn {
+				return -1
+			}
+			if an > bn {
+				return 1
+			}
+			continue
+		}
+		if av < bv {
+			return -1
+		}
+		if av > bv {
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,106 @@
+package jenkins
+
+import (
+	"encoding/xml"
+	"regexp"
+	"strings"
+)
+
+// This file contains a typed representation of the pod templates defined in
+// the Jenkins kubernetes plugin configuration so that we can verify the agent
+// labels used by Jenkinsfiles actually resolve to a pod template
+
+// PodTemplate is a pod template defined in the kubernetes plugin configuration
+// inside the Jenkins config.xml
+type PodTemplate struct {
+	Name           string                 `xml:"name"`
+	Label          string                 `xml:"label"`
+	NodeSelector   string                 `xml:"nodeSelector"`
+	ServiceAccount string                 `xml:"serviceAccount"`
+	Yaml           string                 `xml:"yaml"`
+	Containers     []PodTemplateContainer `xml:"containers>org.csanchez.jenkins.plugins.kubernetes.ContainerTemplate"`
+}
+
+// PodTemplateContainer is a container template of a pod template
+type PodTemplateContainer struct {
+	Name                  string `xml:"name"`
+	Image                 string `xml:"image"`
+	ResourceRequestCpu    string `xml:"resourceRequestCpu"`
+	ResourceRequestMemory string `xml:"resourceRequestMemory"`
+	ResourceLimitCpu      string `xml:"resourceLimitCpu"`
+	ResourceLimitMemory   string `xml:"resourceLimitMemory"`
+}
+
+// podTemplateElement is the element name the kubernetes plugin uses for a pod
+// template in the Jenkins config.xml
+const podTemplateElement = "org.csanchez.jenkins.plugins.kubernetes.PodTemplate"
+
+// jenkinsfileAgentLabelPattern matches the agent label in a Jenkinsfile such
+// as: label "jenkins-maven"
+var jenkinsfileAgentLabelPattern = regexp.MustCompile(`label\s+["']([^"']+)["']`)
+
+// ParsePodTemplates returns the pod templates defined in the given Jenkins
+// config.xml
+func ParsePodTemplates(configXml string) ([]PodTemplate, error) {
+	answer := []PodTemplate{}
+	decoder := xml.NewDecoder(strings.NewReader(configXml))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != podTemplateElement {
+			continue
+		}
+		template := PodTemplate{}
+		err = decoder.DecodeElement(&template, &start)
+		if err != nil {
+			return answer, err
+		}
+		answer = append(answer, template)
+	}
+	return answer, nil
+}
+
+// HasLabel returns true if the pod template provides the given agent label.
+// The kubernetes plugin allows a pod template to define multiple whitespace
+// separated labels
+func (t *PodTemplate) HasLabel(label string) bool {
+	for _, l := range strings.Fields(t.Label) {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// FindPodTemplate returns the pod template providing the given agent label or
+// nil if there is none
+func FindPodTemplate(templates []PodTemplate, label string) *PodTemplate {
+	for i := range templates {
+		if templates[i].HasLabel(label) {
+			return &templates[i]
+		}
+	}
+	return nil
+}
+
+// PodTemplateLabels returns the agent labels provided by the given pod templates
+func PodTemplateLabels(templates []PodTemplate) []string {
+	answer := []string{}
+	for _, t := range templates {
+		answer = append(answer, strings.Fields(t.Label)...)
+	}
+	return answer
+}
+
+// FindJenkinsfileAgentLabel returns the first agent label used in the given
+// Jenkinsfile text or an empty string if there is none
+func FindJenkinsfileAgentLabel(jenkinsfile string) string {
+	match := jenkinsfileAgentLabelPattern.FindStringSubmatch(jenkinsfile)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
@@ -0,0 +1,162 @@
+package jenkins
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ResourceProfile is a named preset of the container resource requests and
+// limits applied to a pod template so that teams pick a t-shirt size instead
+// of hand-tuning the container resources in XML
+type ResourceProfile struct {
+	Name          string
+	RequestCpu    string
+	RequestMemory string
+	LimitCpu      string
+	LimitMemory   string
+}
+
+// DefaultResourceProfiles are the profiles used when the team does not define
+// its own ones
+var DefaultResourceProfiles = []ResourceProfile{
+	{Name: "small", RequestCpu: "0.5", RequestMemory: "512Mi", LimitCpu: "1", LimitMemory: "1Gi"},
+	{Name: "medium", RequestCpu: "1", RequestMemory: "2Gi", LimitCpu: "2", LimitMemory: "4Gi"},
+	{Name: "large", RequestCpu: "2", RequestMemory: "4Gi", LimitCpu: "4", LimitMemory: "8Gi"},
+}
+
+// containerTemplateElement is the element name the kubernetes plugin uses for
+// a container template inside a pod template
+const containerTemplateElement = "org.csanchez.jenkins.plugins.kubernetes.ContainerTemplate"
+
+// FindResourceProfile returns the resource profile of the given name or nil
+// if there is none
+func FindResourceProfile(profiles []ResourceProfile, name string) *ResourceProfile {
+	for i := range profiles {
+		if profiles[i].Name == name {
+			return &profiles[i]
+		}
+	}
+	return nil
+}
+
+// ResourceProfileNames returns the names of the given resource profiles
+func ResourceProfileNames(profiles []ResourceProfile) []string {
+	answer := []string{}
+	for _, profile := range profiles {
+		answer = append(answer, profile.Name)
+	}
+	return answer
+}
+
+// ProfileLabel returns the agent label of the pod template combining the
+// given base label with a resource profile such as jenkins-maven-large
+func ProfileLabel(label string, profile *ResourceProfile) string {
+	return label + "-" + profile.Name
+}
+
+// EnsureResourceProfilePodTemplate returns the Jenkins config.xml containing a
+// pod template for the given agent label with the resources of the given
+// profile. The template is cloned from the pod template of the base label so
+// that it keeps the same containers and volumes. The agent label of the
+// profile template is returned so that Jenkinsfiles can use it
+func EnsureResourceProfilePodTemplate(configXml string, label string, profile *ResourceProfile) (string, string, error) {
+	profileLabel := ProfileLabel(label, profile)
+	templates, err := ParsePodTemplates(configXml)
+	if err != nil {
+		return configXml, "", err
+	}
+	if FindPodTemplate(templates, profileLabel) != nil {
+		answer := replacePodTemplateBlock(configXml, profileLabel, func(block string) string {
+			return applyProfileResources(block, profile)
+		})
+		return answer, profileLabel, nil
+	}
+	base := FindPodTemplate(templates, label)
+	if base == nil {
+		return configXml, "", fmt.Errorf("No pod template found for the agent label %s so the resource profile %s cannot be applied", label, profile.Name)
+	}
+	answer := ""
+	for _, block := range podTemplateBlockPattern.FindAllString(configXml, -1) {
+		template, err := parsePodTemplateBlock(block)
+		if err != nil {
+			continue
+		}
+		if template.HasLabel(label) {
+			clone := setFirstElement(block, "name", base.Name+"-"+profile.Name)
+			clone = setFirstElement(clone, "label", profileLabel)
+			clone = applyProfileResources(clone, profile)
+			answer = strings.Replace(configXml, block, block+"\n"+clone, 1)
+			break
+		}
+	}
+	if answer == "" {
+		return configXml, "", fmt.Errorf("Could not clone the pod template for the agent label %s", label)
+	}
+	return answer, profileLabel, nil
+}
+
+// parsePodTemplateBlock parses a single pod template element
+func parsePodTemplateBlock(block string) (*PodTemplate, error) {
+	templates, err := ParsePodTemplates(block)
+	if err != nil {
+		return nil, err
+	}
+	if len(templates) == 0 {
+		return nil, fmt.Errorf("No pod template found")
+	}
+	return &templates[0], nil
+}
+
+// replacePodTemplateBlock applies the given function to the pod template
+// providing the given agent label
+func replacePodTemplateBlock(configXml string, label string, fn func(string) string) string {
+	return podTemplateBlockPattern.ReplaceAllStringFunc(configXml, func(block string) string {
+		template, err := parsePodTemplateBlock(block)
+		if err != nil || !template.HasLabel(label) {
+			return block
+		}
+		return fn(block)
+	})
+}
+
+// applyProfileResources sets the resource requests and limits of the profile
+// on every container template of the pod template block
+func applyProfileResources(block string, profile *ResourceProfile) string {
+	block = setContainerElements(block, "resourceRequestCpu", profile.RequestCpu)
+	block = setContainerElements(block, "resourceRequestMemory", profile.RequestMemory)
+	block = setContainerElements(block, "resourceLimitCpu", profile.LimitCpu)
+	block = setContainerElements(block, "resourceLimitMemory", profile.LimitMemory)
+	return block
+}
+
+// setFirstElement replaces the value of the first occurrence of the given
+// element in the pod template block. The name and label of a pod template are
+// its first elements so this does not touch the container names
+func setFirstElement(block string, name string, value string) string {
+	element := "<" + name + ">" + value + "</" + name + ">"
+	pattern := regexp.MustCompile(`(?s)<` + name + `\s*/>|<` + name + `>.*?</` + name + `>`)
+	loc := pattern.FindStringIndex(block)
+	if loc == nil {
+		return block
+	}
+	return block[:loc[0]] + element + block[loc[1]:]
+}
+
+// setContainerElements replaces the value of the given element in every
+// container template of the pod template block, adding the element to each
+// container when it is not present yet
+func setContainerElements(block string, name string, value string) string {
+	if value == "" {
+		return block
+	}
+	element := "<" + name + ">" + value + "</" + name + ">"
+	pattern := regexp.MustCompile(`(?s)<` + name + `\s*/>|<` + name + `>.*?</` + name + `>`)
+	if pattern.MatchString(block) {
+		return pattern.ReplaceAllStringFunc(block, func(string) string {
+			return element
+		})
+	}
+	closing := "</" + containerTemplateElement + ">"
+	return strings.Replace(block, closing, "  "+element+"\n"+closing, -1)
+}
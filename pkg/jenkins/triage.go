@@ -0,0 +1,58 @@
+package jenkins
+
+import (
+	"strings"
+)
+
+// BuildFailureAdvice describes a known failure pattern in a build log together
+// with advice on how to remedy it
+type BuildFailureAdvice struct {
+	Name     string
+	Patterns []string
+	Advice   string
+}
+
+// BuildFailureAdvices the known build failure patterns and their remediation advice
+var BuildFailureAdvices = []BuildFailureAdvice{
+	{
+		Name: "Missing pod template label",
+		Patterns: []string{
+			"There are no nodes with the label",
+			"Still waiting to schedule task",
+			"have label",
+		},
+		Advice: "The agent label in the Jenkinsfile does not match any pod template. Check that the Kubernetes plugin in Jenkins has a pod template with the label used in the Jenkinsfile",
+	},
+	{
+		Name: "Docker registry authentication",
+		Patterns: []string{
+			"unauthorized: authentication required",
+			"denied: requested access to the resource is denied",
+			"no basic auth credentials",
+		},
+		Advice: "The build could not authenticate with the Docker registry. Check that the jenkins-docker-cfg secret contains valid credentials for your registry",
+	},
+	{
+		Name: "Helm chart path",
+		Patterns: []string{
+			"no Chart.yaml exists in directory",
+			"Chart.yaml file is missing",
+			"Error: chart not found",
+		},
+		Advice: "The helm chart could not be found. Check that the charts directory contains a chart named after your application and that the Jenkinsfile uses the same chart path",
+	},
+}
+
+// ClassifyBuildFailure returns advice for any known failure patterns found in the build log
+func ClassifyBuildFailure(log string) []BuildFailureAdvice {
+	answer := []BuildFailureAdvice{}
+	for _, advice := range BuildFailureAdvices {
+		for _, pattern := range advice.Patterns {
+			if strings.Contains(log, pattern) {
+				answer = append(answer, advice)
+				break
+			}
+		}
+	}
+	return answer
+}
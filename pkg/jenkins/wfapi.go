@@ -0,0 +1,117 @@
+package jenkins
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/jenkins-x/golang-jenkins"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// WorkflowRun the result of a pipeline build from the workflow API
+type WorkflowRun struct {
+	ID             string          `json:"id"`
+	Name           string          `json:"name"`
+	Status         string          `json:"status"`
+	DurationMillis int64           `json:"durationMillis"`
+	Stages         []WorkflowStage `json:"stages"`
+}
+
+// WorkflowStage a stage of a pipeline build from the workflow API
+type WorkflowStage struct {
+	ID             string         `json:"id"`
+	Name           string         `json:"name"`
+	Status         string         `json:"status"`
+	DurationMillis int64          `json:"durationMillis"`
+	Links          WorkflowLinks  `json:"_links"`
+	StageFlowNodes []WorkflowNode `json:"stageFlowNodes"`
+}
+
+// WorkflowNode a step within a stage of a pipeline build
+type WorkflowNode struct {
+	ID             string        `json:"id"`
+	Name           string        `json:"name"`
+	Status         string        `json:"status"`
+	DurationMillis int64         `json:"durationMillis"`
+	Links          WorkflowLinks `json:"_links"`
+}
+
+// WorkflowLinks the links to related workflow API resources
+type WorkflowLinks struct {
+	Self WorkflowLink `json:"self"`
+	Log  WorkflowLink `json:"log"`
+}
+
+// WorkflowLink a link to a workflow API resource
+type WorkflowLink struct {
+	Href string `json:"href"`
+}
+
+// WorkflowNodeLog the log of a step from the workflow API
+type WorkflowNodeLog struct {
+	NodeID     string `json:"nodeId"`
+	NodeStatus string `json:"nodeStatus"`
+	Length     int64  `json:"length"`
+	HasMore    bool   `json:"hasMore"`
+	Text       string `json:"text"`
+	ConsoleURL string `json:"consoleUrl"`
+}
+
+// GetWorkflowRun returns the stages of the given build using the workflow API
+func GetWorkflowRun(jenk *gojenkins.Jenkins, build *gojenkins.Build) (*WorkflowRun, error) {
+	buildPath, err := buildUrlPath(build)
+	if err != nil {
+		return nil, err
+	}
+	run := &WorkflowRun{}
+	err = jenk.GetJson(util.UrlJoin(buildPath, "wfapi/describe"), nil, run)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query the workflow API of build %s due to: %s", build.Url, err)
+	}
+	return run, nil
+}
+
+// GetWorkflowStage returns the stage with its steps populated using the stage link
+func GetWorkflowStage(jenk *gojenkins.Jenkins, stage *WorkflowStage) (*WorkflowStage, error) {
+	href := stage.Links.Self.Href
+	if href == "" {
+		return stage, nil
+	}
+	answer := &WorkflowStage{}
+	err := jenk.GetJson(href, nil, answer)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query the workflow API for stage %s due to: %s", stage.Name, err)
+	}
+	return answer, nil
+}
+
+// GetWorkflowNodeLog returns the log of the given step
+func GetWorkflowNodeLog(jenk *gojenkins.Jenkins, node *WorkflowNode) (*WorkflowNodeLog, error) {
+	href := node.Links.Log.Href
+	if href == "" {
+		return nil, nil
+	}
+	answer := &WorkflowNodeLog{}
+	err := jenk.GetJson(href, nil, answer)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query the workflow API for the log of step %s due to: %s", node.Name, err)
+	}
+	return answer, nil
+}
+
+// StageNames returns the names of the stages in the run
+func (r *WorkflowRun) StageNames() []string {
+	answer := []string{}
+	for _, stage := range r.Stages {
+		answer = append(answer, stage.Name)
+	}
+	return answer
+}
+
+func buildUrlPath(build *gojenkins.Build) (string, error) {
+	u, err := url.Parse(build.Url)
+	if err != nil {
+		return "", fmt.Errorf("Failed to parse the build URL %s due to: %s", build.Url, err)
+	}
+	return u.Path, nil
+}
@@ -0,0 +1,226 @@
+package jenkinsfile
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"text/template"
+
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// Model is the template model used to render a Jenkinsfile
+type Model struct {
+	// AppName is the name of the application
+	AppName string
+
+	// Organisation is the docker registry organisation the images are pushed to
+	Organisation string
+
+	// ChartDir is the directory of the helm chart of the application
+	ChartDir string
+
+	// AgentLabel is the pod template label the pipeline runs on
+	AgentLabel string
+
+	// Container runs the build steps inside the agent pod
+	Container string
+
+	// BuildCommand builds the project inside the container
+	BuildCommand string
+}
+
+// mavenTemplate is the full CI/CD pipeline used for maven projects
+const mavenTemplate = `
+pipeline {
+    agent {
+      label "{{.AgentLabel}}"
+    }
+
+    environment {
+      ORG 		= '{{.Organisation}}'
+      APP_NAME    = '{{.AppName}}'
+      GIT_CREDS = credentials('jenkins-x-git')
+      DOCKER_REGISTRY = "${env.DOCKER_REGISTRY ?: env.JENKINS_X_DOCKER_REGISTRY_SERVICE_HOST + ':' + env.JENKINS_X_DOCKER_REGISTRY_SERVICE_PORT}"
+      CHARTMUSEUM_CREDS = credentials('jenkins-x-chartmuseum')
+
+      GIT_USERNAME = "$GIT_CREDS_USR"
+      GIT_API_TOKEN = "$GIT_CREDS_PSW"
+    }
+
+    stages {
+      stage('CI Build and push snapshpt') {
+        when {
+          branch 'PR-*'
+        }
+        environment {
+          PREVIEW_VERSION = "0.0.0-SNAPSHOT-$BRANCH_NAME-$BUILD_NUMBER"
+          PREVIEW_NAMESPACE = "$APP_NAME-$BRANCH_NAME".toLowerCase()
+          HELM_RELEASE = "$PREVIEW_NAMESPACE".toLowerCase()
+        }
+        steps {
+          container('{{.Container}}') {
+            sh "mvn versions:set -DnewVersion=$PREVIEW_VERSION"
+            sh "mvn install"
+            sh "docker build -f Dockerfile.release -t $DOCKER_REGISTRY/$ORG/$APP_NAME:$PREVIEW_VERSION ."
+            sh "docker push $DOCKER_REGISTRY/$ORG/$APP_NAME:$PREVIEW_VERSION"
+          }
+
+		  // comment out until draft pack includes preview environment charts
+          //dir ('./charts/preview') {
+          //  container('{{.Container}}') {
+          //    sh "make preview"
+          //  }
+          //}
+        }
+      }
+
+      stage('Build Release') {
+        when {
+          branch 'master'
+        }
+        steps {
+          container('{{.Container}}') {
+            // ensure we're not on a detached head
+            sh "git checkout master"
+
+            // until we switch to the new kubernetes / jenkins credential implementation use git credentials store
+            sh "git config --global credential.helper store"
+
+            // so we can retrieve the version in later steps
+            sh "echo \$(jx-release-version) > VERSION"
+            sh "mvn versions:set -DnewVersion=\$(cat VERSION)"
+          }
+
+          dir ('{{.ChartDir}}') {
+            container('{{.Container}}') {
+              sh "make tag"
+            }
+          }
+
+          container('{{.Container}}') {
+            sh 'mvn clean deploy'
+            sh "docker build -f Dockerfile.release -t $DOCKER_REGISTRY/$ORG/$APP_NAME:\$(cat VERSION) ."
+            sh "docker push $DOCKER_REGISTRY/$ORG/$APP_NAME:\$(cat VERSION)"
+            sh 'jx step changelog --version \$(cat VERSION)'
+          }
+        }
+      }
+
+      stage('Promote to Environments') {
+        environment {
+          GIT_USERNAME = "$GIT_CREDS_USR"
+          GIT_API_TOKEN = "$GIT_CREDS_PSW"
+        }
+        when {
+          branch 'master'
+        }
+        steps {
+          dir ('{{.ChartDir}}') {
+            container('{{.Container}}') {
+
+              // release the helm chart
+              sh 'make release'
+
+              // promote through all 'Auto' promotion Environments
+              sh 'jx promote -b --all-auto --timeout 1h --version \$(cat ../../VERSION)'
+            }
+          }
+        }
+      }
+
+    }
+  }
+`
+
+// genericTemplate is a simple build pipeline used for build packs which do
+// not have a richer template yet
+const genericTemplate = `
+pipeline {
+    agent {
+      label "{{.AgentLabel}}"
+    }
+
+    environment {
+      ORG 		= '{{.Organisation}}'
+      APP_NAME    = '{{.AppName}}'
+    }
+
+    stages {
+      stage('Build') {
+        steps {
+          container('{{.Container}}') {
+            sh "{{.BuildCommand}}"
+          }
+        }
+      }
+    }
+}
+`
+
+// builtInTemplates are the Jenkinsfile templates per build pack language
+var builtInTemplates = map[string]string{
+	"maven": mavenTemplate,
+}
+
+// Generate renders the Jenkinsfile for the given build pack language. When a
+// templates directory is configured a template named <language>.tmpl or
+// default.tmpl in it takes precedence over the built in templates
+func Generate(language string, model Model, templatesDir string) (string, error) {
+	if templatesDir != "" {
+		for _, name := range []string{language + ".tmpl", "default.tmpl"} {
+			fileName := filepath.Join(templatesDir, name)
+			exists, err := util.FileExists(fileName)
+			if err != nil {
+				return "", err
+			}
+			if exists {
+				data, err := ioutil.ReadFile(fileName)
+				if err != nil {
+					return "", err
+				}
+				return render(name, string(data), model)
+			}
+		}
+	}
+	text := builtInTemplates[language]
+	if text == "" {
+		text = genericTemplate
+	}
+	return render(language, text, model)
+}
+
+// GenerateFromURL downloads the Jenkinsfile template from the given URL and
+// renders it against the model so that teams can share a pipeline template
+// from a git raw URL or an internal web server
+func GenerateFromURL(templateURL string, model Model) (string, error) {
+	resp, err := http.Get(templateURL)
+	if err != nil {
+		return "", fmt.Errorf("Failed to download the Jenkinsfile template %s due to: %s", templateURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("Failed to download the Jenkinsfile template %s: %s", templateURL, resp.Status)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("Failed to download the Jenkinsfile template %s due to: %s", templateURL, err)
+	}
+	return render(templateURL, string(data), model)
+}
+
+// render executes the template text against the model
+func render(name string, text string, model Model) (string, error) {
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("Failed to parse the Jenkinsfile template %s due to: %s", name, err)
+	}
+	buffer := &bytes.Buffer{}
+	err = tmpl.Execute(buffer, model)
+	if err != nil {
+		return "", fmt.Errorf("Failed to render the Jenkinsfile template %s due to: %s", name, err)
+	}
+	return buffer.String(), nil
+}
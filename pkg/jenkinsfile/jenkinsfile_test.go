@@ -0,0 +1,73 @@
+package jenkinsfile
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateMaven(t *testing.T) {
+	model := Model{
+		AppName:      "myapp",
+		Organisation: "jenkinsx",
+		ChartDir:     "./charts/myapp",
+		AgentLabel:   "jenkins-maven",
+		Container:    "maven",
+	}
+	text, err := Generate("maven", model, "")
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(text, `label "jenkins-maven"`), "Expected the agent label in the pipeline")
+	assert.True(t, strings.Contains(text, "APP_NAME    = 'myapp'"), "Expected the app name in the pipeline")
+	assert.True(t, strings.Contains(text, "dir ('./charts/myapp')"), "Expected the chart dir in the pipeline")
+	assert.False(t, strings.Contains(text, "{{"), "Expected all the placeholders to be substituted")
+}
+
+func TestGenerateGeneric(t *testing.T) {
+	model := Model{
+		AppName:      "myapp",
+		Organisation: "jenkinsx",
+		AgentLabel:   "jenkins-go",
+		Container:    "go",
+		BuildCommand: "go build ./...",
+	}
+	text, err := Generate("go", model, "")
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(text, `label "jenkins-go"`), "Expected the agent label in the pipeline")
+	assert.True(t, strings.Contains(text, `sh "go build ./..."`), "Expected the build command in the pipeline")
+}
+
+func TestGenerateFromTemplatesDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jx-jenkinsfile-test-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "go.tmpl"), []byte("pipeline for {{.AppName}}"), 0644)
+	assert.Nil(t, err)
+
+	text, err := Generate("go", Model{AppName: "myapp"}, dir)
+	assert.Nil(t, err)
+	assert.Equal(t, "pipeline for myapp", text, "Expected the custom template to be used")
+}
+
+func TestGenerateFromURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("pipeline for {{.AppName}}"))
+	}))
+	defer server.Close()
+
+	text, err := GenerateFromURL(server.URL, Model{AppName: "myapp"})
+	assert.Nil(t, err)
+	assert.Equal(t, "pipeline for myapp", text, "Expected the downloaded template to be rendered")
+
+	missing := httptest.NewServer(http.NotFoundHandler())
+	defer missing.Close()
+
+	_, err = GenerateFromURL(missing.URL, Model{AppName: "myapp"})
+	assert.NotNil(t, err, "Expected an error when the template cannot be downloaded")
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/golang-jenkins"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jenkins"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// multiBranchProjectClass is the Jenkins class of a multibranch pipeline job
+const multiBranchProjectClass = "WorkflowMultiBranchProject"
+
+// AdoptOptions the options for the adopt command
+type AdoptOptions struct {
+	CommonOptions
+
+	Filter string
+	NoTag  bool
+	DryRun bool
+}
+
+// adoptedProject is a multibranch pipeline discovered in Jenkins along with
+// the git repository it builds
+type adoptedProject struct {
+	JobName       string
+	JobPath       []string
+	GitURL        string
+	CredentialsID string
+}
+
+var (
+	adopt_long = templates.LongDesc(`
+		Adopts the pipelines already configured in Jenkins into the platform.
+
+		This scans the Jenkins instance for multibranch pipeline jobs, creates the team
+		environment records if they are missing, records the Jenkins credentials the
+		pipelines use as the team default for future imports and tags the git repositories
+		as managed by the platform.
+
+		This lets a brown-field installation with existing pipelines and webhooks come
+		under jx management without re-importing each project.
+`)
+
+	adopt_example = templates.Examples(`
+		# Adopt all the multibranch pipelines configured in Jenkins
+		jx adopt
+
+		# Show what would be adopted without changing anything
+		jx adopt --dry-run
+
+		# Only adopt the pipelines of one organisation folder
+		jx adopt --filter myorg
+	`)
+)
+
+// NewCmdAdopt creates the adopt command
+func NewCmdAdopt(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &AdoptOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "adopt",
+		Short:   "Adopts the pipelines already configured in Jenkins into the platform",
+		Long:    adopt_long,
+		Example: adopt_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Filter, "filter", "f", "", "Only adopt the pipelines whose job name contains this text")
+	cmd.Flags().BoolVarP(&options.NoTag, "no-tag", "", false, "Do not tag the git repositories as managed by the platform")
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Show what would be adopted without changing anything")
+	return cmd
+}
+
+// Run implements the command
+func (o *AdoptOptions) Run() error {
+	jenkinsClient, err := o.JenkinsClient()
+	if err != nil {
+		return err
+	}
+	jobs, err := jenkinsClient.GetJobs()
+	if err != nil {
+		return err
+	}
+	projects := []*adoptedProject{}
+	for _, job := range jobs {
+		err = o.findMultiBranchProjects(jenkinsClient, []string{}, &job, &projects)
+		if err != nil {
+			return err
+		}
+	}
+	if len(projects) == 0 {
+		o.Printf("No multibranch pipelines found in Jenkins to adopt\n")
+		return nil
+	}
+
+	kubeClient, currentNs, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	devNs, _, err := kube.GetDevNamespace(kubeClient, currentNs)
+	if err != nil {
+		return err
+	}
+	jxClient, _, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+
+	table := o.CreateTable()
+	table.AddRow("JOB", "REPOSITORY", "STATUS")
+	credentials := ""
+	for _, project := range projects {
+		if credentials == "" {
+			credentials = project.CredentialsID
+		}
+		status := "adopted"
+		if o.DryRun {
+			status = "would adopt"
+		} else if !o.NoTag {
+			err = o.tagAdoptedRepository(project.GitURL, devNs)
+			if err != nil {
+				o.warnf("%s\n", err)
+				status = "adopted (not tagged)"
+			}
+		}
+		table.AddRow(project.JobName, project.GitURL, status)
+	}
+	table.Render()
+
+	if o.DryRun {
+		o.Printf("dry-run: no records were created\n")
+		return nil
+	}
+
+	env, err := kube.EnsureDevEnvironmentSetup(jxClient, devNs)
+	if err != nil {
+		return err
+	}
+	if credentials != "" && env.Spec.TeamSettings.ImportDefaults.Credentials == "" {
+		// record the credentials the existing pipelines use so future imports reuse them
+		env.Spec.TeamSettings.ImportDefaults.Credentials = credentials
+		_, err = jxClient.JenkinsV1().Environments(devNs).Update(env)
+		if err != nil {
+			return err
+		}
+		o.Printf("Set the team default Jenkins credentials to %s\n", util.ColorInfo(credentials))
+	}
+	o.Printf("Adopted %s pipelines into team %s\n", util.ColorInfo(len(projects)), util.ColorInfo(devNs))
+	return nil
+}
+
+// findMultiBranchProjects recurses into folders collecting the multibranch
+// pipeline jobs along with the git URL each one builds
+func (o *AdoptOptions) findMultiBranchProjects(jenkinsClient *gojenkins.Jenkins, path []string, job *gojenkins.Job, projects *[]*adoptedProject) error {
+	jobPath := append(append([]string{}, path...), job.Name)
+	if strings.Contains(job.Class, multiBranchProjectClass) {
+		name := strings.Join(jobPath, "/")
+		if o.Filter != "" && !strings.Contains(name, o.Filter) {
+			return nil
+		}
+		configXml, err := jenkinsClient.GetJobConfigXML(jobPath...)
+		if err != nil {
+			o.warnf("Failed to load the configuration of job %s due to: %s\n", name, err)
+			return nil
+		}
+		project, err := jenkins.ParseMultiBranchProjectXml(configXml)
+		if err != nil {
+			o.warnf("Failed to parse the configuration of job %s due to: %s\n", name, err)
+			return nil
+		}
+		gitURL := project.GitURL()
+		if gitURL == "" {
+			o.warnf("Could not find the git URL of job %s so not adopting it\n", name)
+			return nil
+		}
+		*projects = append(*projects, &adoptedProject{
+			JobName:       name,
+			JobPath:       jobPath,
+			GitURL:        gitURL,
+			CredentialsID: project.CredentialsID(),
+		})
+		return nil
+	}
+	children := job.Jobs
+	if children == nil {
+		fullJob, err := jenkinsClient.GetJobByPath(jobPath...)
+		if err != nil {
+			return nil
+		}
+		children = fullJob.Jobs
+	}
+	for _, child := range children {
+		err := o.findMultiBranchProjects(jenkinsClient, jobPath, &child, projects)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tagAdoptedRepository tags the git repository with the managed topics in the
+// same way as an import would
+func (o *AdoptOptions) tagAdoptedRepository(gitURL string, team string) error {
+	gitInfo, err := gits.ParseGitURL(gitURL)
+	if err != nil {
+		return err
+	}
+	gitProvider, err := o.gitProviderForURL(gitURL, "user name to tag the repository")
+	if err != nil {
+		return err
+	}
+	topicsProvider, ok := gitProvider.(gits.GitTopicsProvider)
+	if !ok {
+		return nil
+	}
+	topics, err := topicsProvider.ListRepositoryTopics(gitInfo.Organisation, gitInfo.Name)
+	if err != nil {
+		return err
+	}
+	changed := false
+	for _, topic := range []string{gits.ManagedRepoTopic, repoTopic("team-" + team)} {
+		if topic != "" && util.StringArrayIndex(topics, topic) < 0 {
+			topics = append(topics, topic)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return topicsProvider.UpdateRepositoryTopics(gitInfo.Organisation, gitInfo.Name, topics)
+}
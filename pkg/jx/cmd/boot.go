@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/jenkins"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BootOptions the options for the boot command
+type BootOptions struct {
+	CommonOptions
+
+	ConfigFile string
+}
+
+var (
+	bootLong = templates.LongDesc(`
+		Onboards a team from a single declarative configuration file.
+
+		The file declares the team, its git servers, extra environments, addons,
+		policies and the initial repositories to import. Every step is
+		idempotent so the command can be re-run whenever the file changes, which
+		makes it easy to onboard new business units via a Pull Request to a
+		bootstrap repository.
+`)
+
+	bootExample = templates.Examples(`
+		# Onboard the team declared in team.yaml
+		jx boot --config team.yaml
+	`)
+)
+
+// NewCmdBoot creates the command object for the "boot" command
+func NewCmdBoot(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &BootOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "boot",
+		Short:   "Onboards a team from a declarative configuration file",
+		Long:    bootLong,
+		Example: bootExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.ConfigFile, "config", "c", config.TeamBootConfigFileName, "The team onboarding configuration file")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *BootOptions) Run() error {
+	cfg, err := config.LoadTeamBootConfig(o.ConfigFile)
+	if err != nil {
+		return err
+	}
+	team := kube.ToValidName(cfg.Team)
+	o.Printf("Onboarding team %s from %s\n", util.ColorInfo(team), util.ColorInfo(o.ConfigFile))
+
+	err = o.bootTeam(cfg, team)
+	if err != nil {
+		return err
+	}
+	err = o.bootGitServers(cfg)
+	if err != nil {
+		return err
+	}
+	err = o.bootEnvironments(cfg, team)
+	if err != nil {
+		return err
+	}
+	err = o.bootPolicies(cfg, team)
+	if err != nil {
+		return err
+	}
+	err = o.bootAddons(cfg, team)
+	if err != nil {
+		return err
+	}
+	err = o.bootRepositories(cfg)
+	if err != nil {
+		return err
+	}
+	o.Printf("Team %s onboarded\n", util.ColorInfo(team))
+	return nil
+}
+
+// bootTeam creates the team with its namespace, default environments and RBAC.
+// The Jenkins install is skipped when the team already has a platform release
+// so that re-runs are idempotent
+func (o *BootOptions) bootTeam(cfg *config.TeamBootConfig, team string) error {
+	sharedJenkins := cfg.SharedJenkins
+	if !sharedJenkins {
+		releases, err := o.listPlatformReleases()
+		if err == nil {
+			for _, release := range releases {
+				if release.Namespace == team {
+					o.Printf("The team %s already has the platform release %s so skipping the Jenkins install\n",
+						util.ColorInfo(team), util.ColorInfo(release.Release))
+					sharedJenkins = true
+					break
+				}
+			}
+		}
+	}
+	options := &CreateTeamOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: o.CommonOptions,
+		},
+		SharedJenkins: sharedJenkins,
+	}
+	options.Args = []string{team}
+	options.BatchMode = true
+	err := options.Run()
+	if err != nil {
+		return fmt.Errorf("Failed to create the team %s due to: %s", team, err)
+	}
+	return nil
+}
+
+// bootGitServers registers the git servers of the team
+func (o *BootOptions) bootGitServers(cfg *config.TeamBootConfig) error {
+	for _, server := range cfg.GitServers {
+		options := &CreateGitServerOptions{
+			CreateOptions: CreateOptions{
+				CommonOptions: o.CommonOptions,
+			},
+			Name: server.Name,
+		}
+		options.Args = []string{server.Kind, server.URL}
+		options.BatchMode = true
+		err := options.Run()
+		if err != nil {
+			return fmt.Errorf("Failed to register the git server %s due to: %s", server.URL, err)
+		}
+	}
+	return nil
+}
+
+// bootEnvironments creates the extra environments of the team beyond the
+// default staging and production
+func (o *BootOptions) bootEnvironments(cfg *config.TeamBootConfig, team string) error {
+	if len(cfg.Environments) == 0 {
+		return nil
+	}
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	jxClient, _, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	for _, e := range cfg.Environments {
+		name := kube.ToValidName(e.Name)
+		ns := e.Namespace
+		if ns == "" {
+			ns = team + "-" + name
+		}
+		strategy := v1.PromotionStrategyTypeManual
+		if strings.EqualFold(e.PromotionStrategy, "auto") || e.PromotionStrategy == string(v1.PromotionStrategyTypeAutomatic) {
+			strategy = v1.PromotionStrategyTypeAutomatic
+		}
+		_, err := jxClient.JenkinsV1().Environments(team).Get(name, metav1.GetOptions{})
+		if err == nil {
+			continue
+		}
+		env := &v1.Environment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: v1.EnvironmentSpec{
+				Namespace:         ns,
+				Label:             strings.Title(name),
+				Order:             e.Order,
+				PromotionStrategy: strategy,
+			},
+		}
+		_, err = jxClient.JenkinsV1().Environments(team).Create(env)
+		if err != nil {
+			return fmt.Errorf("Failed to create the %s Environment for team %s due to: %s", name, team, err)
+		}
+		err = kube.EnsureEnvironmentNamespaceSetup(client, jxClient, env, team)
+		if err != nil {
+			return err
+		}
+		o.Printf("Created the %s Environment in namespace %s\n", util.ColorInfo(name), util.ColorInfo(ns))
+	}
+	return nil
+}
+
+// bootPolicies applies the declared policies to the team settings
+func (o *BootOptions) bootPolicies(cfg *config.TeamBootConfig, team string) error {
+	policies := cfg.Policies
+	if policies == nil {
+		return nil
+	}
+	jxClient, _, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	env, err := jxClient.JenkinsV1().Environments(team).Get(kube.LabelValueDevEnvironment, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to load the team settings of %s due to: %s", team, err)
+	}
+	settings := &env.Spec.TeamSettings
+	settings.ImportDefaults.BranchProtection = policies.BranchProtection
+	settings.ImportDefaults.PrivateRepos = policies.PrivateRepos
+	if len(policies.Owners) > 0 {
+		settings.ImportDefaults.Owners = policies.Owners
+	}
+	settings.PipelinePolicies.StageTimeoutMinutes = policies.StageTimeoutMinutes
+	settings.PipelinePolicies.StageRetryCount = policies.StageRetryCount
+	_, err = jxClient.JenkinsV1().Environments(team).Update(env)
+	if err != nil {
+		return fmt.Errorf("Failed to save the team settings of %s due to: %s", team, err)
+	}
+	o.Printf("Applied the policies to the team settings of %s\n", util.ColorInfo(team))
+	return nil
+}
+
+// bootAddons installs the addons into the team namespace
+func (o *BootOptions) bootAddons(cfg *config.TeamBootConfig, team string) error {
+	for _, addon := range cfg.Addons {
+		options := &CreateAddonOptions{
+			CreateOptions: CreateOptions{
+				CommonOptions: o.CommonOptions,
+			},
+			Namespace:  team,
+			HelmUpdate: true,
+		}
+		options.BatchMode = true
+		err := options.CreateAddon(addon)
+		if err != nil {
+			return fmt.Errorf("Failed to install the addon %s due to: %s", addon, err)
+		}
+	}
+	return nil
+}
+
+// bootRepositories imports the initial repositories of the team skipping any
+// which already have a Jenkins job
+func (o *BootOptions) bootRepositories(cfg *config.TeamBootConfig) error {
+	for _, repo := range cfg.Repositories {
+		o.Printf("Importing the repository %s\n", util.ColorInfo(repo))
+		options := &ImportOptions{
+			CommonOptions: o.CommonOptions,
+		}
+		options.RepoURL = repo
+		options.JobExistsMode = jenkins.JobExistsSkip
+		options.BatchMode = true
+		err := options.Run()
+		if err != nil {
+			return fmt.Errorf("Failed to import the repository %s due to: %s", repo, err)
+		}
+	}
+	return nil
+}
@@ -51,14 +51,19 @@ func NewJXCommand(f cmdutil.Factory, in io.Reader, out, err io.Writer) *cobra.Co
 			},
 	*/
 
+	cmds.AddCommand(NewCmdAdopt(f, out, err))
+	cmds.AddCommand(NewCmdBoot(f, out, err))
 	cmds.AddCommand(NewCmdCreateAddon(f, out, err))
 	cmds.AddCommand(NewCmdCompletion(f, out))
 	cmds.AddCommand(NewCmdContext(f, out, err))
 	cmds.AddCommand(NewCmdConsole(f, out, err))
+	cmds.AddCommand(NewCmdController(f, out, err))
 	cmds.AddCommand(NewCmdCDX(f, out, err))
 	cmds.AddCommand(NewCmdCreate(f, out, err))
 	cmds.AddCommand(NewCmdEdit(f, out, err))
 	cmds.AddCommand(NewCmdDelete(f, out, err))
+	cmds.AddCommand(NewCmdDiagnose(f, out, err))
+	cmds.AddCommand(NewCmdDiff(f, out, err))
 	cmds.AddCommand(NewCmdEnvironment(f, out, err))
 	cmds.AddCommand(NewCmdGet(f, out, err))
 	cmds.AddCommand(NewCmdImport(f, out, err))
@@ -72,10 +77,14 @@ func NewJXCommand(f cmdutil.Factory, in io.Reader, out, err io.Writer) *cobra.Co
 	cmds.AddCommand(NewCmdPromote(f, out, err))
 	cmds.AddCommand(NewCmdPrompt(f, out, err))
 	cmds.AddCommand(NewCmdRsh(f, out, err))
+	cmds.AddCommand(NewCmdScale(f, out, err))
 	cmds.AddCommand(NewCmdShell(f, out, err))
+	cmds.AddCommand(NewCmdReplay(f, out, err))
 	cmds.AddCommand(NewCmdStart(f, out, err))
 	cmds.AddCommand(NewCmdStep(f, out, err))
+	cmds.AddCommand(NewCmdTest(f, out, err))
 	cmds.AddCommand(NewCmdUninstall(f, out, err))
+	cmds.AddCommand(NewCmdUpdate(f, out, err))
 	cmds.AddCommand(NewCmdUpgrade(f, out, err))
 	cmds.AddCommand(NewCmdVersion(f, out, err))
 
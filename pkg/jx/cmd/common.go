@@ -63,6 +63,7 @@ func addGitRepoOptionsArguments(cmd *cobra.Command, repositoryOptions *gits.GitR
 	cmd.Flags().StringVarP(&repositoryOptions.ServerURL, "git-provider-url", "", "github.com", "The git server URL to create new git repositories inside")
 	cmd.Flags().StringVarP(&repositoryOptions.Username, "git-username", "", "", "The git username to use for creating new git repositories")
 	cmd.Flags().StringVarP(&repositoryOptions.ApiToken, "git-api-token", "", "", "The git API token to use for creating new git repositories")
+	cmd.Flags().BoolVarP(&repositoryOptions.Private, "git-private", "", false, "Create new git repositories as private")
 }
 
 func (c *CommonOptions) CreateTable() table.Table {
@@ -194,6 +195,36 @@ func (o *CommonOptions) gitProviderForURL(gitURL string, message string) (gits.G
 	return gitInfo.PickOrCreateProvider(authConfigSvc, message, o.BatchMode)
 }
 
+// gitProviderForHost returns a GitProvider for the given git server host,
+// defaulting to GitHub when the github flag is set and prompting the user to
+// pick a server otherwise
+func (o *CommonOptions) gitProviderForHost(github bool, gitHost string, message string) (gits.GitProvider, error) {
+	authConfigSvc, err := o.Factory.CreateGitAuthConfigService()
+	if err != nil {
+		return nil, err
+	}
+	var server *auth.AuthServer
+	config := authConfigSvc.Config()
+	if github {
+		server = config.GetOrCreateServer(gits.GitHubHost)
+	} else if gitHost != "" {
+		server = config.GetOrCreateServer(gitHost)
+	} else {
+		server, err = config.PickServer(message)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if server == nil {
+		return nil, fmt.Errorf("No git server provided!")
+	}
+	userAuth, err := config.PickServerUserAuth(server, "git user name", o.BatchMode)
+	if err != nil {
+		return nil, err
+	}
+	return gits.CreateProvider(server, userAuth)
+}
+
 func (o *ServerFlags) addGitServerFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&o.ServerName, optionServerName, "n", "", "The name of the git server to add a user")
 	cmd.Flags().StringVarP(&o.ServerName, optionServerURL, "u", "", "The URL of the git server to add a user")
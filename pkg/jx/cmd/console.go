@@ -11,12 +11,14 @@ import (
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/pkg/browser"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type ConsoleOptions struct {
 	CommonOptions
 
-	OnlyViewURL bool
+	OnlyViewURL     bool
+	ShowCredentials bool
 }
 
 var (
@@ -27,7 +29,10 @@ var (
 		jx console
 
 		# Print the Jenkins X console URL but do not open a browser
-		jx console -u`)
+		jx console -u
+
+		# Open the console and print the admin credentials
+		jx console --show-credentials`)
 )
 
 func NewCmdConsole(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
@@ -51,6 +56,7 @@ func NewCmdConsole(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Co
 		},
 	}
 	cmd.Flags().BoolVarP(&options.OnlyViewURL, "url", "u", false, "Only displays and the URL and does not open the browser")
+	cmd.Flags().BoolVarP(&options.ShowCredentials, "show-credentials", "", false, "Displays the admin credentials from the Kubernetes secret as well")
 	return cmd
 }
 
@@ -64,8 +70,37 @@ func (o *ConsoleOptions) Open(name string, label string) error {
 		return err
 	}
 	fmt.Fprintf(o.Out, "%s: %s\n", label, util.ColorInfo(url))
+	if o.ShowCredentials {
+		err = o.showAdminCredentials()
+		if err != nil {
+			return err
+		}
+	}
 	if !o.OnlyViewURL {
 		browser.OpenURL(url)
 	}
 	return nil
 }
+
+// showAdminCredentials prints the Jenkins admin credentials stored in the admin secret
+func (o *ConsoleOptions) showAdminCredentials() error {
+	client, curNs, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err := kube.GetDevNamespace(client, curNs)
+	if err != nil {
+		return err
+	}
+	secret, err := client.CoreV1().Secrets(ns).Get(kube.SecretJenkins, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to find the secret %s in namespace %s due to: %s", kube.SecretJenkins, ns, err)
+	}
+	username := string(secret.Data[kube.JenkinsAdminUserField])
+	if username == "" {
+		username = "admin"
+	}
+	password := string(secret.Data[kube.JenkinsAdminPasswordField])
+	fmt.Fprintf(o.Out, "Username: %s\nPassword: %s\n", util.ColorInfo(username), util.ColorInfo(password))
+	return nil
+}
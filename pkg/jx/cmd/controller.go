@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+)
+
+// ControllerOptions contains the CLI options
+type ControllerOptions struct {
+	CommonOptions
+}
+
+// NewCmdController creates the command object for the "controller" command
+// which hosts the long running controllers
+func NewCmdController(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ControllerOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "controller",
+		Short:   "Runs a controller",
+		Aliases: []string{"controllers"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.AddCommand(NewCmdControllerAbort(f, out, errOut))
+	cmd.AddCommand(NewCmdControllerBuildQueue(f, out, errOut))
+	cmd.AddCommand(NewCmdControllerCert(f, out, errOut))
+	cmd.AddCommand(NewCmdControllerLabels(f, out, errOut))
+	cmd.AddCommand(NewCmdControllerMergeQueue(f, out, errOut))
+	cmd.AddCommand(NewCmdControllerReviewers(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *ControllerOptions) Run() error {
+	return o.Cmd.Help()
+}
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/golang-jenkins"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// supersededBuildLookback is how many builds before the last one are checked
+// for still being in flight when looking for superseded builds
+const supersededBuildLookback = 10
+
+// ControllerAbortOptions contains the command line flags
+type ControllerAbortOptions struct {
+	ControllerOptions
+
+	PollTime int
+	RunOnce  bool
+	Excludes []string
+}
+
+var (
+	controllerAbortLong = templates.LongDesc(`
+		Runs the controller which aborts superseded pull request builds.
+
+		When a pull request build is still running while a newer commit of the
+		same pull request is already being built, the older build can never be
+		merged so it only wastes agent capacity. The controller polls the
+		Jenkins jobs and aborts every in-flight pull request build which has
+		been superseded by a newer one. Repositories can be excluded with the
+		--exclude flag when they rely on every build completing.
+`)
+
+	controllerAbortExample = templates.Examples(`
+		# Run the superseded build controller
+		jx controller abort
+
+		# Never abort the builds of the myorg/release repository
+		jx controller abort --exclude myorg/release
+	`)
+)
+
+// NewCmdControllerAbort creates the command
+func NewCmdControllerAbort(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ControllerAbortOptions{
+		ControllerOptions: ControllerOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "abort",
+		Short:   "Runs the controller which aborts superseded pull request builds",
+		Aliases: []string{"superseded"},
+		Long:    controllerAbortLong,
+		Example: controllerAbortExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().IntVarP(&options.PollTime, "poll-time", "", 60, "The number of seconds between polls of the Jenkins jobs")
+	cmd.Flags().BoolVarP(&options.RunOnce, "run-once", "", false, "Abort the currently superseded builds and exit instead of polling")
+	cmd.Flags().StringArrayVarP(&options.Excludes, "exclude", "", []string{}, "An org/repository whose builds are never aborted. Can be specified multiple times")
+	return cmd
+}
+
+// Run implements this command
+func (o *ControllerAbortOptions) Run() error {
+	for {
+		err := o.abortSupersededBuilds()
+		if err != nil {
+			if o.RunOnce {
+				return err
+			}
+			o.warnf("Failed to abort superseded builds: %s\n", err)
+		}
+		if o.RunOnce {
+			return nil
+		}
+		time.Sleep(time.Duration(o.PollTime) * time.Second)
+	}
+}
+
+// abortSupersededBuilds aborts every running pull request build which has been
+// superseded by a newer build of the same pull request
+func (o *ControllerAbortOptions) abortSupersededBuilds() error {
+	jobMap, err := o.getJobMap("")
+	if err != nil {
+		return err
+	}
+	jenkinsClient, err := o.JenkinsClient()
+	if err != nil {
+		return err
+	}
+	names := []string{}
+	for name := range jobMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !isPullRequestJob(name) || o.isExcluded(name) {
+			continue
+		}
+		job := jobMap[name]
+		building, err := runningBuilds(jenkinsClient, job)
+		if err != nil {
+			o.warnf("Failed to load the builds of %s: %s\n", name, err)
+			continue
+		}
+		if len(building) < 2 {
+			continue
+		}
+		// the newest build is the one of the latest commit so keep it running
+		for _, build := range building[1:] {
+			u, err := url.Parse(build.Url)
+			if err != nil {
+				o.warnf("Could not parse the URL of build %s #%d: %s\n", name, build.Number, err)
+				continue
+			}
+			err = jenkinsClient.StopBuild(u.Path)
+			if err != nil {
+				o.warnf("Failed to abort build %s #%d: %s\n", name, build.Number, err)
+				continue
+			}
+			o.Printf("Aborted build %s #%d as it was superseded by build #%d\n",
+				util.ColorInfo(name), build.Number, building[0].Number)
+		}
+	}
+	return nil
+}
+
+// runningBuilds returns the in-flight builds of the job newest first
+func runningBuilds(jenkinsClient *gojenkins.Jenkins, job *gojenkins.Job) ([]gojenkins.Build, error) {
+	answer := []gojenkins.Build{}
+	last, err := jenkinsClient.GetLastBuild(*job)
+	if err != nil {
+		if jenkinsClient.IsErrNotFound(err) {
+			return answer, nil
+		}
+		return answer, err
+	}
+	lowest := last.Number - supersededBuildLookback
+	if lowest < 1 {
+		lowest = 1
+	}
+	for number := last.Number; number >= lowest; number-- {
+		build := last
+		if number != last.Number {
+			build, err = jenkinsClient.GetBuild(*job, number)
+			if err != nil {
+				if jenkinsClient.IsErrNotFound(err) {
+					continue
+				}
+				return answer, err
+			}
+		}
+		if build.Building {
+			answer = append(answer, build)
+		}
+	}
+	return answer, nil
+}
+
+// isPullRequestJob returns true if the job name is a pull request branch job
+func isPullRequestJob(name string) bool {
+	idx := strings.LastIndex(name, "/")
+	if idx < 0 {
+		return false
+	}
+	return strings.HasPrefix(name[idx+1:], "PR-")
+}
+
+// isExcluded returns true if the org/repository of the job has been excluded
+func (o *ControllerAbortOptions) isExcluded(name string) bool {
+	idx := strings.LastIndex(name, "/")
+	if idx < 0 {
+		return false
+	}
+	repository := name[0:idx]
+	return util.StringArrayIndex(o.Excludes, repository) >= 0
+}
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// buildCapacityDeployment is the name of the placeholder deployment the build
+// queue controller scales to reserve capacity for queued builds
+const buildCapacityDeployment = "jx-build-capacity"
+
+// ControllerBuildQueueOptions contains the command line flags
+type ControllerBuildQueueOptions struct {
+	ControllerOptions
+
+	PollTime     int
+	MinReplicas  int
+	MaxReplicas  int
+	Cpu          string
+	Memory       string
+	NodeSelector string
+	RunOnce      bool
+}
+
+var (
+	controllerBuildQueueLong = templates.LongDesc(`
+		Runs the build queue controller which scales build capacity ahead of
+		demand.
+
+		The controller polls the Jenkins build queue and scales a placeholder
+		deployment of pause pods to match the number of buildable items. The
+		placeholder pods request the same resources as a build agent so the
+		cluster autoscaler provisions nodes before the agents are scheduled,
+		and the agents then evict the placeholders as they have a lower
+		priority. When the queue drains the placeholders are scaled back down
+		so the autoscaler can remove the spare nodes again.
+`)
+
+	controllerBuildQueueExample = templates.Examples(`
+		# Run the build queue controller
+		jx controller buildqueue
+
+		# Reserve capacity on the CI node pool only
+		jx controller buildqueue --node-selector node-role=ci
+	`)
+)
+
+// NewCmdControllerBuildQueue creates the command
+func NewCmdControllerBuildQueue(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ControllerBuildQueueOptions{
+		ControllerOptions: ControllerOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "buildqueue",
+		Short:   "Runs the build queue controller to scale build capacity ahead of demand",
+		Aliases: []string{"bq"},
+		Long:    controllerBuildQueueLong,
+		Example: controllerBuildQueueExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().IntVarP(&options.PollTime, "poll-time", "", 30, "The number of seconds between polls of the build queue")
+	cmd.Flags().IntVarP(&options.MinReplicas, "min", "", 0, "The minimum number of placeholder pods to keep as warm capacity")
+	cmd.Flags().IntVarP(&options.MaxReplicas, "max", "", 10, "The maximum number of placeholder pods")
+	cmd.Flags().StringVarP(&options.Cpu, "cpu", "", "1", "The cpu request of each placeholder pod; should match a build agent")
+	cmd.Flags().StringVarP(&options.Memory, "memory", "", "2Gi", "The memory request of each placeholder pod; should match a build agent")
+	cmd.Flags().StringVarP(&options.NodeSelector, "node-selector", "", "", "Node selector of the placeholder pods in the form key=value,key2=value2 to target a dedicated CI node pool")
+	cmd.Flags().BoolVarP(&options.RunOnce, "run-once", "", false, "Reconcile the capacity once and exit instead of polling")
+	return cmd
+}
+
+// Run implements this command
+func (o *ControllerBuildQueueOptions) Run() error {
+	if o.MaxReplicas < o.MinReplicas {
+		return fmt.Errorf("The --max value %d must not be smaller than the --min value %d", o.MaxReplicas, o.MinReplicas)
+	}
+	for {
+		err := o.reconcileCapacity()
+		if err != nil {
+			o.warnf("Failed to reconcile the build capacity: %s\n", err)
+		}
+		if o.RunOnce {
+			return err
+		}
+		time.Sleep(time.Duration(o.PollTime) * time.Second)
+	}
+}
+
+// reconcileCapacity scales the placeholder deployment to the number of
+// buildable items in the Jenkins build queue
+func (o *ControllerBuildQueueOptions) reconcileCapacity() error {
+	jenkins, err := o.JenkinsClient()
+	if err != nil {
+		return err
+	}
+	queue, err := jenkins.GetQueue()
+	if err != nil {
+		return fmt.Errorf("Failed to query the build queue due to: %s", err)
+	}
+	buildable := 0
+	for _, item := range queue.Items {
+		if item.Buildable && !item.Blocked {
+			buildable++
+		}
+	}
+	replicas := buildable
+	if replicas < o.MinReplicas {
+		replicas = o.MinReplicas
+	}
+	if replicas > o.MaxReplicas {
+		replicas = o.MaxReplicas
+	}
+	return o.scalePlaceholder(replicas, buildable)
+}
+
+// scalePlaceholder creates or scales the placeholder deployment to the given
+// number of replicas
+func (o *ControllerBuildQueueOptions) scalePlaceholder(replicas int, buildable int) error {
+	kubeClient, currentNs, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err := kube.GetDevNamespace(kubeClient, currentNs)
+	if err != nil {
+		return err
+	}
+	deployments := kubeClient.ExtensionsV1beta1().Deployments(ns)
+	deployment, err := deployments.Get(buildCapacityDeployment, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		deployment, err = o.placeholderDeployment(replicas)
+		if err != nil {
+			return err
+		}
+		_, err = deployments.Create(deployment)
+		if err != nil {
+			return fmt.Errorf("Failed to create the deployment %s due to: %s", buildCapacityDeployment, err)
+		}
+	} else {
+		if deployment.Spec.Replicas != nil && int(*deployment.Spec.Replicas) == replicas {
+			return nil
+		}
+		count := int32(replicas)
+		deployment.Spec.Replicas = &count
+		_, err = deployments.Update(deployment)
+		if err != nil {
+			return fmt.Errorf("Failed to scale the deployment %s due to: %s", buildCapacityDeployment, err)
+		}
+	}
+	o.Printf("Scaled the build capacity to %s placeholder pod(s) for %s buildable item(s) in the queue\n",
+		util.ColorInfo(fmt.Sprintf("%d", replicas)), util.ColorInfo(fmt.Sprintf("%d", buildable)))
+	return nil
+}
+
+// placeholderDeployment returns the placeholder deployment of pause pods
+// which reserve capacity for build agents
+func (o *ControllerBuildQueueOptions) placeholderDeployment(replicas int) (*v1beta1.Deployment, error) {
+	cpu, err := resource.ParseQuantity(o.Cpu)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid --cpu value %s: %s", o.Cpu, err)
+	}
+	memory, err := resource.ParseQuantity(o.Memory)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid --memory value %s: %s", o.Memory, err)
+	}
+	count := int32(replicas)
+	labels := map[string]string{"app": buildCapacityDeployment}
+	return &v1beta1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   buildCapacityDeployment,
+			Labels: labels,
+		},
+		Spec: v1beta1.DeploymentSpec{
+			Replicas: &count,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					NodeSelector: parseNodeSelector(o.NodeSelector),
+					Containers: []corev1.Container{
+						{
+							Name:  "pause",
+							Image: "k8s.gcr.io/pause:3.0",
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    cpu,
+									corev1.ResourceMemory: memory,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
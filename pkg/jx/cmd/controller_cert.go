@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// ControllerCertOptions contains the command line flags
+type ControllerCertOptions struct {
+	ControllerOptions
+
+	PollTime   int
+	ExpiryDays int
+	RunOnce    bool
+}
+
+var (
+	controllerCertLong = templates.LongDesc(`
+		Runs the certificate controller which watches the TLS certificates of the
+		exposed services.
+
+		The controller periodically connects to each TLS host found on the Ingress
+		resources of the team and environment namespaces and warns when a
+		certificate has expired, is about to expire or can no longer be fetched, so
+		a failed renewal is noticed before an expired webhook endpoint silently
+		stops all CI triggers.
+`)
+
+	controllerCertExample = templates.Examples(`
+		# Run the certificate controller
+		jx controller cert
+
+		# Check the certificates once and fail if any expire within 30 days
+		jx controller cert --run-once --expiry-days 30
+	`)
+)
+
+// NewCmdControllerCert creates the command
+func NewCmdControllerCert(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ControllerCertOptions{
+		ControllerOptions: ControllerOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "cert",
+		Short:   "Runs the certificate controller to warn about expiring TLS certificates",
+		Aliases: []string{"certs", "certificate", "certificates"},
+		Long:    controllerCertLong,
+		Example: controllerCertExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().IntVarP(&options.PollTime, "poll-time", "", 3600, "The number of seconds between certificate checks")
+	cmd.Flags().IntVarP(&options.ExpiryDays, "expiry-days", "", 14, "The number of days before expiry a certificate is warned about")
+	cmd.Flags().BoolVarP(&options.RunOnce, "run-once", "", false, "Check the certificates once and exit instead of polling; exits with an error when any certificate is unhealthy")
+	return cmd
+}
+
+// Run implements this command
+func (o *ControllerCertOptions) Run() error {
+	for {
+		err := o.checkCertificates()
+		if err != nil {
+			o.warnf("Failed to check the certificates: %s\n", err)
+		}
+		if o.RunOnce {
+			return err
+		}
+		time.Sleep(time.Duration(o.PollTime) * time.Second)
+	}
+}
+
+// checkCertificates warns about each certificate which has expired, is about to
+// expire or could not be fetched
+func (o *ControllerCertOptions) checkCertificates() error {
+	summaries, err := o.certificateSummaries(o.ExpiryDays)
+	if err != nil {
+		return err
+	}
+	unhealthy := 0
+	for _, s := range summaries {
+		if s.Status == "Valid" {
+			continue
+		}
+		unhealthy++
+		if s.Expires != "" {
+			o.warnf("The certificate of %s in namespace %s is %s: it expires at %s and was issued by %s\n",
+				util.ColorInfo(s.Host), s.Namespace, strings.ToLower(s.Status), s.Expires, s.Issuer)
+		} else {
+			o.warnf("The certificate of %s in namespace %s could not be fetched: %s. The renewal may have failed\n",
+				util.ColorInfo(s.Host), s.Namespace, s.Status)
+		}
+	}
+	if unhealthy > 0 {
+		return fmt.Errorf("%d certificate(s) are unhealthy", unhealthy)
+	}
+	o.Printf("All %s certificate(s) are valid\n", util.ColorInfo(fmt.Sprintf("%d", len(summaries))))
+	return nil
+}
@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// sizeLabels are the size labels from smallest to largest with the maximum
+// number of changed lines for each
+var sizeLabels = []struct {
+	Name     string
+	MaxLines int
+}{
+	{"size/XS", 10},
+	{"size/S", 50},
+	{"size/M", 250},
+	{"size/L", 1000},
+	{"size/XL", 0},
+}
+
+// kindLabels maps conventional commit prefixes of PR titles to kind labels
+var kindLabels = map[string]string{
+	"feat":     "kind/feature",
+	"fix":      "kind/bug",
+	"bug":      "kind/bug",
+	"chore":    "kind/chore",
+	"docs":     "kind/docs",
+	"refactor": "kind/refactor",
+	"test":     "kind/test",
+}
+
+// ControllerLabelsOptions contains the command line flags
+type ControllerLabelsOptions struct {
+	ControllerOptions
+
+	URL      string
+	Config   string
+	PollTime int
+	RunOnce  bool
+}
+
+var (
+	controllerLabelsLong = templates.LongDesc(`
+		Runs the Pull Request labelling controller for a repository.
+
+		Open Pull Requests are labelled by size from the number of changed
+		lines, by kind from the conventional commit prefix of the title and by
+		area from the path mappings in the labels configuration file, so that
+		reviewers can triage Pull Requests at a glance.
+
+		This currently only works against GitHub repositories.
+`)
+
+	controllerLabelsExample = templates.Examples(`
+		# Label the open Pull Requests of a repository
+		jx controller labels https://github.com/jstrachan/myapp.git
+`)
+)
+
+// NewCmdControllerLabels creates the command
+func NewCmdControllerLabels(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ControllerLabelsOptions{
+		ControllerOptions: ControllerOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "labels [url]",
+		Short:   "Runs the Pull Request labelling controller for a repository",
+		Long:    controllerLabelsLong,
+		Example: controllerLabelsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.URL, "url", "u", "", "The git URL of the repository to label Pull Requests for")
+	cmd.Flags().StringVarP(&options.Config, "config", "c", config.DefaultLabelsFileName, "The labels configuration file with the area path mappings")
+	cmd.Flags().IntVarP(&options.PollTime, "poll-time", "", 60, "The number of seconds between polls of the open Pull Requests")
+	cmd.Flags().BoolVarP(&options.RunOnce, "run-once", "", false, "Label the Pull Requests once and exit instead of polling")
+	return cmd
+}
+
+// Run implements this command
+func (o *ControllerLabelsOptions) Run() error {
+	url := o.URL
+	if url == "" && len(o.Args) > 0 {
+		url = o.Args[0]
+	}
+	if url == "" {
+		return util.MissingOption("url")
+	}
+	labelsConfig, err := config.LoadLabelsConfig(o.Config)
+	if err != nil {
+		return err
+	}
+	gitInfo, err := gits.ParseGitURL(url)
+	if err != nil {
+		return err
+	}
+	provider, err := o.gitProviderForURL(url, "user name to label Pull Requests")
+	if err != nil {
+		return err
+	}
+	ghProvider, ok := provider.(*gits.GitHubProvider)
+	if !ok {
+		return fmt.Errorf("Pull Request labelling currently only supports GitHub repositories")
+	}
+	for {
+		err = o.labelPullRequests(ghProvider, gitInfo, labelsConfig)
+		if err != nil {
+			o.warnf("Failed to label the Pull Requests: %s\n", err)
+		}
+		if o.RunOnce {
+			return err
+		}
+		time.Sleep(time.Duration(o.PollTime) * time.Second)
+	}
+}
+
+// labelPullRequests applies the size, kind and area labels to the open Pull Requests
+func (o *ControllerLabelsOptions) labelPullRequests(provider *gits.GitHubProvider, gitInfo *gits.GitRepositoryInfo, labelsConfig *config.LabelsConfig) error {
+	prs, err := provider.ListOpenPullRequests(gitInfo.Organisation, gitInfo.Name)
+	if err != nil {
+		return err
+	}
+	for _, pr := range prs {
+		files, err := provider.ListPullRequestFiles(pr)
+		if err != nil {
+			o.warnf("Failed to list the files of PR #%s: %s\n", formatInt(pr.Number), err)
+			continue
+		}
+		desired := []string{sizeLabel(files)}
+		if kind := kindLabel(pr.Title); kind != "" {
+			desired = append(desired, kind)
+		}
+		desired = append(desired, areaLabels(labelsConfig, files)...)
+
+		missing := []string{}
+		for _, label := range desired {
+			if util.StringArrayIndex(pr.Labels, label) < 0 {
+				missing = append(missing, label)
+			}
+		}
+		for _, label := range pr.Labels {
+			if strings.HasPrefix(label, "size/") && util.StringArrayIndex(desired, label) < 0 {
+				err = provider.RemovePRLabel(pr, label)
+				if err != nil {
+					o.warnf("%s\n", err)
+				}
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		err = provider.AddPRLabels(pr, missing)
+		if err != nil {
+			o.warnf("%s\n", err)
+			continue
+		}
+		o.Printf("Labelled PR #%s with %s\n", util.ColorInfo(formatInt(pr.Number)),
+			util.ColorInfo(strings.Join(missing, " ")))
+	}
+	return nil
+}
+
+// sizeLabel returns the size label for the number of changed lines
+func sizeLabel(files []gits.GitPullRequestFile) string {
+	lines := 0
+	for _, file := range files {
+		lines += file.Additions + file.Deletions
+	}
+	for _, size := range sizeLabels {
+		if size.MaxLines == 0 || lines < size.MaxLines {
+			return size.Name
+		}
+	}
+	return sizeLabels[len(sizeLabels)-1].Name
+}
+
+// kindLabel returns the kind label for the conventional commit prefix of the
+// title or an empty string if the title has no known prefix
+func kindLabel(title string) string {
+	prefix := title
+	if idx := strings.IndexAny(title, ":(!"); idx >= 0 {
+		prefix = title[:idx]
+	}
+	return kindLabels[strings.ToLower(strings.TrimSpace(prefix))]
+}
+
+// areaLabels returns the area labels whose paths match any of the changed files
+func areaLabels(labelsConfig *config.LabelsConfig, files []gits.GitPullRequestFile) []string {
+	answer := []string{}
+	for _, area := range labelsConfig.Areas {
+		for _, file := range files {
+			if matchesAnyPathFilter(area.Paths, file.Filename) {
+				answer = append(answer, area.Name)
+				break
+			}
+		}
+	}
+	return answer
+}
@@ -0,0 +1,336 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// defaultMergeQueueLabel is the label which marks a Pull Request as ready to
+// be picked up by the merge queue
+const defaultMergeQueueLabel = "ready-to-merge"
+
+// ControllerMergeQueueOptions contains the command line flags
+type ControllerMergeQueueOptions struct {
+	ControllerOptions
+
+	URL            string
+	Label          string
+	BatchSize      int
+	PollTime       int
+	Timeout        int
+	RunOnce        bool
+	UpdateBranches bool
+}
+
+var (
+	controllerMergeQueueLong = templates.LongDesc(`
+		Runs the merge queue controller for a repository.
+
+		Open Pull Requests labelled as ready to merge are queued in number order.
+		The controller merges the head pull requests of the queue into a
+		temporary batch branch so that they are tested together against the tip
+		of the target branch, waits for the commit status of the batch to go
+		green and then merges the pull requests automatically. Pull requests
+		which conflict with the batch are dropped from it with a warning and
+		stay in the queue.
+
+		This currently only works against GitHub repositories.
+`)
+
+	controllerMergeQueueExample = templates.Examples(`
+		# Run the merge queue controller for a repository
+		jx controller mergequeue https://github.com/jstrachan/myapp.git
+
+		# Process the queue once and exit
+		jx controller mergequeue https://github.com/jstrachan/myapp.git --run-once
+`)
+)
+
+// NewCmdControllerMergeQueue creates the command
+func NewCmdControllerMergeQueue(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ControllerMergeQueueOptions{
+		ControllerOptions: ControllerOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "mergequeue [url]",
+		Short:   "Runs the merge queue controller for a repository",
+		Aliases: []string{"mq"},
+		Long:    controllerMergeQueueLong,
+		Example: controllerMergeQueueExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.URL, "url", "u", "", "The git URL of the repository to run the merge queue for")
+	cmd.Flags().StringVarP(&options.Label, "label", "l", defaultMergeQueueLabel, "The label which queues a Pull Request for merging")
+	cmd.Flags().IntVarP(&options.BatchSize, "batch-size", "", 4, "The maximum number of Pull Requests to test and merge together")
+	cmd.Flags().IntVarP(&options.PollTime, "poll-time", "", 60, "The number of seconds between polls of the queue")
+	cmd.Flags().IntVarP(&options.Timeout, "timeout", "", 60, "The number of minutes to wait for the batch build to complete")
+	cmd.Flags().BoolVarP(&options.RunOnce, "run-once", "", false, "Process the queue once and exit instead of polling")
+	cmd.Flags().BoolVarP(&options.UpdateBranches, "update-branches", "", false, "Keep open Pull Requests up to date by merging their base branch into them when it moves on")
+	return cmd
+}
+
+// Run implements this command
+func (o *ControllerMergeQueueOptions) Run() error {
+	url := o.URL
+	if url == "" && len(o.Args) > 0 {
+		url = o.Args[0]
+	}
+	if url == "" {
+		return util.MissingOption("url")
+	}
+	gitInfo, err := gits.ParseGitURL(url)
+	if err != nil {
+		return err
+	}
+	provider, err := o.gitProviderForURL(url, "user name to access the merge queue")
+	if err != nil {
+		return err
+	}
+	ghProvider, ok := provider.(*gits.GitHubProvider)
+	if !ok {
+		return fmt.Errorf("The merge queue currently only supports GitHub repositories")
+	}
+	for {
+		if o.UpdateBranches {
+			err = o.updatePullRequestBranches(ghProvider, gitInfo, url)
+			if err != nil {
+				o.warnf("Failed to update the open Pull Request branches: %s\n", err)
+			}
+		}
+		err = o.processQueue(ghProvider, gitInfo, url)
+		if err != nil {
+			o.warnf("Failed to process the merge queue: %s\n", err)
+		}
+		if o.RunOnce {
+			return err
+		}
+		time.Sleep(time.Duration(o.PollTime) * time.Second)
+	}
+}
+
+// processQueue runs one iteration of the merge queue: batch the head of the
+// queue, test the batch and merge it when green
+func (o *ControllerMergeQueueOptions) processQueue(provider *gits.GitHubProvider, gitInfo *gits.GitRepositoryInfo, url string) error {
+	queue, err := mergeQueuePullRequests(provider, gitInfo.Organisation, gitInfo.Name, o.Label)
+	if err != nil {
+		return err
+	}
+	if len(queue) == 0 {
+		o.Printf("The merge queue of %s is empty\n", util.ColorInfo(gitInfo.HttpURL()))
+		return nil
+	}
+	batch := queue
+	if len(batch) > o.BatchSize {
+		batch = batch[:o.BatchSize]
+	}
+	numbers := []string{}
+	for _, pr := range batch {
+		numbers = append(numbers, "#"+formatInt(pr.Number))
+	}
+	o.Printf("Testing a batch of %s Pull Request(s) together: %s\n",
+		util.ColorInfo(fmt.Sprintf("%d", len(batch))), util.ColorInfo(strings.Join(numbers, " ")))
+
+	dir, err := ioutil.TempDir("", "jx-mergequeue-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	err = gits.GitClone(url, dir)
+	if err != nil {
+		return fmt.Errorf("Failed to clone %s due to: %s", url, err)
+	}
+	branchName := fmt.Sprintf("merge-queue-%d", time.Now().Unix())
+	err = gits.GitCmd(dir, "checkout", "-b", branchName)
+	if err != nil {
+		return err
+	}
+	merged := []*gits.GitPullRequest{}
+	for _, pr := range batch {
+		err = gits.GitCmd(dir, "fetch", "origin", fmt.Sprintf("pull/%s/head", formatInt(pr.Number)))
+		if err == nil {
+			err = gits.GitCmd(dir, "merge", "--no-edit", "FETCH_HEAD")
+		}
+		if err != nil {
+			gits.GitCmd(dir, "merge", "--abort")
+			o.warnf("Dropping PR #%s from the batch as it does not merge cleanly: %s\n", formatInt(pr.Number), err)
+			continue
+		}
+		merged = append(merged, pr)
+	}
+	if len(merged) == 0 {
+		return fmt.Errorf("None of the queued Pull Requests merge cleanly")
+	}
+	err = gits.GitCmd(dir, "push", "origin", branchName)
+	if err != nil {
+		return fmt.Errorf("Failed to push the batch branch %s due to: %s", branchName, err)
+	}
+	defer gits.GitCmd(dir, "push", "origin", ":"+branchName)
+
+	sha, err := util.GetCommandOutput(dir, "git", "rev-parse", "HEAD")
+	if err != nil {
+		return err
+	}
+	sha = strings.TrimSpace(sha)
+	err = o.waitForBatchToBeGreen(provider, gitInfo, sha)
+	if err != nil {
+		return err
+	}
+	for _, pr := range merged {
+		err = provider.MergePullRequest(pr, "merged by the jx merge queue")
+		if err != nil {
+			return fmt.Errorf("Failed to merge PR #%s due to: %s", formatInt(pr.Number), err)
+		}
+		o.Printf("Merged PR #%s: %s\n", util.ColorInfo(formatInt(pr.Number)), pr.Title)
+	}
+	return nil
+}
+
+// updatePullRequestBranches merges the base branch into any open Pull Request
+// which has fallen behind it and pushes the result, so that required
+// up-to-date branch protection does not strand the Pull Requests. Pull
+// Requests from forks are skipped as we cannot push to them
+func (o *ControllerMergeQueueOptions) updatePullRequestBranches(provider *gits.GitHubProvider, gitInfo *gits.GitRepositoryInfo, url string) error {
+	prs, err := provider.ListOpenPullRequests(gitInfo.Organisation, gitInfo.Name)
+	if err != nil {
+		return err
+	}
+	if len(prs) == 0 {
+		return nil
+	}
+	dir, err := ioutil.TempDir("", "jx-update-branches-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	err = gits.GitClone(url, dir)
+	if err != nil {
+		return fmt.Errorf("Failed to clone %s due to: %s", url, err)
+	}
+	for _, pr := range prs {
+		if pr.HeadRef == "" || pr.BaseRef == "" {
+			continue
+		}
+		if pr.HeadOwner != gitInfo.Organisation {
+			o.Printf("Skipping PR #%s as it comes from the fork of %s\n", formatInt(pr.Number), pr.HeadOwner)
+			continue
+		}
+		err = gits.GitCmd(dir, "fetch", "origin", pr.HeadRef)
+		if err != nil {
+			o.warnf("Failed to fetch the branch %s: %s\n", pr.HeadRef, err)
+			continue
+		}
+		behind, err := util.GetCommandOutput(dir, "git", "rev-list", "--count",
+			"origin/"+pr.HeadRef+"..origin/"+pr.BaseRef)
+		if err != nil {
+			o.warnf("Failed to compare the branch %s with %s: %s\n", pr.HeadRef, pr.BaseRef, err)
+			continue
+		}
+		if strings.TrimSpace(behind) == "0" {
+			continue
+		}
+		o.Printf("Updating PR #%s as its branch %s is behind %s\n",
+			util.ColorInfo(formatInt(pr.Number)), util.ColorInfo(pr.HeadRef), util.ColorInfo(pr.BaseRef))
+		err = gits.GitCmd(dir, "checkout", "-B", pr.HeadRef, "origin/"+pr.HeadRef)
+		if err == nil {
+			err = gits.GitCmd(dir, "merge", "--no-edit", "origin/"+pr.BaseRef)
+		}
+		if err != nil {
+			gits.GitCmd(dir, "merge", "--abort")
+			o.warnf("Could not update PR #%s as the merge failed: %s\n", formatInt(pr.Number), err)
+			continue
+		}
+		err = gits.GitCmd(dir, "push", "origin", pr.HeadRef)
+		if err != nil {
+			o.warnf("Failed to push the updated branch %s: %s\n", pr.HeadRef, err)
+		}
+	}
+	return nil
+}
+
+// waitForBatchToBeGreen polls the commit status of the batch branch until it
+// succeeds, fails or the timeout is reached
+func (o *ControllerMergeQueueOptions) waitForBatchToBeGreen(provider *gits.GitHubProvider, gitInfo *gits.GitRepositoryInfo, sha string) error {
+	end := time.Now().Add(time.Duration(o.Timeout) * time.Minute)
+	for {
+		statuses, err := provider.ListCommitStatus(gitInfo.Organisation, gitInfo.Name, sha)
+		if err != nil {
+			o.warnf("Failed to query the commit status of %s: %s\n", sha, err)
+		} else {
+			pending := len(statuses) == 0
+			for _, status := range statuses {
+				switch status.State {
+				case "failure", "error":
+					return fmt.Errorf("The batch build failed: %s", status.TargetURL)
+				case "pending":
+					pending = true
+				}
+			}
+			if !pending {
+				return nil
+			}
+		}
+		if time.Now().After(end) {
+			return fmt.Errorf("Timed out waiting for the batch build of %s to complete", sha)
+		}
+		time.Sleep(30 * time.Second)
+	}
+}
+
+// mergeQueuePullRequests returns the open Pull Requests queued for merging in
+// number order
+func mergeQueuePullRequests(provider *gits.GitHubProvider, owner string, repo string, label string) ([]*gits.GitPullRequest, error) {
+	answer := []*gits.GitPullRequest{}
+	prs, err := provider.ListOpenPullRequests(owner, repo)
+	if err != nil {
+		return answer, err
+	}
+	for _, pr := range prs {
+		if util.StringArrayIndex(pr.Labels, label) >= 0 {
+			answer = append(answer, pr)
+		}
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return asInt(answer[i].Number) < asInt(answer[j].Number)
+	})
+	return answer, nil
+}
+
+// formatInt renders an optional number for display
+func formatInt(number *int) string {
+	if number == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d", *number)
+}
+
+// asInt dereferences an optional number defaulting to zero
+func asInt(number *int) int {
+	if number == nil {
+		return 0
+	}
+	return *number
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// ControllerReviewersOptions contains the command line flags
+type ControllerReviewersOptions struct {
+	ControllerOptions
+
+	URL          string
+	Reviewers    []string
+	NumReviewers int
+	PollTime     int
+	RunOnce      bool
+
+	nextReviewer int
+}
+
+var (
+	controllerReviewersLong = templates.LongDesc(`
+		Runs the reviewer assignment controller for a repository.
+
+		Open Pull Requests which have no requested reviewers yet get reviewers
+		assigned round-robin from the list of candidates, skipping the author of
+		the Pull Request, so that reviews do not depend on someone noticing the
+		Pull Request by chance.
+
+		This currently only works against GitHub repositories.
+`)
+
+	controllerReviewersExample = templates.Examples(`
+		# Assign reviewers round-robin on the open Pull Requests of a repository
+		jx controller reviewers https://github.com/jstrachan/myapp.git --reviewer rawlingsj --reviewer jstrachan
+`)
+)
+
+// NewCmdControllerReviewers creates the command
+func NewCmdControllerReviewers(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ControllerReviewersOptions{
+		ControllerOptions: ControllerOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "reviewers [url]",
+		Short:   "Runs the reviewer assignment controller for a repository",
+		Long:    controllerReviewersLong,
+		Example: controllerReviewersExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.URL, "url", "u", "", "The git URL of the repository to assign reviewers for")
+	cmd.Flags().StringArrayVarP(&options.Reviewers, "reviewer", "r", []string{}, "The candidate reviewers to assign round-robin. Defaults to the owners from the team import settings")
+	cmd.Flags().IntVarP(&options.NumReviewers, "num-reviewers", "", 1, "The number of reviewers to assign to each Pull Request")
+	cmd.Flags().IntVarP(&options.PollTime, "poll-time", "", 60, "The number of seconds between polls of the open Pull Requests")
+	cmd.Flags().BoolVarP(&options.RunOnce, "run-once", "", false, "Assign reviewers once and exit instead of polling")
+	return cmd
+}
+
+// Run implements this command
+func (o *ControllerReviewersOptions) Run() error {
+	url := o.URL
+	if url == "" && len(o.Args) > 0 {
+		url = o.Args[0]
+	}
+	if url == "" {
+		return util.MissingOption("url")
+	}
+	if len(o.Reviewers) == 0 {
+		o.Reviewers = o.teamOwners()
+	}
+	if len(o.Reviewers) == 0 {
+		return util.MissingOption("reviewer")
+	}
+	gitInfo, err := gits.ParseGitURL(url)
+	if err != nil {
+		return err
+	}
+	provider, err := o.gitProviderForURL(url, "user name to assign reviewers")
+	if err != nil {
+		return err
+	}
+	ghProvider, ok := provider.(*gits.GitHubProvider)
+	if !ok {
+		return fmt.Errorf("Reviewer assignment currently only supports GitHub repositories")
+	}
+	for {
+		err = o.assignReviewers(ghProvider, gitInfo)
+		if err != nil {
+			o.warnf("Failed to assign reviewers: %s\n", err)
+		}
+		if o.RunOnce {
+			return err
+		}
+		time.Sleep(time.Duration(o.PollTime) * time.Second)
+	}
+}
+
+// assignReviewers requests reviews on any open Pull Request which has none yet
+func (o *ControllerReviewersOptions) assignReviewers(provider *gits.GitHubProvider, gitInfo *gits.GitRepositoryInfo) error {
+	prs, err := provider.ListOpenPullRequests(gitInfo.Organisation, gitInfo.Name)
+	if err != nil {
+		return err
+	}
+	for _, pr := range prs {
+		requested, err := provider.ListRequestedReviewers(pr)
+		if err != nil {
+			o.warnf("Failed to list the requested reviewers of PR #%s: %s\n", formatInt(pr.Number), err)
+			continue
+		}
+		if len(requested) > 0 {
+			continue
+		}
+		reviewers := o.pickReviewers(pr.Author)
+		if len(reviewers) == 0 {
+			continue
+		}
+		err = provider.RequestReviewers(pr, reviewers)
+		if err != nil {
+			o.warnf("%s\n", err)
+			continue
+		}
+		o.Printf("Requested a review of PR #%s from %s\n",
+			util.ColorInfo(formatInt(pr.Number)), util.ColorInfo(fmt.Sprintf("%v", reviewers)))
+	}
+	return nil
+}
+
+// pickReviewers returns the next reviewers round-robin skipping the author
+func (o *ControllerReviewersOptions) pickReviewers(author string) []string {
+	answer := []string{}
+	for i := 0; i < len(o.Reviewers) && len(answer) < o.NumReviewers; i++ {
+		reviewer := o.Reviewers[o.nextReviewer%len(o.Reviewers)]
+		o.nextReviewer++
+		if reviewer != author {
+			answer = append(answer, reviewer)
+		}
+	}
+	return answer
+}
+
+// teamOwners returns the default owners from the team import settings
+func (o *ControllerReviewersOptions) teamOwners() []string {
+	jxClient, ns, err := o.JXClient()
+	if err != nil {
+		return nil
+	}
+	env, err := jxClient.JenkinsV1().Environments(ns).Get(kube.LabelValueDevEnvironment, metav1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+	answer := []string{}
+	for _, owner := range env.Spec.TeamSettings.ImportDefaults.Owners {
+		answer = append(answer, strings.TrimPrefix(owner, "@"))
+	}
+	return answer
+}
@@ -28,10 +28,12 @@ type CreateProjectOptions struct {
 var (
 	create_resources = `Valid resource types include:
 
+	* api
 	* archetype
 	* cluster
 	* env
 	* git
+    * quickstart
     * spring (aka 'springboot')
     `
 
@@ -65,13 +67,20 @@ func NewCmdCreate(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Com
 	}
 
 	cmd.AddCommand(NewCmdCreateAddon(f, out, errOut))
+	cmd.AddCommand(NewCmdCreateApi(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateArchetype(f, out, errOut))
+	cmd.AddCommand(NewCmdCreateDocker(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateDocs(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateEnv(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateEtcHosts(f, out, errOut))
+	cmd.AddCommand(NewCmdCreateFreeze(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateGit(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateJenkins(f, out, errOut))
+	cmd.AddCommand(NewCmdCreateQuickstart(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateSpring(f, out, errOut))
+	cmd.AddCommand(NewCmdCreateTeam(f, out, errOut))
+	cmd.AddCommand(NewCmdCreateTracker(f, out, errOut))
+	cmd.AddCommand(NewCmdCreateTrain(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateCluster(f, out, errOut))
 	return cmd
 }
@@ -0,0 +1,374 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// apiLanguages are the languages the api command can scaffold a stub for
+var apiLanguages = []string{"go", "java"}
+
+// apiSpec is the subset of an OpenAPI document needed to scaffold a server stub
+type apiSpec struct {
+	Swagger string `yaml:"swagger"`
+	OpenAPI string `yaml:"openapi"`
+	Info    struct {
+		Title   string `yaml:"title"`
+		Version string `yaml:"version"`
+	} `yaml:"info"`
+	Paths map[string]map[string]interface{} `yaml:"paths"`
+}
+
+// apiOperation is one resolved operation of the OpenAPI document
+type apiOperation struct {
+	Method      string
+	Path        string
+	OperationID string
+	Summary     string
+}
+
+var (
+	createApiLong = templates.LongDesc(`
+		Creates a server stub from an OpenAPI specification and imports the
+		generated code into git and Jenkins for CI / CD.
+
+		The specification is copied into the project so the stub can be
+		regenerated as the API evolves.
+`)
+
+	createApiExample = templates.Examples(`
+		# Create a Go server stub from a specification
+		jx create api --openapi spec.yaml --language go
+
+		# Create a Java server stub called orders
+		jx create api --openapi spec.yaml --language java --name orders
+	`)
+)
+
+// CreateApiOptions the options for the create api command
+type CreateApiOptions struct {
+	CreateProjectOptions
+
+	OpenApiFile string
+	Language    string
+}
+
+// NewCmdCreateApi creates a command object for the "create api" command
+func NewCmdCreateApi(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateApiOptions{
+		CreateProjectOptions: CreateProjectOptions{
+			ImportOptions: ImportOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "api",
+		Short:   "Create a server stub from an OpenAPI specification and import it into git and Jenkins for CI / CD",
+		Long:    createApiLong,
+		Example: createApiExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	options.addCreateAppFlags(cmd)
+
+	cmd.Flags().StringVarP(&options.OpenApiFile, "openapi", "", "", "The OpenAPI specification file to generate the server stub from")
+	cmd.Flags().StringVarP(&options.Language, "language", "l", "go", "The language of the generated stub: "+strings.Join(apiLanguages, "|"))
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateApiOptions) Run() error {
+	if o.OpenApiFile == "" {
+		return util.MissingOption("openapi")
+	}
+	if util.StringArrayIndex(apiLanguages, o.Language) < 0 {
+		return util.InvalidOption("language", o.Language, apiLanguages)
+	}
+	data, err := ioutil.ReadFile(o.OpenApiFile)
+	if err != nil {
+		return fmt.Errorf("Failed to read the OpenAPI specification %s due to: %s", o.OpenApiFile, err)
+	}
+	spec := &apiSpec{}
+	err = yaml.Unmarshal(data, spec)
+	if err != nil {
+		return fmt.Errorf("Failed to parse the OpenAPI specification %s due to: %s", o.OpenApiFile, err)
+	}
+	if spec.Swagger == "" && spec.OpenAPI == "" {
+		return fmt.Errorf("The file %s does not look like an OpenAPI specification: no swagger or openapi version found", o.OpenApiFile)
+	}
+	operations := specOperations(spec)
+	if len(operations) == 0 {
+		return fmt.Errorf("The specification %s declares no operations", o.OpenApiFile)
+	}
+	appName := o.Repository
+	if appName == "" {
+		appName = kebabCase(spec.Info.Title)
+	}
+	if appName == "" {
+		return util.MissingOption("name")
+	}
+	dir := o.OutDir
+	if dir == "" {
+		dir, err = os.Getwd()
+		if err != nil {
+			return err
+		}
+	}
+	outDir := filepath.Join(dir, appName)
+	exists, err := util.FileExists(outDir)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("The directory %s already exists", outDir)
+	}
+	files := map[string]string{
+		"openapi.yaml": string(data),
+	}
+	switch o.Language {
+	case "java":
+		addJavaApiStub(files, appName, operations)
+	default:
+		addGoApiStub(files, appName, operations)
+	}
+	err = writeProjectFiles(outDir, files)
+	if err != nil {
+		return err
+	}
+	o.Printf("Created a %s server stub with %s operation(s) at %s\n",
+		util.ColorInfo(o.Language), util.ColorInfo(fmt.Sprintf("%d", len(operations))), util.ColorInfo(outDir))
+
+	return o.ImportCreatedProject(outDir)
+}
+
+// specOperations flattens the paths of the specification into operations in a
+// stable order, skipping path level keys such as parameters
+func specOperations(spec *apiSpec) []apiOperation {
+	methods := []string{"get", "put", "post", "delete", "options", "head", "patch"}
+	paths := []string{}
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	answer := []apiOperation{}
+	for _, path := range paths {
+		for _, method := range methods {
+			value, ok := spec.Paths[path][method]
+			if !ok {
+				continue
+			}
+			op := apiOperation{
+				Method: method,
+				Path:   path,
+			}
+			if m, ok := value.(map[interface{}]interface{}); ok {
+				if s, ok := m["operationId"].(string); ok {
+					op.OperationID = s
+				}
+				if s, ok := m["summary"].(string); ok {
+					op.Summary = s
+				}
+			}
+			if op.OperationID == "" {
+				op.OperationID = method + strings.Title(kebabToCamel(kebabCase(path)))
+			}
+			answer = append(answer, op)
+		}
+	}
+	return answer
+}
+
+// addGoApiStub adds the Go server stub files
+func addGoApiStub(files map[string]string, appName string, operations []apiOperation) {
+	buffer := &bytes.Buffer{}
+	fmt.Fprintf(buffer, `package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+)
+
+`)
+	for _, op := range operations {
+		if op.Summary != "" {
+			fmt.Fprintf(buffer, "// %s %s\n", handlerName(&op), op.Summary)
+		}
+		fmt.Fprintf(buffer, `func %s(w http.ResponseWriter, r *http.Request) {
+	// TODO implement %s %s
+	http.Error(w, "not implemented", http.StatusNotImplemented)
+}
+
+`, handlerName(&op), strings.ToUpper(op.Method), op.Path)
+	}
+	fmt.Fprintf(buffer, `func main() {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+`)
+	registered := map[string]bool{}
+	for _, op := range operations {
+		if registered[op.Path] {
+			continue
+		}
+		registered[op.Path] = true
+		fmt.Fprintf(buffer, "\thttp.HandleFunc(%q, %s)\n", op.Path, handlerName(&op))
+	}
+	fmt.Fprintf(buffer, `	fmt.Println("%s listening on port " + port)
+	http.ListenAndServe(":"+port, nil)
+}
+`, appName)
+	files["main.go"] = buffer.String()
+	files["Gopkg.toml"] = fmt.Sprintf("# dependencies of %s\n", appName)
+}
+
+// addJavaApiStub adds the Spring Boot server stub files
+func addJavaApiStub(files map[string]string, appName string, operations []apiOperation) {
+	files["pom.xml"] = fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:schemaLocation="http://maven.apache.org/POM/4.0.0 http://maven.apache.org/xsd/maven-4.0.0.xsd">
+  <modelVersion>4.0.0</modelVersion>
+  <groupId>com.example</groupId>
+  <artifactId>%s</artifactId>
+  <version>0.0.1-SNAPSHOT</version>
+  <parent>
+    <groupId>org.springframework.boot</groupId>
+    <artifactId>spring-boot-starter-parent</artifactId>
+    <version>1.5.9.RELEASE</version>
+  </parent>
+  <dependencies>
+    <dependency>
+      <groupId>org.springframework.boot</groupId>
+      <artifactId>spring-boot-starter-web</artifactId>
+    </dependency>
+  </dependencies>
+  <build>
+    <plugins>
+      <plugin>
+        <groupId>org.springframework.boot</groupId>
+        <artifactId>spring-boot-maven-plugin</artifactId>
+      </plugin>
+    </plugins>
+  </build>
+</project>
+`, appName)
+	files["src/main/java/com/example/Application.java"] = `package com.example;
+
+import org.springframework.boot.SpringApplication;
+import org.springframework.boot.autoconfigure.SpringBootApplication;
+
+@SpringBootApplication
+public class Application {
+
+    public static void main(String[] args) {
+        SpringApplication.run(Application.class, args);
+    }
+}
+`
+	buffer := &bytes.Buffer{}
+	fmt.Fprintf(buffer, `package com.example;
+
+import org.springframework.http.HttpStatus;
+import org.springframework.http.ResponseEntity;
+import org.springframework.web.bind.annotation.RequestMapping;
+import org.springframework.web.bind.annotation.RequestMethod;
+import org.springframework.web.bind.annotation.RestController;
+
+@RestController
+public class ApiController {
+`)
+	for _, op := range operations {
+		if op.Summary != "" {
+			fmt.Fprintf(buffer, "\n    // %s\n", op.Summary)
+		} else {
+			fmt.Fprintf(buffer, "\n")
+		}
+		fmt.Fprintf(buffer, `    @RequestMapping(value = "%s", method = RequestMethod.%s)
+    public ResponseEntity<String> %s() {
+        // TODO implement %s %s
+        return new ResponseEntity<>(HttpStatus.NOT_IMPLEMENTED);
+    }
+`, op.Path, strings.ToUpper(op.Method), handlerName(&op), strings.ToUpper(op.Method), op.Path)
+	}
+	fmt.Fprintf(buffer, "}\n")
+	files["src/main/java/com/example/ApiController.java"] = buffer.String()
+}
+
+// handlerName returns the function name of the operation stub
+func handlerName(op *apiOperation) string {
+	return kebabToCamel(kebabCase(op.OperationID))
+}
+
+// writeProjectFiles writes the scaffolded files into the output directory
+func writeProjectFiles(outDir string, files map[string]string) error {
+	names := []string{}
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fileName := filepath.Join(outDir, filepath.FromSlash(name))
+		err := os.MkdirAll(filepath.Dir(fileName), DefaultWritePermissions)
+		if err != nil {
+			return err
+		}
+		err = ioutil.WriteFile(fileName, []byte(files[name]), DefaultWritePermissions)
+		if err != nil {
+			return fmt.Errorf("Failed to write %s due to %s", fileName, err)
+		}
+	}
+	return nil
+}
+
+// kebabCase lowercases the text replacing any non alphanumeric runs with a dash
+func kebabCase(text string) string {
+	buffer := &bytes.Buffer{}
+	lastDash := true
+	for _, c := range strings.ToLower(text) {
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') {
+			buffer.WriteRune(c)
+			lastDash = false
+		} else if !lastDash {
+			buffer.WriteRune('-')
+			lastDash = true
+		}
+	}
+	return strings.Trim(buffer.String(), "-")
+}
+
+// kebabToCamel converts kebab-case text to camelCase
+func kebabToCamel(text string) string {
+	parts := strings.Split(text, "-")
+	answer := ""
+	for i, part := range parts {
+		if i == 0 {
+			answer += part
+		} else {
+			answer += strings.Title(part)
+		}
+	}
+	return answer
+}
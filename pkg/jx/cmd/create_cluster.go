@@ -42,7 +42,7 @@ const (
 	JX_INFRA = "jx-infra"
 )
 
-var KUBERNETES_PROVIDERS = []string{MINIKUBE, GKE, AKS, JX_INFRA}
+var KUBERNETES_PROVIDERS = []string{MINIKUBE, GKE, EKS, AKS, JX_INFRA}
 
 const (
 	stableKubeCtlVersionURL = "https://storage.googleapis.com/kubernetes-release/release/stable.txt"
@@ -51,9 +51,8 @@ const (
 
     * minikube (single-node Kubernetes cluster inside a VM on your laptop)
     * gke (Google Container Engine - https://cloud.google.com/kubernetes-engine)
+    * eks (Amazon Elastic Container Service - https://aws.amazon.com/eks)
     * aks (Azure Container Service - https://docs.microsoft.com/en-us/azure/aks)
-    * coming soon:
-        eks (Amazon Elastic Container Service - https://aws.amazon.com/eks)
     `
 )
 
@@ -105,6 +104,7 @@ func NewCmdCreateCluster(f cmdutil.Factory, out io.Writer, errOut io.Writer) *co
 
 	cmd.AddCommand(NewCmdCreateClusterMinikube(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateClusterGKE(f, out, errOut))
+	cmd.AddCommand(NewCmdCreateClusterEKS(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateClusterAKS(f, out, errOut))
 
 	return cmd
@@ -145,6 +145,9 @@ func (o *CreateClusterOptions) initAndInstall(provider string) error {
 	if initOpts.Flags.Domain != "" && o.InstallOptions.Flags.Domain == "" {
 		o.InstallOptions.Flags.Domain = initOpts.Flags.Domain
 	}
+	if o.InstallOptions.Flags.IPFamily == "" {
+		o.InstallOptions.Flags.IPFamily = initOpts.Flags.IPFamily
+	}
 	o.InstallOptions.BatchMode = o.BatchMode
 	o.InstallOptions.Flags.Provider = provider
 
@@ -257,6 +260,10 @@ func (o *CreateClusterOptions) doInstallMissingDependencies(install []string) er
 			err = o.installMinikube()
 		case "az":
 			err = o.installAzureCli()
+		case "eksctl":
+			err = o.installEksCtl()
+		case "heptio-authenticator-aws":
+			err = o.installHeptioAuthenticatorAws()
 		default:
 			return fmt.Errorf("unknown dependency to install %s\n", i)
 		}
@@ -572,6 +579,69 @@ func (o *CreateClusterOptions) installAzureCli() error {
 	return o.runCommand("brew", "install", "azure-cli")
 }
 
+func (o *CreateClusterOptions) installEksCtl() error {
+	if runtime.GOOS == "darwin" && !o.NoBrew {
+		err := o.runCommand("brew", "tap", "weaveworks/tap")
+		if err != nil {
+			return err
+		}
+		return o.runCommand("brew", "install", "weaveworks/tap/eksctl")
+	}
+
+	binDir, err := util.BinaryLocation()
+	if err != nil {
+		return err
+	}
+	binary := "eksctl"
+	fileName, flag, err := o.shouldInstallBinary(binDir, binary)
+	if err != nil || !flag {
+		return err
+	}
+	latestVersion, err := util.GetLatestVersionFromGitHub("weaveworks", "eksctl")
+	if err != nil {
+		return err
+	}
+	clientURL := fmt.Sprintf("https://github.com/weaveworks/eksctl/releases/download/%s/eksctl_%s_%s.tar.gz", latestVersion, strings.Title(runtime.GOOS), runtime.GOARCH)
+	fullPath := filepath.Join(binDir, fileName)
+	tarFile := fullPath + ".tgz"
+	err = o.downloadFile(clientURL, tarFile)
+	if err != nil {
+		return err
+	}
+	err = util.UnTargz(tarFile, binDir, []string{binary, fileName})
+	if err != nil {
+		return err
+	}
+	err = os.Remove(tarFile)
+	if err != nil {
+		return err
+	}
+	return os.Chmod(fullPath, 0755)
+}
+
+func (o *CreateClusterOptions) installHeptioAuthenticatorAws() error {
+	binDir, err := util.BinaryLocation()
+	if err != nil {
+		return err
+	}
+	fileName, flag, err := o.shouldInstallBinary(binDir, "heptio-authenticator-aws")
+	if err != nil || !flag {
+		return err
+	}
+	clientURL := fmt.Sprintf("https://amazon-eks.s3-us-west-2.amazonaws.com/1.10.3/2018-06-05/bin/%s/%s/heptio-authenticator-aws", runtime.GOOS, runtime.GOARCH)
+	fullPath := filepath.Join(binDir, fileName)
+	tmpFile := fullPath + ".tmp"
+	err = o.downloadFile(clientURL, tmpFile)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmpFile, fullPath)
+	if err != nil {
+		return err
+	}
+	return os.Chmod(fullPath, 0755)
+}
+
 func (o *CommonOptions) GetCloudProvider(p string) (string, error) {
 	if p == "" {
 		// lets detect minikube
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Pallinder/go-randomdata"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/log"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1"
+)
+
+// CreateClusterEKSOptions the flags for running create cluster eks
+type CreateClusterEKSOptions struct {
+	CreateClusterOptions
+
+	Flags CreateClusterEKSFlags
+}
+
+type CreateClusterEKSFlags struct {
+	ClusterName  string
+	Region       string
+	NodeCount    string
+	NodeType     string
+	SSHPublicKey string
+}
+
+var (
+	createClusterEKSLong = templates.LongDesc(`
+		This command creates a new kubernetes cluster on Amazon EKS, installing required local dependencies and provisions
+		the Jenkins X platform
+
+		Amazon Elastic Container Service for Kubernetes (Amazon EKS) is a managed service that makes it easy for you to
+		run Kubernetes on AWS without needing to install and operate your own Kubernetes control plane.
+
+		The cluster is created with eksctl so your AWS credentials need to be configured, for example via
+		'aws configure' or the standard AWS environment variables.
+
+`)
+
+	createClusterEKSExample = templates.Examples(`
+
+		jx create cluster eks
+
+`)
+)
+
+// NewCmdCreateClusterEKS creates the command object for creating a cluster on Amazon EKS
+func NewCmdCreateClusterEKS(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := CreateClusterEKSOptions{
+		CreateClusterOptions: createCreateClusterOptions(f, out, errOut, EKS),
+	}
+	cmd := &cobra.Command{
+		Use:     "eks",
+		Short:   "Create a new kubernetes cluster on Amazon EKS: Runs on AWS",
+		Long:    createClusterEKSLong,
+		Example: createClusterEKSExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	options.addCreateClusterFlags(cmd)
+
+	cmd.Flags().StringVarP(&options.Flags.ClusterName, "cluster-name", "n", "", "The name of this cluster, default is a random generated name")
+	cmd.Flags().StringVarP(&options.Flags.Region, "region", "r", "", "The AWS region to create the cluster in (e.g. us-west-2)")
+	cmd.Flags().StringVarP(&options.Flags.NodeCount, "nodes", "o", "", "The number of worker nodes to create")
+	cmd.Flags().StringVarP(&options.Flags.NodeType, "node-type", "m", "", "The instance type of the worker nodes (e.g. m5.large)")
+	cmd.Flags().StringVarP(&options.Flags.SSHPublicKey, "ssh-public-key", "k", "", "The SSH public key to use for the worker nodes. Defaults to ~/.ssh/id_rsa.pub")
+	return cmd
+}
+
+func (o *CreateClusterEKSOptions) Run() error {
+
+	var deps []string
+	d := binaryShouldBeInstalled("eksctl")
+	if d != "" {
+		deps = append(deps, d)
+	}
+	d = binaryShouldBeInstalled("heptio-authenticator-aws")
+	if d != "" {
+		deps = append(deps, d)
+	}
+	err := o.installMissingDependencies(deps)
+	if err != nil {
+		log.Errorf("%v\nPlease fix the error or install manually then try again", err)
+		os.Exit(-1)
+	}
+
+	err = o.createClusterEKS()
+	if err != nil {
+		log.Errorf("error creating cluster %v", err)
+		os.Exit(-1)
+	}
+
+	return nil
+}
+
+func (o *CreateClusterEKSOptions) createClusterEKS() error {
+
+	clusterName := o.Flags.ClusterName
+	if clusterName == "" {
+		clusterName = strings.ToLower(randomdata.SillyName())
+		log.Infof("No cluster name provided so using a generated one: %s", clusterName)
+	}
+
+	region := o.Flags.Region
+	if region == "" {
+		prompt := &survey.Input{
+			Message: "region",
+			Default: "us-west-2",
+			Help:    "The AWS region to create the cluster in",
+		}
+		err := survey.AskOne(prompt, &region, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	nodeCount := o.Flags.NodeCount
+	if nodeCount == "" {
+		prompt := &survey.Input{
+			Message: "nodes",
+			Default: "3",
+			Help:    "number of worker nodes",
+		}
+		survey.AskOne(prompt, &nodeCount, nil)
+	}
+
+	// eksctl creates the cluster and writes the kube context
+	createCluster := []string{"create", "cluster", "--name", clusterName, "--region", region, "--nodes", nodeCount}
+
+	if o.Flags.NodeType != "" {
+		createCluster = append(createCluster, "--node-type", o.Flags.NodeType)
+	}
+	if o.Flags.SSHPublicKey != "" {
+		createCluster = append(createCluster, "--ssh-public-key", o.Flags.SSHPublicKey)
+	}
+
+	err := o.runCommand("eksctl", createCluster...)
+	if err != nil {
+		return err
+	}
+
+	return o.initAndInstall(EKS)
+}
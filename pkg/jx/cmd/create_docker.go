@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"io"
+
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// CreateDockerOptions the options for the create docker command
+type CreateDockerOptions struct {
+	CreateOptions
+}
+
+// NewCmdCreateDocker creates a command object for the "create docker" command
+func NewCmdCreateDocker(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateDockerOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "docker",
+		Short: "Creates a docker resource",
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.AddCommand(NewCmdCreateDockerAuth(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *CreateDockerOptions) Run() error {
+	return o.Cmd.Help()
+}
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/jenkins"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+var (
+	create_docker_auth_long = templates.LongDesc(`
+		Creates or updates the docker registry credentials used by pipelines.
+
+		The credentials are stored in the ` + kube.SecretJenkinsDockerConfig + ` secret,
+		registered as an image pull secret on the default service account of each
+		namespace and created as a Jenkins credential so that pipelines, pods and
+		Jenkins itself all share the same registry authentication.
+`)
+
+	create_docker_auth_example = templates.Examples(`
+		# Create the registry credentials in the current namespace
+		jx create docker auth --registry myregistry.io --username foo
+
+		# Create the registry credentials in several namespaces
+		jx create docker auth --registry myregistry.io --username foo --namespaces jx,jx-staging
+	`)
+)
+
+// CreateDockerAuthOptions the options for the create docker auth command
+type CreateDockerAuthOptions struct {
+	CreateOptions
+
+	Registry   string
+	Username   string
+	Password   string
+	Email      string
+	Namespaces []string
+}
+
+// NewCmdCreateDockerAuth creates a command object for the "create docker auth" command
+func NewCmdCreateDockerAuth(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateDockerAuthOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "auth",
+		Short:   "Creates or updates the docker registry credentials used by pipelines",
+		Long:    create_docker_auth_long,
+		Example: create_docker_auth_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Registry, "registry", "r", "", "The docker registry host such as myregistry.io")
+	cmd.Flags().StringVarP(&options.Username, "username", "u", "", "The user name to authenticate with the registry")
+	cmd.Flags().StringVarP(&options.Password, "password", "p", "", "The password or access token. If not specified you will be prompted")
+	cmd.Flags().StringVarP(&options.Email, "email", "e", "", "The email address associated with the registry account")
+	cmd.Flags().StringArrayVarP(&options.Namespaces, "namespaces", "n", []string{}, "The namespaces to create the secrets in. Defaults to the current namespace")
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateDockerAuthOptions) Run() error {
+	if o.Registry == "" {
+		return util.MissingOption("registry")
+	}
+	if o.Username == "" {
+		return util.MissingOption("username")
+	}
+	if o.Password == "" {
+		if o.BatchMode {
+			return util.MissingOption("password")
+		}
+		prompt := &survey.Password{
+			Message: fmt.Sprintf("Password or access token for %s on %s:", o.Username, o.Registry),
+		}
+		err := survey.AskOne(prompt, &o.Password, survey.Required)
+		if err != nil {
+			return err
+		}
+	}
+	client, currentNs, err := o.Factory.CreateClient()
+	if err != nil {
+		return err
+	}
+	namespaces := splitCommaArgs(o.Namespaces)
+	if len(namespaces) == 0 {
+		namespaces = []string{currentNs}
+	}
+
+	configJson, err := o.dockerConfigJson()
+	if err != nil {
+		return err
+	}
+	for _, ns := range namespaces {
+		err = o.upsertSecret(client, ns, kube.SecretJenkinsDockerConfig, corev1.SecretTypeOpaque, map[string][]byte{
+			"config.json": configJson,
+		})
+		if err != nil {
+			return err
+		}
+		err = o.upsertSecret(client, ns, kube.SecretDockerRegistryPull, corev1.SecretTypeDockerConfigJson, map[string][]byte{
+			corev1.DockerConfigJsonKey: configJson,
+		})
+		if err != nil {
+			return err
+		}
+		err = o.registerImagePullSecret(client, ns, kube.SecretDockerRegistryPull)
+		if err != nil {
+			return err
+		}
+		o.Printf("Created the registry secrets in namespace %s\n", util.ColorInfo(ns))
+	}
+
+	err = o.createJenkinsCredential()
+	if err != nil {
+		return err
+	}
+	o.Printf("Docker registry credentials for %s configured for user %s\n", util.ColorInfo(o.Registry), util.ColorInfo(o.Username))
+	return nil
+}
+
+// dockerConfigJson returns the docker config.json content for the registry credentials
+func (o *CreateDockerAuthOptions) dockerConfigJson() ([]byte, error) {
+	auth := base64.StdEncoding.EncodeToString([]byte(o.Username + ":" + o.Password))
+	entry := map[string]string{
+		"auth": auth,
+	}
+	if o.Email != "" {
+		entry["email"] = o.Email
+	}
+	config := map[string]interface{}{
+		"auths": map[string]interface{}{
+			o.Registry: entry,
+		},
+	}
+	return json.Marshal(config)
+}
+
+func (o *CreateDockerAuthOptions) upsertSecret(client *kubernetes.Clientset, ns string, name string, secretType corev1.SecretType, data map[string][]byte) error {
+	secrets := client.CoreV1().Secrets(ns)
+	secret, err := secrets.Get(name, metav1.GetOptions{})
+	if err == nil {
+		secret.Type = secretType
+		secret.Data = data
+		_, err = secrets.Update(secret)
+		if err != nil {
+			return fmt.Errorf("Failed to update secret %s in namespace %s due to: %s", name, ns, err)
+		}
+		return nil
+	}
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Type: secretType,
+		Data: data,
+	}
+	_, err = secrets.Create(secret)
+	if err != nil {
+		return fmt.Errorf("Failed to create secret %s in namespace %s due to: %s", name, ns, err)
+	}
+	return nil
+}
+
+// registerImagePullSecret adds the secret to the image pull secrets of the default
+// service account in the namespace if its not already registered
+func (o *CreateDockerAuthOptions) registerImagePullSecret(client *kubernetes.Clientset, ns string, secretName string) error {
+	serviceAccounts := client.CoreV1().ServiceAccounts(ns)
+	sa, err := serviceAccounts.Get("default", metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to find the default service account in namespace %s due to: %s", ns, err)
+	}
+	for _, ref := range sa.ImagePullSecrets {
+		if ref.Name == secretName {
+			return nil
+		}
+	}
+	sa.ImagePullSecrets = append(sa.ImagePullSecrets, corev1.LocalObjectReference{Name: secretName})
+	_, err = serviceAccounts.Update(sa)
+	if err != nil {
+		return fmt.Errorf("Failed to update the default service account in namespace %s due to: %s", ns, err)
+	}
+	return nil
+}
+
+// createJenkinsCredential creates or updates the registry credential in Jenkins
+func (o *CreateDockerAuthOptions) createJenkinsCredential() error {
+	jenkinsClient, err := o.JenkinsClient()
+	if err != nil {
+		o.warnf("Could not connect to Jenkins to create the registry credential: %s\n", err)
+		return nil
+	}
+	name := jenkins.DefaultJenkinsCredentialsPrefix + "docker"
+	_, err = jenkinsClient.GetCredential(name)
+	if err == nil {
+		return nil
+	}
+	err = jenkinsClient.CreateCredential(name, o.Username, o.Password)
+	if err != nil {
+		return fmt.Errorf("Failed to create the jenkins credential %s due to: %s", name, err)
+	}
+	o.Printf("Created Jenkins credential %s\n", util.ColorInfo(name))
+	return nil
+}
+
+func splitCommaArgs(values []string) []string {
+	answer := []string{}
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			if v != "" {
+				answer = append(answer, v)
+			}
+		}
+	}
+	return answer
+}
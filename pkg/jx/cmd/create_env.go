@@ -165,7 +165,7 @@ func (o *CreateEnvOptions) Run() error {
 			}
 			gitProvider = p
 		}
-		return jenkins.ImportProject(o.Out, jenkinClient, gitURL, jenkins.DefaultJenkinsfile, o.EnvJobCredentials, false, gitProvider, authConfigSvc)
+		return jenkins.ImportProject(o.Out, jenkinClient, gitURL, "", jenkins.DefaultJenkinsfile, o.EnvJobCredentials, false, jenkins.JobExistsSkip, o.BatchMode, gitProvider, authConfigSvc, nil)
 	}
 	return nil
 }
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CreateFreezeOptions the options for the create freeze command
+type CreateFreezeOptions struct {
+	CreateOptions
+
+	Environments []string
+	From         string
+	To           string
+	Description  string
+}
+
+var (
+	create_freeze_long = templates.LongDesc(`
+		Creates a freeze window during which promotions to the matching environments
+		are blocked.
+
+		While a window is active 'jx promote' and automatic promotions to the frozen
+		environments fail unless the --override-freeze flag is used; overrides are
+		recorded against the window as an audit trail.
+
+		Times are specified as dates (2006-01-02) or full timestamps (RFC3339).
+		Omitting --from starts the freeze immediately; omitting --to freezes until
+		the window is deleted.
+`)
+
+	create_freeze_example = templates.Examples(`
+		# Freeze production over a holiday weekend
+		jx create freeze xmas -e production --from 2018-12-24 --to 2018-12-27 -d "Christmas change freeze"
+
+		# Freeze every environment immediately until the window is deleted
+		jx create freeze incident -d "SEV1 incident in progress"
+	`)
+)
+
+// NewCmdCreateFreeze creates the command
+func NewCmdCreateFreeze(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateFreezeOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "freeze",
+		Short:   "Create a freeze window blocking promotions to environments",
+		Long:    create_freeze_long,
+		Example: create_freeze_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&options.Environments, "env", "e", []string{}, "An environment the freeze applies to. Can be specified multiple times; defaults to all environments")
+	cmd.Flags().StringVarP(&options.From, "from", "", "", "When the freeze starts. Defaults to now")
+	cmd.Flags().StringVarP(&options.To, "to", "", "", "When the freeze ends. Defaults to never")
+	cmd.Flags().StringVarP(&options.Description, "description", "d", "", "Why the freeze is in place")
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateFreezeOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("Missing the name of the freeze window. Usage: jx create freeze <name>")
+	}
+	name := o.Args[0]
+	from, err := parseFreezeTime(o.From, "from")
+	if err != nil {
+		return err
+	}
+	to, err := parseFreezeTime(o.To, "to")
+	if err != nil {
+		return err
+	}
+	if !from.IsZero() && !to.IsZero() && to.Time.Before(from.Time) {
+		return fmt.Errorf("The freeze window ends at %s before it starts at %s", o.To, o.From)
+	}
+
+	jxClient, ns, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	env, err := jxClient.JenkinsV1().Environments(ns).Get(kube.LabelValueDevEnvironment, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to load the team settings from the %s environment due to: %s", kube.LabelValueDevEnvironment, err)
+	}
+	settings := &env.Spec.TeamSettings
+	window := findFreezeWindow(settings, name)
+	if window == nil {
+		settings.FreezeWindows = append(settings.FreezeWindows, v1.FreezeWindow{Name: name})
+		window = &settings.FreezeWindows[len(settings.FreezeWindows)-1]
+	}
+	window.Environments = o.Environments
+	window.From = from
+	window.To = to
+	window.Description = o.Description
+
+	_, err = jxClient.JenkinsV1().Environments(ns).Update(env)
+	if err != nil {
+		return fmt.Errorf("Failed to save the team settings due to: %s", err)
+	}
+	envText := "all environments"
+	if len(o.Environments) > 0 {
+		envText = util.ColorInfo(o.Environments)
+	}
+	o.Printf("Created freeze window %s blocking promotions to %s\n", util.ColorInfo(name), envText)
+	return nil
+}
+
+// parseFreezeTime parses a freeze window boundary which can be a date or a
+// full RFC3339 timestamp. An empty value returns the zero time
+func parseFreezeTime(text string, option string) (metav1.Time, error) {
+	if text == "" {
+		return metav1.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, text)
+	if err != nil {
+		t, err = time.Parse("2006-01-02", text)
+		if err != nil {
+			return metav1.Time{}, util.InvalidOption(option, text, []string{"2006-01-02", time.RFC3339})
+		}
+	}
+	return metav1.NewTime(t), nil
+}
+
+// findFreezeWindow returns the freeze window with the given name or nil
+func findFreezeWindow(settings *v1.TeamSettings, name string) *v1.FreezeWindow {
+	for i := range settings.FreezeWindows {
+		if settings.FreezeWindows[i].Name == name {
+			return &settings.FreezeWindows[i]
+		}
+	}
+	return nil
+}
@@ -4,10 +4,14 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var (
@@ -69,6 +73,9 @@ func (o *CreateGitServerOptions) Run() error {
 		return missingArguments()
 	}
 	kind := args[0]
+	if util.StringArrayIndex(gits.GitProviderKinds(), kind) < 0 {
+		return util.InvalidArg(kind, gits.GitProviderKinds())
+	}
 	name := o.Name
 	if name == "" {
 		name = kind
@@ -103,9 +110,51 @@ func (o *CreateGitServerOptions) Run() error {
 		return err
 	}
 	o.Printf("Added git server %s for URL %s\n", util.ColorInfo(name), util.ColorInfo(gitUrl))
+
+	err = o.saveGitServiceCRD(name, kind, gitUrl)
+	if err != nil {
+		o.warnf("Could not create the GitService resource in the cluster: %s\n", err)
+	}
 	return nil
 }
 
+// saveGitServiceCRD creates or updates the in-cluster GitService resource for the server
+// so that in-cluster tooling can discover the kind of git provider for a URL
+func (o *CreateGitServerOptions) saveGitServiceCRD(name string, kind string, gitUrl string) error {
+	apisClient, err := o.Factory.CreateApiExtensionsClient()
+	if err != nil {
+		return err
+	}
+	err = kube.RegisterGitServiceCRD(apisClient)
+	if err != nil {
+		return err
+	}
+	jxClient, ns, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	gitServices := jxClient.JenkinsV1().GitServices(ns)
+	gitService, err := gitServices.Get(name, metav1.GetOptions{})
+	if err == nil {
+		gitService.Spec.GitKind = kind
+		gitService.Spec.URL = gitUrl
+		_, err = gitServices.Update(gitService)
+		return err
+	}
+	gitService = &v1.GitService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: v1.GitServiceSpec{
+			Name:    name,
+			GitKind: kind,
+			URL:     gitUrl,
+		},
+	}
+	_, err = gitServices.Create(gitService)
+	return err
+}
+
 func missingArguments() error {
 	return fmt.Errorf("Missing git server URL arguments. Usage: jx create git server kind [url]")
 }
@@ -0,0 +1,315 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// quickstart is a curated project template which can be scaffolded and
+// imported in one step
+type quickstart struct {
+	Name        string
+	Language    string
+	Description string
+
+	// Files are the scaffolded files of the project by relative path
+	Files map[string]string
+}
+
+// quickstarts are the curated templates in display order
+var quickstarts = []quickstart{
+	{
+		Name:        "spring-boot",
+		Language:    "java",
+		Description: "Spring Boot web application built with maven",
+		Files: map[string]string{
+			"pom.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:schemaLocation="http://maven.apache.org/POM/4.0.0 http://maven.apache.org/xsd/maven-4.0.0.xsd">
+  <modelVersion>4.0.0</modelVersion>
+  <groupId>com.example</groupId>
+  <artifactId>%s</artifactId>
+  <version>0.0.1-SNAPSHOT</version>
+  <parent>
+    <groupId>org.springframework.boot</groupId>
+    <artifactId>spring-boot-starter-parent</artifactId>
+    <version>1.5.9.RELEASE</version>
+  </parent>
+  <dependencies>
+    <dependency>
+      <groupId>org.springframework.boot</groupId>
+      <artifactId>spring-boot-starter-web</artifactId>
+    </dependency>
+  </dependencies>
+  <build>
+    <plugins>
+      <plugin>
+        <groupId>org.springframework.boot</groupId>
+        <artifactId>spring-boot-maven-plugin</artifactId>
+      </plugin>
+    </plugins>
+  </build>
+</project>
+`,
+			"src/main/java/com/example/Application.java": `package com.example;
+
+import org.springframework.boot.SpringApplication;
+import org.springframework.boot.autoconfigure.SpringBootApplication;
+import org.springframework.web.bind.annotation.RequestMapping;
+import org.springframework.web.bind.annotation.RestController;
+
+@SpringBootApplication
+@RestController
+public class Application {
+
+    @RequestMapping("/")
+    public String home() {
+        return "Hello from %s!";
+    }
+
+    public static void main(String[] args) {
+        SpringApplication.run(Application.class, args);
+    }
+}
+`,
+		},
+	},
+	{
+		Name:        "node",
+		Language:    "nodejs",
+		Description: "Node.js web application using express",
+		Files: map[string]string{
+			"package.json": `{
+  "name": "%s",
+  "version": "0.0.1",
+  "main": "server.js",
+  "scripts": {
+    "start": "node server.js"
+  },
+  "dependencies": {
+    "express": "^4.16.0"
+  }
+}
+`,
+			"server.js": `const express = require('express');
+const app = express();
+const port = process.env.PORT || 8080;
+
+app.get('/', (req, res) => res.send('Hello from %s!'));
+
+app.listen(port, () => console.log('listening on port ' + port));
+`,
+		},
+	},
+	{
+		Name:        "golang",
+		Language:    "go",
+		Description: "Go HTTP server",
+		Files: map[string]string{
+			"main.go": `package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+)
+
+func main() {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "Hello from %s!")
+	})
+	http.ListenAndServe(":"+port, nil)
+}
+`,
+			"Gopkg.toml": `# dependencies of %s
+`,
+		},
+	},
+	{
+		Name:        "python",
+		Language:    "python",
+		Description: "Python web application using flask",
+		Files: map[string]string{
+			"requirements.txt": `flask
+`,
+			"app.py": `import os
+from flask import Flask
+
+app = Flask(__name__)
+
+
+@app.route('/')
+def home():
+    return 'Hello from %s!'
+
+
+if __name__ == '__main__':
+    app.run(host='0.0.0.0', port=int(os.environ.get('PORT', 8080)))
+`,
+		},
+	},
+}
+
+var (
+	createQuickstartLong = templates.LongDesc(`
+		Creates a new project from a curated quickstart template and imports
+		the generated code into git and Jenkins for CI / CD.
+`)
+
+	createQuickstartExample = templates.Examples(`
+		# Pick a quickstart interactively
+		jx create quickstart
+
+		# Create a Go project called myapp
+		jx create quickstart golang --name myapp
+	`)
+)
+
+// CreateQuickstartOptions the options for the create quickstart command
+type CreateQuickstartOptions struct {
+	CreateProjectOptions
+}
+
+// NewCmdCreateQuickstart creates a command object for the "create quickstart" command
+func NewCmdCreateQuickstart(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateQuickstartOptions{
+		CreateProjectOptions: CreateProjectOptions{
+			ImportOptions: ImportOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "quickstart [name]",
+		Short:   "Create a new project from a quickstart template and import it into git and Jenkins for CI / CD",
+		Aliases: []string{"quickstarts", "qs"},
+		Long:    createQuickstartLong,
+		Example: createQuickstartExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	options.addCreateAppFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateQuickstartOptions) Run() error {
+	name := ""
+	if len(o.Args) > 0 {
+		name = o.Args[0]
+	}
+	names := quickstartNames()
+	if name == "" {
+		if o.BatchMode {
+			return fmt.Errorf("Please specify the quickstart to use: %s", strings.Join(names, ", "))
+		}
+		prompt := &survey.Select{
+			Message: "Which quickstart do you want to create?",
+			Options: names,
+		}
+		err := survey.AskOne(prompt, &name, survey.Required)
+		if err != nil {
+			return err
+		}
+	}
+	q := findQuickstart(name)
+	if q == nil {
+		return util.InvalidArg(name, names)
+	}
+	appName := o.Repository
+	if appName == "" {
+		appName = q.Name
+	}
+	dir := o.OutDir
+	if dir == "" {
+		var err error
+		dir, err = os.Getwd()
+		if err != nil {
+			return err
+		}
+	}
+	outDir := filepath.Join(dir, appName)
+	err := q.scaffold(outDir, appName)
+	if err != nil {
+		return err
+	}
+	o.Printf("Created %s project at %s\n", util.ColorInfo(q.Name), util.ColorInfo(outDir))
+
+	return o.ImportCreatedProject(outDir)
+}
+
+// scaffold writes the files of the quickstart into the output directory
+// substituting the application name
+func (q *quickstart) scaffold(outDir string, appName string) error {
+	exists, err := util.FileExists(outDir)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("The directory %s already exists", outDir)
+	}
+	names := []string{}
+	for name := range q.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fileName := filepath.Join(outDir, filepath.FromSlash(name))
+		err = os.MkdirAll(filepath.Dir(fileName), DefaultWritePermissions)
+		if err != nil {
+			return err
+		}
+		text := q.Files[name]
+		if strings.Contains(text, "%s") {
+			text = fmt.Sprintf(text, appName)
+		}
+		err = ioutil.WriteFile(fileName, []byte(text), DefaultWritePermissions)
+		if err != nil {
+			return fmt.Errorf("Failed to write %s due to %s", fileName, err)
+		}
+	}
+	return nil
+}
+
+// quickstartNames returns the names of the curated quickstarts
+func quickstartNames() []string {
+	names := []string{}
+	for _, q := range quickstarts {
+		names = append(names, q.Name)
+	}
+	return names
+}
+
+// findQuickstart returns the quickstart with the given name or nil
+func findQuickstart(name string) *quickstart {
+	for i := range quickstarts {
+		if quickstarts[i].Name == name {
+			return &quickstarts[i]
+		}
+	}
+	return nil
+}
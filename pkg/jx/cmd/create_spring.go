@@ -27,6 +27,9 @@ var (
 
 		# Creates a Spring Boot application passing in the required dependencies
 		jx create spring -d web -d actuator
+
+		# Creates a Spring Boot application in batch mode without any prompts
+		jx create spring -b -g com.example -a myapp -d web
 	`)
 )
 
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+var (
+	create_team_long = templates.LongDesc(`
+		Creates a new team in the current cluster.
+
+		A team gets its own namespace, a dev Environment with the team settings,
+		default staging and production Environments and the RBAC required for the
+		pipelines to deploy into them. By default a dedicated Jenkins master is
+		provisioned for the team; use --shared-jenkins to register the team with
+		the shared master instead.
+`)
+
+	create_team_example = templates.Examples(`
+		# Create a new team with its own Jenkins master
+		jx create team myteam
+
+		# Create a new team using the shared Jenkins master
+		jx create team myteam --shared-jenkins
+	`)
+)
+
+// CreateTeamOptions the options for the create team command
+type CreateTeamOptions struct {
+	CreateOptions
+
+	SharedJenkins bool
+}
+
+// NewCmdCreateTeam creates a command object for the "create team" command
+func NewCmdCreateTeam(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateTeamOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "team",
+		Short:   "Create a new team with its own namespace, environments and Jenkins master",
+		Long:    create_team_long,
+		Example: create_team_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().BoolVarP(&options.SharedJenkins, "shared-jenkins", "", false, "Register the team with the shared Jenkins master instead of provisioning a dedicated one")
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateTeamOptions) Run() error {
+	name := ""
+	args := o.Args
+	if len(args) > 0 {
+		name = args[0]
+	} else if !o.BatchMode {
+		prompt := &survey.Input{
+			Message: "Team name: ",
+		}
+		err := survey.AskOne(prompt, &name, nil)
+		if err != nil {
+			return err
+		}
+	}
+	if name == "" {
+		return fmt.Errorf("No team name specified!")
+	}
+	name = kube.ToValidName(name)
+
+	client, _, err := o.Factory.CreateClient()
+	if err != nil {
+		return err
+	}
+	jxClient, _, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	apisClient, err := o.Factory.CreateApiExtensionsClient()
+	if err != nil {
+		return err
+	}
+	kube.RegisterEnvironmentCRD(apisClient)
+
+	labels := map[string]string{
+		kube.LabelTeam:        name,
+		kube.LabelEnvironment: kube.LabelValueDevEnvironment,
+	}
+	err = kube.EnsureNamespaceCreated(client, name, labels, nil)
+	if err != nil {
+		return err
+	}
+	_, err = kube.EnsureDevEnvironmentSetup(jxClient, name)
+	if err != nil {
+		return err
+	}
+	err = o.createTeamEnvironments(jxClient, client, name)
+	if err != nil {
+		return err
+	}
+	err = o.createTeamRBAC(client, name)
+	if err != nil {
+		return err
+	}
+	if o.SharedJenkins {
+		o.Printf("Registered team %s with the shared Jenkins master\n", util.ColorInfo(name))
+	} else {
+		o.Printf("Installing a Jenkins master for team %s\n", util.ColorInfo(name))
+		err = o.runCommand("helm", "install", "--name", name, "--namespace", name, "jenkins-x/jenkins-x-platform")
+		if err != nil {
+			return fmt.Errorf("Failed to install the Jenkins master for team %s due to: %s", name, err)
+		}
+	}
+	o.Printf("Team %s created\n", util.ColorInfo(name))
+	return nil
+}
+
+// createTeamEnvironments creates the default staging and production Environments
+// for the team along with their namespaces
+func (o *CreateTeamOptions) createTeamEnvironments(jxClient *versioned.Clientset, client *kubernetes.Clientset, team string) error {
+	environments := []v1.Environment{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "staging",
+			},
+			Spec: v1.EnvironmentSpec{
+				Namespace:         team + "-staging",
+				Label:             "Staging",
+				Order:             100,
+				PromotionStrategy: v1.PromotionStrategyTypeAutomatic,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "production",
+			},
+			Spec: v1.EnvironmentSpec{
+				Namespace:         team + "-production",
+				Label:             "Production",
+				Order:             200,
+				PromotionStrategy: v1.PromotionStrategyTypeManual,
+			},
+		},
+	}
+	for i := range environments {
+		env := &environments[i]
+		_, err := jxClient.JenkinsV1().Environments(team).Get(env.Name, metav1.GetOptions{})
+		if err == nil {
+			continue
+		}
+		_, err = jxClient.JenkinsV1().Environments(team).Create(env)
+		if err != nil {
+			return fmt.Errorf("Failed to create the %s Environment for team %s due to: %s", env.Name, team, err)
+		}
+		err = kube.EnsureEnvironmentNamespaceSetup(client, jxClient, env, team)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createTeamRBAC creates the jenkins ServiceAccount for the team and binds the
+// admin cluster role to it in the team namespace
+func (o *CreateTeamOptions) createTeamRBAC(client *kubernetes.Clientset, team string) error {
+	serviceAccounts := client.CoreV1().ServiceAccounts(team)
+	_, err := serviceAccounts.Get(kube.ServiceJenkins, metav1.GetOptions{})
+	if err != nil {
+		sa := &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: kube.ServiceJenkins,
+			},
+		}
+		_, err = serviceAccounts.Create(sa)
+		if err != nil {
+			return fmt.Errorf("Failed to create the %s ServiceAccount for team %s due to: %s", kube.ServiceJenkins, team, err)
+		}
+	}
+	roleBindings := client.RbacV1().RoleBindings(team)
+	bindingName := team + "-admin"
+	_, err = roleBindings.Get(bindingName, metav1.GetOptions{})
+	if err != nil {
+		binding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: bindingName,
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					Kind:      rbacv1.ServiceAccountKind,
+					Name:      kube.ServiceJenkins,
+					Namespace: team,
+				},
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     "admin",
+			},
+		}
+		_, err = roleBindings.Create(binding)
+		if err != nil {
+			return fmt.Errorf("Failed to create the %s RoleBinding for team %s due to: %s", bindingName, team, err)
+		}
+	}
+	return nil
+}
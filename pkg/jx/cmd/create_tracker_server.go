@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	create_tracker_server_long = templates.LongDesc(`
+		Adds a new issue tracker server URL
+
+		Issue tracker servers are used by the changelog, issue and promotion commands
+		so that issues can live in a different server to the source code.
+`)
+
+	create_tracker_server_example = templates.Examples(`
+		# Add a new issue tracker server URL
+		jx create tracker server jira https://mycompany.atlassian.net
+	`)
+
+	// trackerKinds the issue tracker kinds we support
+	trackerKinds = []string{"jira", "github", "gitlab"}
+)
+
+// CreateTrackerServerOptions the options for the create tracker server command
+type CreateTrackerServerOptions struct {
+	CreateOptions
+
+	Name string
+}
+
+// NewCmdCreateTrackerServer creates a command object for the "create tracker server" command
+func NewCmdCreateTrackerServer(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateTrackerServerOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "server kind [url]",
+		Short:   "Creates a new issue tracker server URL",
+		Aliases: []string{"provider"},
+		Long:    create_tracker_server_long,
+		Example: create_tracker_server_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Name, "name", "n", "", "The name for the issue tracker server being created")
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateTrackerServerOptions) Run() error {
+	args := o.Args
+	if len(args) < 2 {
+		return missingTrackerArguments()
+	}
+	kind := args[0]
+	if util.StringArrayIndex(trackerKinds, kind) < 0 {
+		return util.InvalidArg(kind, trackerKinds)
+	}
+	name := o.Name
+	if name == "" {
+		name = kind
+	}
+	trackerUrl := args[1]
+
+	authConfigSvc, err := o.Factory.CreateIssueTrackerAuthConfigService()
+	if err != nil {
+		return err
+	}
+	config := authConfigSvc.Config()
+	config.GetOrCreateServerName(trackerUrl, name, kind)
+	config.CurrentServer = trackerUrl
+	err = authConfigSvc.SaveConfig()
+	if err != nil {
+		return err
+	}
+	o.Printf("Added issue tracker server %s for URL %s\n", util.ColorInfo(name), util.ColorInfo(trackerUrl))
+	return nil
+}
+
+func missingTrackerArguments() error {
+	return fmt.Errorf("Missing tracker server URL arguments. Usage: jx create tracker server kind [url]")
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CreateTrainOptions the options for the create train command
+type CreateTrainOptions struct {
+	CreateOptions
+
+	Apps []string
+}
+
+var (
+	create_train_long = templates.LongDesc(`
+		Creates a release train: a named set of applications which are versioned and
+		promoted together.
+
+		The train definition is stored in the team settings so for GitOps teams it lives
+		in git along with the rest of the team configuration. The whole train is promoted
+		in one go via: jx promote --train <name>
+`)
+
+	create_train_example = templates.Examples(`
+		# Create a release train of two applications at pinned versions
+		jx create train payments-2024.06 -a payments-api=1.2.3 -a payments-ui=2.0.1
+
+		# Update the version of one application on an existing train
+		jx create train payments-2024.06 -a payments-api=1.2.4
+	`)
+)
+
+// NewCmdCreateTrain creates the command
+func NewCmdCreateTrain(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateTrainOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "train",
+		Short:   "Create a release train of applications promoted together",
+		Long:    create_train_long,
+		Example: create_train_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&options.Apps, "app", "a", []string{}, "An application on the train in the name=version form. Can be specified multiple times")
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateTrainOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("Missing the name of the release train. Usage: jx create train <name> -a app=version")
+	}
+	name := o.Args[0]
+	if len(o.Apps) == 0 {
+		return util.MissingOption("app")
+	}
+	apps := []v1.ReleaseTrainApp{}
+	for _, app := range o.Apps {
+		parts := strings.SplitN(app, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("Invalid app %s. Expected the name=version form", app)
+		}
+		apps = append(apps, v1.ReleaseTrainApp{
+			Name:    parts[0],
+			Version: parts[1],
+		})
+	}
+
+	jxClient, ns, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	env, err := jxClient.JenkinsV1().Environments(ns).Get(kube.LabelValueDevEnvironment, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to load the team settings from the %s environment due to: %s", kube.LabelValueDevEnvironment, err)
+	}
+	settings := &env.Spec.TeamSettings
+	train := findReleaseTrain(settings, name)
+	if train == nil {
+		settings.ReleaseTrains = append(settings.ReleaseTrains, v1.ReleaseTrain{Name: name})
+		train = &settings.ReleaseTrains[len(settings.ReleaseTrains)-1]
+	}
+	for _, app := range apps {
+		updated := false
+		for i := range train.Apps {
+			if train.Apps[i].Name == app.Name {
+				train.Apps[i].Version = app.Version
+				updated = true
+				break
+			}
+		}
+		if !updated {
+			train.Apps = append(train.Apps, app)
+		}
+	}
+	_, err = jxClient.JenkinsV1().Environments(ns).Update(env)
+	if err != nil {
+		return fmt.Errorf("Failed to save the team settings due to: %s", err)
+	}
+	o.Printf("Created release train %s with %s apps. Promote it via: %s\n", util.ColorInfo(name), util.ColorInfo(len(train.Apps)), util.ColorInfo("jx promote --train "+name))
+	return nil
+}
+
+// findReleaseTrain returns the release train with the given name or nil
+func findReleaseTrain(settings *v1.TeamSettings, name string) *v1.ReleaseTrain {
+	for i := range settings.ReleaseTrains {
+		if settings.ReleaseTrains[i].Name == name {
+			return &settings.ReleaseTrains[i]
+		}
+	}
+	return nil
+}
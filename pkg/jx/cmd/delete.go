@@ -52,10 +52,13 @@ func NewCmdDelete(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Com
 	}
 
 	cmd.AddCommand(NewCmdDeleteAddon(f, out, errOut))
+	cmd.AddCommand(NewCmdDeleteApp(f, out, errOut))
 	cmd.AddCommand(NewCmdDeleteEnv(f, out, errOut))
+	cmd.AddCommand(NewCmdDeleteFreeze(f, out, errOut))
 	cmd.AddCommand(NewCmdDeleteGit(f, out, errOut))
 	cmd.AddCommand(NewCmdDeleteJenkins(f, out, errOut))
 	cmd.AddCommand(NewCmdDeleteRepo(f, out, errOut))
+	cmd.AddCommand(NewCmdDeleteTeam(f, out, errOut))
 	return cmd
 }
 
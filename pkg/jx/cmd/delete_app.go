@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+var (
+	delete_app_long = templates.LongDesc(`
+		Deletes an application from an environment by removing its helm release.
+`)
+
+	delete_app_example = templates.Examples(`
+		# Delete an application from the staging environment
+		jx delete app foo --env staging
+	`)
+)
+
+// DeleteAppOptions the options for the delete app command
+type DeleteAppOptions struct {
+	CreateOptions
+
+	Environment string
+	Purge       bool
+}
+
+// NewCmdDeleteApp creates a command object for the "delete app" command
+func NewCmdDeleteApp(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &DeleteAppOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "app",
+		Short:   "Deletes an application from an environment",
+		Aliases: []string{"application"},
+		Long:    delete_app_long,
+		Example: delete_app_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Environment, "env", "e", "", "The environment containing the application")
+	cmd.Flags().BoolVarP(&options.Purge, "purge", "", false, "Also remove the helm release name so it can be reused")
+	return cmd
+}
+
+// Run implements the command
+func (o *DeleteAppOptions) Run() error {
+	args := o.Args
+	if len(args) == 0 {
+		return fmt.Errorf("Missing application name argument")
+	}
+	app := args[0]
+	if o.Environment == "" {
+		return util.MissingOption("env")
+	}
+	ns, err := o.environmentNamespace(o.Environment)
+	if err != nil {
+		return err
+	}
+	if !o.BatchMode {
+		flag := false
+		confirm := &survey.Confirm{
+			Message: fmt.Sprintf("Delete the application %s from environment %s?", app, o.Environment),
+			Default: false,
+		}
+		err = survey.AskOne(confirm, &flag, nil)
+		if err != nil {
+			return err
+		}
+		if !flag {
+			return nil
+		}
+	}
+	releaseName := ns + "-" + app
+	deleteArgs := []string{"delete"}
+	if o.Purge {
+		deleteArgs = append(deleteArgs, "--purge")
+	}
+	deleteArgs = append(deleteArgs, releaseName)
+	err = o.runCommand("helm", deleteArgs...)
+	if err != nil {
+		return fmt.Errorf("Failed to delete the helm release %s due to: %s", releaseName, err)
+	}
+	o.Printf("Deleted application %s from environment %s\n", util.ColorInfo(app), util.ColorInfo(o.Environment))
+	return nil
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeleteFreezeOptions the options for the delete freeze command
+type DeleteFreezeOptions struct {
+	CommonOptions
+}
+
+var (
+	delete_freeze_long = templates.LongDesc(`
+		Deletes a freeze window so that promotions to the environments it covered
+		are allowed again.
+`)
+
+	delete_freeze_example = templates.Examples(`
+		# Delete a freeze window
+		jx delete freeze xmas
+	`)
+)
+
+// NewCmdDeleteFreeze creates the command
+func NewCmdDeleteFreeze(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &DeleteFreezeOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "freeze",
+		Short:   "Deletes a freeze window",
+		Long:    delete_freeze_long,
+		Example: delete_freeze_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	return cmd
+}
+
+// Run implements the command
+func (o *DeleteFreezeOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("Missing the name of the freeze window. Usage: jx delete freeze <name>")
+	}
+	name := o.Args[0]
+
+	jxClient, ns, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	env, err := jxClient.JenkinsV1().Environments(ns).Get(kube.LabelValueDevEnvironment, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to load the team settings from the %s environment due to: %s", kube.LabelValueDevEnvironment, err)
+	}
+	settings := &env.Spec.TeamSettings
+	windows := []v1.FreezeWindow{}
+	names := []string{}
+	found := false
+	for _, window := range settings.FreezeWindows {
+		if window.Name == name {
+			found = true
+			continue
+		}
+		names = append(names, window.Name)
+		windows = append(windows, window)
+	}
+	if !found {
+		return util.InvalidArg(name, names)
+	}
+	settings.FreezeWindows = windows
+
+	_, err = jxClient.JenkinsV1().Environments(ns).Update(env)
+	if err != nil {
+		return fmt.Errorf("Failed to save the team settings due to: %s", err)
+	}
+	o.Printf("Deleted freeze window %s\n", util.ColorInfo(name))
+	return nil
+}
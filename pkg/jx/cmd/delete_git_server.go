@@ -8,6 +8,7 @@ import (
 	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"strings"
 )
 
@@ -83,5 +84,29 @@ func (o *DeleteGitServerOptions) Run() error {
 		return err
 	}
 	o.Printf("Deleted git servers: %s from local settings\n", util.ColorInfo(strings.Join(args, ", ")))
+
+	err = o.deleteGitServiceCRDs(args)
+	if err != nil {
+		o.warnf("Could not delete the GitService resources in the cluster: %s\n", err)
+	}
+	return nil
+}
+
+// deleteGitServiceCRDs removes any in-cluster GitService resources for the deleted servers
+func (o *DeleteGitServerOptions) deleteGitServiceCRDs(names []string) error {
+	jxClient, ns, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	gitServices := jxClient.JenkinsV1().GitServices(ns)
+	for _, name := range names {
+		_, err = gitServices.Get(name, metav1.GetOptions{})
+		if err == nil {
+			err = gitServices.Delete(name, &metav1.DeleteOptions{})
+			if err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
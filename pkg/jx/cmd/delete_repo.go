@@ -122,7 +122,7 @@ func (o *DeleteRepoOptions) Run() error {
 
 	names := o.Repositories
 	if len(names) == 0 {
-		repos, err := gits.PickRepositories(provider, org, "Which repositories do you want to delete:", o.SelectAll, o.SelectFilter)
+		repos, err := gits.PickRepositories(provider, org, "Which repositories do you want to delete:", o.BatchMode, o.SelectAll, o.SelectFilter)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	delete_team_long = templates.LongDesc(`
+		Deletes one or more teams and their namespaces.
+`)
+
+	delete_team_example = templates.Examples(`
+		# Delete a team
+		jx delete team myteam
+	`)
+)
+
+// DeleteTeamOptions the options for the delete team command
+type DeleteTeamOptions struct {
+	CreateOptions
+
+	Confirm bool
+}
+
+// NewCmdDeleteTeam creates a command object for the "delete team" command
+func NewCmdDeleteTeam(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &DeleteTeamOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "team",
+		Short:   "Deletes one or more teams and their namespaces",
+		Long:    delete_team_long,
+		Example: delete_team_example,
+		Aliases: []string{"teams"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().BoolVarP(&options.Confirm, "yes", "y", false, "Confirms deleting the teams without prompting")
+	return cmd
+}
+
+// Run implements the command
+func (o *DeleteTeamOptions) Run() error {
+	args := o.Args
+	if len(args) == 0 {
+		return fmt.Errorf("Missing team name argument")
+	}
+	client, _, err := o.Factory.CreateClient()
+	if err != nil {
+		return err
+	}
+	for _, name := range args {
+		ns, err := client.CoreV1().Namespaces().Get(name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("Failed to find the team %s due to: %s", name, err)
+		}
+		if ns.Labels[kube.LabelEnvironment] != kube.LabelValueDevEnvironment {
+			return fmt.Errorf("The namespace %s is not a team namespace", name)
+		}
+		if !o.Confirm && !o.BatchMode {
+			flag := false
+			confirm := &survey.Confirm{
+				Message: "Deleting the team " + name + " will delete its namespace and everything in it. Are you sure?",
+				Default: false,
+			}
+			err = survey.AskOne(confirm, &flag, nil)
+			if err != nil {
+				return err
+			}
+			if !flag {
+				continue
+			}
+		}
+		err = client.CoreV1().Namespaces().Delete(name, &metav1.DeleteOptions{})
+		if err != nil {
+			return fmt.Errorf("Failed to delete the team %s due to: %s", name, err)
+		}
+		o.Printf("Deleted team %s\n", util.ColorInfo(name))
+	}
+	return nil
+}
@@ -0,0 +1,335 @@
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// defaultRedactedFields the config fields whose values are always redacted
+// when collecting a support bundle
+var defaultRedactedFields = []string{"apitoken", "bearertoken", "password", "secret", "token"}
+
+// DiagnoseOptions the command line options
+type DiagnoseOptions struct {
+	CommonOptions
+
+	Collect    bool
+	OutputFile string
+	Redact     []string
+}
+
+var (
+	diagnose_long = templates.LongDesc(`
+		Diagnoses the health of the Jenkins X platform.
+
+		By default the status of the platform pods is displayed. The --collect
+		flag gathers a support bundle containing the platform logs, the
+		Environment and GitService resources, the Jenkins job configurations and
+		the local jx configuration into a tarball which can be attached to bug
+		reports. Secrets such as API tokens and passwords are redacted; further
+		fields can be redacted with the --redact flag.
+`)
+
+	diagnose_example = templates.Examples(`
+		# Show the status of the platform pods
+		jx diagnose
+
+		# Collect a support bundle for a bug report
+		jx diagnose --collect
+
+		# Collect a support bundle redacting an extra field from configuration files
+		jx diagnose --collect --redact email
+	`)
+)
+
+// NewCmdDiagnose creates the command
+func NewCmdDiagnose(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &DiagnoseOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "diagnose",
+		Short:   "Diagnoses the health of the Jenkins X platform",
+		Long:    diagnose_long,
+		Example: diagnose_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().BoolVarP(&options.Collect, "collect", "c", false, "Collect a support bundle tarball for attaching to bug reports")
+	cmd.Flags().StringVarP(&options.OutputFile, "output-file", "f", "", "The file to write the support bundle to. Defaults to jx-diagnose-<timestamp>.tar.gz")
+	cmd.Flags().StringArrayVarP(&options.Redact, "redact", "", []string{}, "Additional configuration field names to redact in the support bundle")
+	return cmd
+}
+
+// Run implements this command
+func (o *DiagnoseOptions) Run() error {
+	if o.Collect {
+		return o.collectBundle()
+	}
+	client, currentNs, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err := kube.GetDevNamespace(client, currentNs)
+	if err != nil {
+		return err
+	}
+	pods, err := client.CoreV1().Pods(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	table := o.CreateTable()
+	table.AddRow("POD", "STATUS")
+	unhealthy := 0
+	for _, pod := range pods.Items {
+		status := string(pod.Status.Phase)
+		if pod.Status.Phase != corev1.PodRunning && pod.Status.Phase != corev1.PodSucceeded {
+			status = util.ColorWarning(status)
+			unhealthy++
+		}
+		table.AddRow(pod.Name, status)
+	}
+	table.Render()
+	if unhealthy > 0 {
+		o.Printf("\n%d pod(s) are not running\n", unhealthy)
+	}
+	o.Printf("\nUse %s to gather a support bundle for a bug report\n", util.ColorInfo("jx diagnose --collect"))
+	return nil
+}
+
+// collectBundle gathers the platform logs, resources and configuration into a
+// tarball with secrets redacted
+func (o *DiagnoseOptions) collectBundle() error {
+	client, currentNs, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err := kube.GetDevNamespace(client, currentNs)
+	if err != nil {
+		return err
+	}
+	tmpDir, err := ioutil.TempDir("", "jx-diagnose-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	o.collectPodLogs(client, ns, tmpDir)
+	o.collectResources(ns, tmpDir)
+	o.collectJobConfigs(tmpDir)
+	o.collectConfigFiles(tmpDir)
+
+	outputFile := o.OutputFile
+	if outputFile == "" {
+		outputFile = fmt.Sprintf("jx-diagnose-%s.tar.gz", time.Now().Format("20060102-150405"))
+	}
+	err = createTarball(tmpDir, outputFile)
+	if err != nil {
+		return fmt.Errorf("Failed to create the support bundle %s due to: %s", outputFile, err)
+	}
+	o.Printf("Wrote the support bundle to %s\n", util.ColorInfo(outputFile))
+	o.Printf("Please review the contents before attaching it to a bug report\n")
+	return nil
+}
+
+// collectPodLogs saves the logs of each container of the platform pods
+func (o *DiagnoseOptions) collectPodLogs(client *kubernetes.Clientset, ns string, dir string) {
+	pods, err := client.CoreV1().Pods(ns).List(metav1.ListOptions{})
+	if err != nil {
+		o.warnf("Could not list the pods in namespace %s: %s\n", ns, err)
+		return
+	}
+	logDir := filepath.Join(dir, "logs")
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			data, err := client.CoreV1().Pods(ns).GetLogs(pod.Name, &corev1.PodLogOptions{Container: container.Name}).DoRaw()
+			if err != nil {
+				o.warnf("Could not fetch the log of pod %s container %s: %s\n", pod.Name, container.Name, err)
+				continue
+			}
+			o.saveBundleFile(filepath.Join(logDir, pod.Name+"-"+container.Name+".log"), o.redact(string(data)))
+		}
+	}
+}
+
+// collectResources saves the Environment and GitService resources of the team
+func (o *DiagnoseOptions) collectResources(ns string, dir string) {
+	jxClient, _, err := o.JXClient()
+	if err != nil {
+		o.warnf("Could not create the jx client: %s\n", err)
+		return
+	}
+	resourceDir := filepath.Join(dir, "resources")
+	envs, err := jxClient.JenkinsV1().Environments(ns).List(metav1.ListOptions{})
+	if err != nil {
+		o.warnf("Could not list the Environments: %s\n", err)
+	} else {
+		o.saveYaml(filepath.Join(resourceDir, "environments.yaml"), envs.Items)
+	}
+	gitServices, err := jxClient.JenkinsV1().GitServices(ns).List(metav1.ListOptions{})
+	if err != nil {
+		o.warnf("Could not list the GitServices: %s\n", err)
+	} else {
+		o.saveYaml(filepath.Join(resourceDir, "gitservices.yaml"), gitServices.Items)
+	}
+}
+
+// collectJobConfigs saves the config.xml of the Jenkins jobs
+func (o *DiagnoseOptions) collectJobConfigs(dir string) {
+	jenkins, err := o.JenkinsClient()
+	if err != nil {
+		o.warnf("Could not connect to Jenkins: %s\n", err)
+		return
+	}
+	jobs, err := jenkins.GetJobs()
+	if err != nil {
+		o.warnf("Could not list the Jenkins jobs: %s\n", err)
+		return
+	}
+	jobDir := filepath.Join(dir, "jenkins")
+	for _, job := range jobs {
+		config, err := jenkins.GetJobConfigXML(job.Name)
+		if err != nil {
+			o.warnf("Could not load the configuration of job %s: %s\n", job.Name, err)
+			continue
+		}
+		o.saveBundleFile(filepath.Join(jobDir, job.Name+"-config.xml"), o.redact(config))
+	}
+}
+
+// collectConfigFiles saves the local jx configuration files with secrets redacted
+func (o *DiagnoseOptions) collectConfigFiles(dir string) {
+	configDir, err := util.ConfigDir()
+	if err != nil {
+		o.warnf("Could not find the jx configuration directory: %s\n", err)
+		return
+	}
+	files := []string{
+		cmdutil.JenkinsAuthConfigFile,
+		cmdutil.GitAuthConfigFile,
+		cmdutil.ChartmuseumAuthConfigFile,
+		cmdutil.IssuesAuthConfigFile,
+	}
+	bundleDir := filepath.Join(dir, "config")
+	for _, name := range files {
+		data, err := ioutil.ReadFile(filepath.Join(configDir, name))
+		if err != nil {
+			continue
+		}
+		o.saveBundleFile(filepath.Join(bundleDir, name), o.redact(string(data)))
+	}
+}
+
+// redact replaces the values of secret fields with REDACTED so tokens and
+// passwords never end up in a support bundle
+func (o *DiagnoseOptions) redact(text string) string {
+	fields := append([]string{}, defaultRedactedFields...)
+	for _, field := range o.Redact {
+		fields = append(fields, strings.ToLower(field))
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		idx := strings.Index(line, ":")
+		if idx < 0 {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(line[0:idx]))
+		value := strings.TrimSpace(line[idx+1:])
+		if value == "" {
+			continue
+		}
+		for _, field := range fields {
+			if strings.Contains(key, field) {
+				lines[i] = line[0:idx+1] + " REDACTED"
+				break
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// saveYaml marshals the value as YAML into the given bundle file
+func (o *DiagnoseOptions) saveYaml(path string, value interface{}) {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		o.warnf("Could not marshal %s: %s\n", path, err)
+		return
+	}
+	o.saveBundleFile(path, o.redact(string(data)))
+}
+
+// saveBundleFile writes a file into the bundle directory creating any parent directories
+func (o *DiagnoseOptions) saveBundleFile(path string, text string) {
+	err := os.MkdirAll(filepath.Dir(path), DefaultWritePermissions)
+	if err == nil {
+		err = ioutil.WriteFile(path, []byte(text), DefaultWritePermissions)
+	}
+	if err != nil {
+		o.warnf("Could not save %s: %s\n", path, err)
+	}
+}
+
+// createTarball writes the contents of the directory to a gzipped tarball
+func createTarball(dir string, outputFile string) error {
+	file, err := os.Create(outputFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	gzipWriter := gzip.NewWriter(file)
+	defer gzipWriter.Close()
+	tarWriter := tar.NewWriter(gzipWriter)
+	defer tarWriter.Close()
+
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = relPath
+		err = tarWriter.WriteHeader(header)
+		if err != nil {
+			return err
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		_, err = tarWriter.Write(data)
+		return err
+	})
+}
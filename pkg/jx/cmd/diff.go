@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DiffOptions the options for the diff command
+type DiffOptions struct {
+	CommonOptions
+
+	From string
+	To   string
+}
+
+var (
+	diff_long = templates.LongDesc(`
+		Shows the applications and versions which differ between two environments
+		as a pre-flight check before promoting.
+`)
+
+	diff_example = templates.Examples(`
+		# Show what would change when promoting from staging to production
+		jx diff --from staging --to production
+	`)
+)
+
+// NewCmdDiff creates the command
+func NewCmdDiff(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &DiffOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "diff",
+		Short:   "Show the differences between two environments",
+		Long:    diff_long,
+		Example: diff_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.From, "from", "f", "", "The name of the source Environment")
+	cmd.Flags().StringVarP(&options.To, "to", "t", "", "The name of the target Environment")
+	return cmd
+}
+
+// Run implements this command
+func (o *DiffOptions) Run() error {
+	if o.From == "" {
+		return util.MissingOption("from")
+	}
+	if o.To == "" {
+		return util.MissingOption("to")
+	}
+	jxClient, currentNs, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err := kube.GetDevNamespace(kubeClient, currentNs)
+	if err != nil {
+		return err
+	}
+	envNames, err := kube.GetEnvironmentNames(jxClient, ns)
+	if err != nil {
+		return err
+	}
+	fromEnv, err := jxClient.JenkinsV1().Environments(ns).Get(o.From, metav1.GetOptions{})
+	if err != nil {
+		return util.InvalidOption("from", o.From, envNames)
+	}
+	toEnv, err := jxClient.JenkinsV1().Environments(ns).Get(o.To, metav1.GetOptions{})
+	if err != nil {
+		return util.InvalidOption("to", o.To, envNames)
+	}
+
+	fromVersions, err := o.environmentAppVersions(fromEnv.Spec.Namespace)
+	if err != nil {
+		return err
+	}
+	toVersions, err := o.environmentAppVersions(toEnv.Spec.Namespace)
+	if err != nil {
+		return err
+	}
+
+	apps := []string{}
+	for app := range fromVersions {
+		apps = append(apps, app)
+	}
+	for app := range toVersions {
+		if _, ok := fromVersions[app]; !ok {
+			apps = append(apps, app)
+		}
+	}
+	if len(apps) == 0 {
+		o.Printf("No applications found in environments %s and %s\n", util.ColorInfo(o.From), util.ColorInfo(o.To))
+		return nil
+	}
+	sort.Strings(apps)
+
+	differences := 0
+	table := o.CreateTable()
+	table.AddRow("APPLICATION", strings.ToUpper(o.From), strings.ToUpper(o.To), "STATUS")
+	for _, app := range apps {
+		fromVersion, inFrom := fromVersions[app]
+		toVersion, inTo := toVersions[app]
+		status := ""
+		switch {
+		case !inTo:
+			status = "missing in " + o.To
+			differences++
+		case !inFrom:
+			status = "missing in " + o.From
+			differences++
+		case fromVersion != toVersion:
+			status = "different"
+			differences++
+		}
+		table.AddRow(app, fromVersion, toVersion, status)
+	}
+	table.Render()
+
+	if differences == 0 {
+		o.Printf("\nEnvironments %s and %s are in sync\n", util.ColorInfo(o.From), util.ColorInfo(o.To))
+	} else {
+		o.Printf("\n%s applications differ between %s and %s\n", util.ColorInfo(differences), util.ColorInfo(o.From), util.ColorInfo(o.To))
+	}
+	return nil
+}
+
+// environmentAppVersions returns a map of the application names to versions
+// deployed in the namespace of an environment
+func (o *DiffOptions) environmentAppVersions(ns string) (map[string]string, error) {
+	answer := map[string]string{}
+	if ns == "" {
+		return answer, nil
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return answer, err
+	}
+	deployments, err := kube.GetDeployments(kubeClient, ns)
+	if err != nil {
+		return answer, err
+	}
+	for name, d := range deployments {
+		appName := kube.GetAppName(name, ns)
+		answer[appName] = kube.GetVersion(&d.ObjectMeta)
+	}
+	return answer, nil
+}
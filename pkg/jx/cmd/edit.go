@@ -50,7 +50,10 @@ func NewCmdEdit(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Comma
 		SuggestFor: []string{"list", "ps"},
 	}
 
+	cmd.AddCommand(NewCmdEditAgent(f, out, errOut))
+	cmd.AddCommand(NewCmdEditDNS(f, out, errOut))
 	cmd.AddCommand(NewCmdEditEnv(f, out, errOut))
+	cmd.AddCommand(NewCmdEditPipeline(f, out, errOut))
 	return cmd
 }
 
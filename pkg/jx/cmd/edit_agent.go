@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jenkins"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// gkePreemptibleLabel is the node label GKE puts on preemptible nodes
+	gkePreemptibleLabel = "cloud.google.com/gke-preemptible"
+
+	// defaultEvictionRetryCount is the pipeline retry count used when enabling
+	// spot nodes without an explicit retry count
+	defaultEvictionRetryCount = 2
+)
+
+// EditAgentOptions the options for the edit agent command
+type EditAgentOptions struct {
+	CommonOptions
+
+	Spot               bool
+	NodeSelector       string
+	Tolerations        []string
+	PriorityClass      string
+	EvictionRetryCount int
+	NoApply            bool
+}
+
+var (
+	editAgentLong = templates.LongDesc(`
+		Edits the team settings for scheduling the Jenkins build agents.
+
+		Running build agents on spot / preemptible node pools saves a lot of
+		money on CI workloads. This command stores the node selector,
+		tolerations and priority class in the team settings, applies them to
+		the pod templates in the Jenkins configuration and makes the generated
+		pipelines retry so that builds survive a node preemption.
+`)
+
+	editAgentExample = templates.Examples(`
+		# Run the build agents on GKE preemptible nodes
+		jx edit agent --spot
+
+		# Use a custom spot node pool and priority class
+		jx edit agent --node-selector node-pool=spot --toleration spot=true:NoSchedule --priority-class low-priority
+	`)
+)
+
+// NewCmdEditAgent creates a command object for the "edit agent" command
+func NewCmdEditAgent(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &EditAgentOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "agent",
+		Short:   "Edits the scheduling of the Jenkins build agents",
+		Long:    editAgentLong,
+		Example: editAgentExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&options.Spot, "spot", "", false, "Schedule the build agents onto spot / preemptible nodes using the defaults for the cloud provider")
+	cmd.Flags().StringVarP(&options.NodeSelector, "node-selector", "", "", "The node selector for the agent pods in the key=value,key2=value2 form")
+	cmd.Flags().StringArrayVarP(&options.Tolerations, "toleration", "", []string{}, "A toleration for the agent pods in the key=value:Effect form")
+	cmd.Flags().StringVarP(&options.PriorityClass, "priority-class", "", "", "The priority class name for the agent pods")
+	cmd.Flags().IntVarP(&options.EvictionRetryCount, "eviction-retry", "", 0, "The number of times generated pipelines retry so that builds survive a node preemption")
+	cmd.Flags().BoolVarP(&options.NoApply, "no-apply", "", false, "Do not apply the settings to the pod templates in the Jenkins configuration")
+	return cmd
+}
+
+// Run implements the command
+func (o *EditAgentOptions) Run() error {
+	jxClient, ns, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	env, err := jxClient.JenkinsV1().Environments(ns).Get(kube.LabelValueDevEnvironment, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to load the team settings from the %s environment due to: %s", kube.LabelValueDevEnvironment, err)
+	}
+	scheduling := &env.Spec.TeamSettings.AgentScheduling
+	if o.Cmd != nil && o.Cmd.Flags().Changed("spot") {
+		scheduling.UseSpotNodes = o.Spot
+	}
+	if o.NodeSelector != "" {
+		scheduling.NodeSelector = o.NodeSelector
+	}
+	if len(o.Tolerations) > 0 {
+		scheduling.Tolerations = o.Tolerations
+	}
+	if o.PriorityClass != "" {
+		scheduling.PriorityClass = o.PriorityClass
+	}
+	if o.EvictionRetryCount > 0 {
+		scheduling.EvictionRetryCount = o.EvictionRetryCount
+	}
+	if scheduling.UseSpotNodes {
+		if scheduling.NodeSelector == "" {
+			scheduling.NodeSelector = gkePreemptibleLabel + "=true"
+		}
+		if len(scheduling.Tolerations) == 0 {
+			scheduling.Tolerations = []string{gkePreemptibleLabel + "=true:NoSchedule"}
+		}
+		if scheduling.EvictionRetryCount == 0 {
+			scheduling.EvictionRetryCount = defaultEvictionRetryCount
+		}
+	}
+	_, err = jxClient.JenkinsV1().Environments(ns).Update(env)
+	if err != nil {
+		return fmt.Errorf("Failed to save the team settings due to: %s", err)
+	}
+	o.Printf("Updated the agent scheduling settings of team %s\n", util.ColorInfo(ns))
+
+	if o.NoApply {
+		return nil
+	}
+	return o.applyToPodTemplates(scheduling.NodeSelector, scheduling.Tolerations, scheduling.PriorityClass)
+}
+
+// applyToPodTemplates updates the pod templates in the Jenkins configuration
+// with the given scheduling so that new agents land on the spot node pool
+func (o *EditAgentOptions) applyToPodTemplates(nodeSelector string, tolerations []string, priorityClass string) error {
+	kubeClient, currentNs, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err := kube.GetDevNamespace(kubeClient, currentNs)
+	if err != nil {
+		return err
+	}
+	configMaps := kubeClient.CoreV1().ConfigMaps(ns)
+	cm, err := configMaps.Get(kube.ConfigMapJenkins, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to load the Jenkins configuration from the ConfigMap %s in namespace %s due to: %s", kube.ConfigMapJenkins, ns, err)
+	}
+	configXml := cm.Data[kube.ConfigMapKeyConfigXml]
+	if configXml == "" {
+		return fmt.Errorf("The ConfigMap %s in namespace %s does not contain a %s entry", kube.ConfigMapJenkins, ns, kube.ConfigMapKeyConfigXml)
+	}
+	updated, err := jenkins.ApplyAgentScheduling(configXml, &jenkins.AgentScheduling{
+		NodeSelector:  nodeSelector,
+		Tolerations:   tolerations,
+		PriorityClass: priorityClass,
+	})
+	if err != nil {
+		return err
+	}
+	if updated == configXml {
+		o.Printf("The pod templates in the Jenkins configuration are already up to date\n")
+		return nil
+	}
+	cm.Data[kube.ConfigMapKeyConfigXml] = updated
+	_, err = configMaps.Update(cm)
+	if err != nil {
+		return fmt.Errorf("Failed to update the ConfigMap %s in namespace %s due to: %s", kube.ConfigMapJenkins, ns, err)
+	}
+	o.Printf("Updated the pod templates in the Jenkins configuration. Reload the configuration or restart Jenkins to pick up the changes\n")
+	return nil
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/dns"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EditDNSOptions the options for the edit dns command
+type EditDNSOptions struct {
+	CommonOptions
+
+	Provider string
+	Domain   string
+	Target   string
+	Remove   bool
+}
+
+var (
+	editDNSLong = templates.LongDesc(`
+		Edits the team settings for the automatic management of DNS records.
+
+		Once a DNS provider, domain and target are configured the records of
+		exposed services and preview environments are created in the real domain
+		instead of relying on wildcard DNS services like nip.io.
+`)
+
+	editDNSExample = templates.Examples(`
+		# Manage the team domain in AWS Route53 pointing records at the ingress IP
+		jx edit dns --provider route53 --domain my-teams.com --target 35.190.0.1
+
+		# Use Cloudflare with a CNAME target for the ingress
+		jx edit dns --provider cloudflare --domain my-teams.com --target ingress.my-teams.com
+
+		# Stop managing DNS records
+		jx edit dns --remove
+	`)
+)
+
+// NewCmdEditDNS creates a command object for the "edit dns" command
+func NewCmdEditDNS(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &EditDNSOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "dns",
+		Short:   "Edits the team settings for automatic DNS record management",
+		Long:    editDNSLong,
+		Example: editDNSExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Provider, "provider", "p", "", "The DNS provider to use: "+strings.Join(dns.KindDNSProviders, ", "))
+	cmd.Flags().StringVarP(&options.Domain, "domain", "d", "", "The team domain the records are created in")
+	cmd.Flags().StringVarP(&options.Target, "target", "t", "", "The IP address or host name of the ingress controller the records point at")
+	cmd.Flags().BoolVarP(&options.Remove, "remove", "", false, "Removes the DNS settings so records are no longer managed")
+	return cmd
+}
+
+// Run implements the command
+func (o *EditDNSOptions) Run() error {
+	if !o.Remove {
+		if o.Provider == "" {
+			return util.MissingOption("provider")
+		}
+		if util.StringArrayIndex(dns.KindDNSProviders, o.Provider) < 0 {
+			return util.InvalidOption("provider", o.Provider, dns.KindDNSProviders)
+		}
+		if o.Domain == "" {
+			return util.MissingOption("domain")
+		}
+		if o.Target == "" {
+			return util.MissingOption("target")
+		}
+	}
+
+	jxClient, ns, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	env, err := jxClient.JenkinsV1().Environments(ns).Get(kube.LabelValueDevEnvironment, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to load the team settings from the %s environment due to: %s", kube.LabelValueDevEnvironment, err)
+	}
+	settings := &env.Spec.TeamSettings
+	if o.Remove {
+		settings.DNS = v1.DNSSettings{}
+	} else {
+		settings.DNS.Provider = o.Provider
+		settings.DNS.Domain = o.Domain
+		settings.DNS.Target = o.Target
+	}
+	_, err = jxClient.JenkinsV1().Environments(ns).Update(env)
+	if err != nil {
+		return fmt.Errorf("Failed to save the team settings due to: %s", err)
+	}
+	if o.Remove {
+		o.Printf("DNS records are no longer managed for the team\n")
+	} else {
+		o.Printf("DNS records of domain %s are now managed via %s pointing at %s\n",
+			util.ColorInfo(o.Domain), util.ColorInfo(o.Provider), util.ColorInfo(o.Target))
+	}
+	return nil
+}
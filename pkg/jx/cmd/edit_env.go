@@ -47,7 +47,7 @@ type EditEnvOptions struct {
 
 // NewCmdEditEnv creates a command object for the "create" command
 func NewCmdEditEnv(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
-	options := &CreateEnvOptions{
+	options := &EditEnvOptions{
 		HelmValuesConfig: config.HelmValuesConfig{
 			ExposeController: &config.ExposeController{},
 		},
@@ -62,7 +62,7 @@ func NewCmdEditEnv(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Co
 
 	cmd := &cobra.Command{
 		Use:     "environment",
-		Short:   "Create a new Environment which is used to promote your Team's Applications via Continuous Delivery",
+		Short:   "Edits an Environment which is used to promote your Team's Applications via Continuous Delivery",
 		Aliases: []string{"env"},
 		Long:    edit_env_long,
 		Example: edit_env_example,
@@ -180,7 +180,7 @@ func (o *EditEnvOptions) Run() error {
 			}
 			gitProvider = p
 		}
-		return jenkins.ImportProject(o.Out, jenkinClient, gitURL, jenkins.DefaultJenkinsfile, o.EnvJobCredentials, false, gitProvider, authConfigSvc)
+		return jenkins.ImportProject(o.Out, jenkinClient, gitURL, "", jenkins.DefaultJenkinsfile, o.EnvJobCredentials, false, jenkins.JobExistsSkip, o.BatchMode, gitProvider, authConfigSvc, nil)
 	}
 	return nil
 }
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EditPipelineOptions the options for the edit pipeline command
+type EditPipelineOptions struct {
+	CommonOptions
+
+	StageTimeout int
+	StageRetry   int
+}
+
+var (
+	editPipelineLong = templates.LongDesc(`
+		Edits the team policies applied to generated pipelines.
+
+		The stage timeout and retry policies are stored in the team settings and
+		emitted as wrappers around each stage when a pipeline is generated at
+		import time, so flaky steps such as registry pushes or helm installs
+		retry automatically instead of failing the whole build.
+`)
+
+	editPipelineExample = templates.Examples(`
+		# Retry failed stages twice and abort stages running over 30 minutes
+		jx edit pipeline --stage-retry 2 --stage-timeout 30
+	`)
+)
+
+// NewCmdEditPipeline creates a command object for the "edit pipeline" command
+func NewCmdEditPipeline(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &EditPipelineOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "pipeline",
+		Short:   "Edits the team stage timeout and retry policies of generated pipelines",
+		Long:    editPipelineLong,
+		Example: editPipelineExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.Flags().IntVarP(&options.StageTimeout, "stage-timeout", "", 0, "The number of minutes after which a pipeline stage is aborted")
+	cmd.Flags().IntVarP(&options.StageRetry, "stage-retry", "", 0, "The number of times a failed pipeline stage is retried")
+	return cmd
+}
+
+// Run implements the command
+func (o *EditPipelineOptions) Run() error {
+	jxClient, ns, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	env, err := jxClient.JenkinsV1().Environments(ns).Get(kube.LabelValueDevEnvironment, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to load the team settings from the %s environment due to: %s", kube.LabelValueDevEnvironment, err)
+	}
+	policies := &env.Spec.TeamSettings.PipelinePolicies
+	if o.Cmd != nil && o.Cmd.Flags().Changed("stage-timeout") {
+		policies.StageTimeoutMinutes = o.StageTimeout
+	}
+	if o.Cmd != nil && o.Cmd.Flags().Changed("stage-retry") {
+		policies.StageRetryCount = o.StageRetry
+	}
+	_, err = jxClient.JenkinsV1().Environments(ns).Update(env)
+	if err != nil {
+		return fmt.Errorf("Failed to save the team settings due to: %s", err)
+	}
+	o.Printf("Updated the pipeline policies of team %s: stage timeout %s minutes, stage retries %s\n",
+		util.ColorInfo(ns), util.ColorInfo(policies.StageTimeoutMinutes), util.ColorInfo(policies.StageRetryCount))
+	o.Printf("The policies are applied to pipelines generated by %s\n", util.ColorInfo("jx import"))
+	return nil
+}
@@ -65,10 +65,27 @@ func NewCmdGet(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Comman
 
 	cmd.AddCommand(NewCmdGetActivity(f, out, errOut))
 	cmd.AddCommand(NewCmdGetAddon(f, out, errOut))
+	cmd.AddCommand(NewCmdGetBranchProtection(f, out, errOut))
 	cmd.AddCommand(NewCmdGetBuild(f, out, errOut))
+	cmd.AddCommand(NewCmdGetCertificates(f, out, errOut))
+	cmd.AddCommand(NewCmdGetDora(f, out, errOut))
+	cmd.AddCommand(NewCmdGetDurations(f, out, errOut))
 	cmd.AddCommand(NewCmdGetEnv(f, out, errOut))
+	cmd.AddCommand(NewCmdGetEvents(f, out, errOut))
+	cmd.AddCommand(NewCmdGetFreezes(f, out, errOut))
 	cmd.AddCommand(NewCmdGetGit(f, out, errOut))
+	cmd.AddCommand(NewCmdGetHealth(f, out, errOut))
+	cmd.AddCommand(NewCmdGetLabels(f, out, errOut))
+	cmd.AddCommand(NewCmdGetMergeQueue(f, out, errOut))
+	cmd.AddCommand(NewCmdGetNodes(f, out, errOut))
 	cmd.AddCommand(NewCmdGetPipeline(f, out, errOut))
+	cmd.AddCommand(NewCmdGetPlatform(f, out, errOut))
+	cmd.AddCommand(NewCmdGetPlugins(f, out, errOut))
+	cmd.AddCommand(NewCmdGetQueue(f, out, errOut))
+	cmd.AddCommand(NewCmdGetRepos(f, out, errOut))
+	cmd.AddCommand(NewCmdGetTeam(f, out, errOut))
+	cmd.AddCommand(NewCmdGetTrackers(f, out, errOut))
+	cmd.AddCommand(NewCmdGetTrains(f, out, errOut))
 	cmd.AddCommand(NewCmdGetURL(f, out, errOut))
 	cmd.AddCommand(NewCmdGetVersion(f, out, errOut))
 	return cmd
@@ -2,12 +2,14 @@ package cmd
 
 import (
 	"io"
+	"os"
 	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/features"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
 	"github.com/jenkins-x/jx/pkg/kube"
@@ -19,6 +21,12 @@ import (
 // GetVersionOptions containers the CLI options
 type GetVersionOptions struct {
 	CommonOptions
+
+	URL          bool
+	FeatureFlag  string
+	FlagProvider string
+	FlagServer   string
+	FlagToken    string
 }
 
 var (
@@ -29,13 +37,16 @@ var (
 	get_version_example = templates.Examples(`
 		# List applications for all environments
 		jx get app
+
+		# List applications with their exposed URLs
+		jx get applications -u
 	`)
 )
 
 // NewCmdGetVersion creates the new command for: jx get version
 func NewCmdGetVersion(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 	options := &GetVersionOptions{
-		CommonOptions{
+		CommonOptions: CommonOptions{
 			Factory: f,
 			Out:     out,
 			Err:     errOut,
@@ -44,7 +55,7 @@ func NewCmdGetVersion(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra
 	cmd := &cobra.Command{
 		Use:     "apps",
 		Short:   "Display one or many Applications and their versions",
-		Aliases: []string{"app", "version", "versions"},
+		Aliases: []string{"app", "applications", "application", "version", "versions"},
 		Long:    get_version_long,
 		Example: get_version_example,
 		Run: func(cmd *cobra.Command, args []string) {
@@ -54,12 +65,18 @@ func NewCmdGetVersion(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra
 			cmdutil.CheckErr(err)
 		},
 	}
+	cmd.Flags().BoolVarP(&options.URL, "url", "u", false, "Display the exposed URL of each application in the environment")
+	cmd.Flags().StringVarP(&options.FeatureFlag, "feature-flag", "", "", "Show the state of this feature flag in each environment")
+	cmd.Flags().StringVarP(&options.FlagProvider, "flag-provider", "", "", "The feature flag service to use: "+strings.Join(features.KindFlagProviders, ", "))
+	cmd.Flags().StringVarP(&options.FlagServer, "flag-server", "", "", "The URL of the feature flag service")
+	cmd.Flags().StringVarP(&options.FlagToken, "flag-token", "", "", "The API token for the feature flag service. Defaults to $FEATURE_FLAG_TOKEN")
 	return cmd
 }
 
 type EnvApps struct {
 	Environment v1.Environment
 	Apps        map[string]v1beta1.Deployment
+	URLs        map[string]string
 }
 
 // Run implements this command
@@ -97,6 +114,15 @@ func (o *GetVersionOptions) Run() error {
 				envApp := EnvApps{
 					Environment: env,
 					Apps:        map[string]v1beta1.Deployment{},
+					URLs:        map[string]string{},
+				}
+				if o.URL {
+					services, err := kube.GetServices(kubeClient, ens)
+					if err == nil {
+						for name, svc := range services {
+							envApp.URLs[kube.GetAppName(name, ens)] = kube.GetServiceURL(svc)
+						}
+					}
 				}
 				envApps = append(envApps, envApp)
 				for k, d := range m {
@@ -116,10 +142,18 @@ func (o *GetVersionOptions) Run() error {
 	}
 	sort.Strings(apps)
 
+	flagProvider := o.createFlagProvider()
+
 	table := o.CreateTable()
 	titles := []string{"APPLICATION"}
 	for _, ea := range envApps {
 		titles = append(titles, strings.ToUpper(ea.Environment.Name), "PODS")
+		if o.URL {
+			titles = append(titles, "URL")
+		}
+		if flagProvider != nil {
+			titles = append(titles, "FLAG")
+		}
 	}
 	table.AddRow(titles...)
 
@@ -137,9 +171,45 @@ func (o *GetVersionOptions) Run() error {
 				pods = formatInt32(ready) + "/" + replicas
 			}
 			row = append(row, version, pods)
+			if o.URL {
+				row = append(row, ea.URLs[appName])
+			}
+			if flagProvider != nil {
+				row = append(row, o.flagStateText(flagProvider, ea.Environment.Name))
+			}
 		}
 		table.AddRow(row...)
 	}
 	table.Render()
 	return nil
 }
+
+// createFlagProvider creates the feature flag provider if the --feature-flag
+// option was given
+func (o *GetVersionOptions) createFlagProvider() features.FlagProvider {
+	if o.FeatureFlag == "" {
+		return nil
+	}
+	token := o.FlagToken
+	if token == "" {
+		token = os.Getenv("FEATURE_FLAG_TOKEN")
+	}
+	provider, err := features.CreateFlagProvider(o.FlagProvider, o.FlagServer, token)
+	if err != nil {
+		o.warnf("Could not create the feature flag provider: %s\n", err)
+		return nil
+	}
+	return provider
+}
+
+// flagStateText returns the display text of the feature flag state in the environment
+func (o *GetVersionOptions) flagStateText(provider features.FlagProvider, envName string) string {
+	enabled, err := provider.FlagState(o.FeatureFlag, envName)
+	if err != nil {
+		return ""
+	}
+	if enabled {
+		return util.ColorInfo("on")
+	}
+	return "off"
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// GetBranchProtectionOptions the command line options
+type GetBranchProtectionOptions struct {
+	GetOptions
+
+	Organisation string
+	GitHost      string
+	GitHub       bool
+	Branch       string
+	Filter       string
+}
+
+var (
+	get_branchprotection_long = templates.LongDesc(`
+		Display the branch protection settings of the repositories of an
+		organisation so that drift from the policy is easy to spot.
+`)
+
+	get_branchprotection_example = templates.Examples(`
+		# Show the branch protection of all repositories of an organisation
+		jx get branchprotection --github --org myorg
+	`)
+)
+
+// NewCmdGetBranchProtection creates the command
+func NewCmdGetBranchProtection(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetBranchProtectionOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "branchprotection",
+		Short:   "Display the branch protection of the repositories of an organisation",
+		Aliases: []string{"branch-protection"},
+		Long:    get_branchprotection_long,
+		Example: get_branchprotection_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Organisation, "org", "o", "", "The git provider organisation to query")
+	cmd.Flags().StringVarP(&options.GitHost, "git-host", "g", "", "The Git server host if not using GitHub")
+	cmd.Flags().BoolVarP(&options.GitHub, "github", "", false, "If the repositories are on GitHub")
+	cmd.Flags().StringVarP(&options.Branch, "branch", "b", "master", "The branch to query the protection of")
+	cmd.Flags().StringVarP(&options.Filter, "filter", "", "", "Only show repositories whose name contains this text")
+	return cmd
+}
+
+// Run implements the command
+func (o *GetBranchProtectionOptions) Run() error {
+	if o.Organisation == "" {
+		return util.MissingOption("org")
+	}
+	provider, err := o.gitProviderForHost(o.GitHub, o.GitHost, "Pick the git server of the organisation")
+	if err != nil {
+		return err
+	}
+	ghProvider, ok := provider.(*gits.GitHubProvider)
+	if !ok {
+		return fmt.Errorf("Branch protection is currently only supported for GitHub repositories")
+	}
+	repos, err := provider.ListRepositories(o.Organisation)
+	if err != nil {
+		return fmt.Errorf("Failed to list the repositories of %s due to: %s", o.Organisation, err)
+	}
+	table := o.CreateTable()
+	table.AddRow("REPOSITORY", "PROTECTED", "CONTEXTS", "UP TO DATE", "REVIEWS", "ADMINS")
+	for _, repo := range repos {
+		if o.Filter != "" && !strings.Contains(repo.Name, o.Filter) {
+			continue
+		}
+		protection, err := ghProvider.GetBranchProtection(o.Organisation, repo.Name, o.Branch)
+		if err != nil {
+			o.warnf("%s\n", err)
+			continue
+		}
+		if protection == nil {
+			table.AddRow(repo.Name, util.ColorWarning("no"), "", "", "", "")
+			continue
+		}
+		table.AddRow(repo.Name, util.ColorInfo("yes"),
+			strings.Join(protection.RequiredContexts, " "),
+			formatBool(protection.RequireUpToDate),
+			formatBool(protection.RequireReviews),
+			formatBool(protection.EnforceAdmins))
+	}
+	table.Render()
+	return nil
+}
+
+// formatBool renders a boolean for display in a table
+func formatBool(flag bool) string {
+	if flag {
+		return "true"
+	}
+	return "false"
+}
@@ -2,52 +2,68 @@ package cmd
 
 import (
 	"io"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/jenkins-x/golang-jenkins"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
 )
 
 // GetBuildOptions the command line options
 type GetBuildOptions struct {
-	CommonOptions
+	GetOptions
 
-	Output string
+	BuildCount int
+}
+
+// BuildSummary the summary of a build used for the machine readable output formats
+type BuildSummary struct {
+	Pipeline string `json:"pipeline"`
+	Build    string `json:"build"`
+	Status   string `json:"status"`
+	Duration string `json:"duration"`
+	URL      string `json:"url"`
 }
 
 var (
 	get_build_long = templates.LongDesc(`
-		Display one or many resources.
-
-		` + valid_resources + `
+		Display the recent builds of the pipelines along with their status, duration and URL.
 
 `)
 
 	get_build_example = templates.Examples(`
-		# List all pipeines
-		jx get pipeline
+		# List the recent builds of all pipelines
+		jx get builds
+
+		# List the recent builds of the pipelines matching a filter
+		jx get builds myorg/myrepo
 
-		# List all URLs for services in the current namespace
-		jx get url
+		# List the last 10 builds of each pipeline as YAML
+		jx get builds --build-count 10 -o yaml
 	`)
 )
 
 // NewCmdGetBuild creates the command object
 func NewCmdGetBuild(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 	options := &GetBuildOptions{
-		CommonOptions: CommonOptions{
-			Factory: f,
-			Out:     out,
-			Err:     errOut,
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
 		},
 	}
 
 	cmd := &cobra.Command{
 		Use:     "build [flags]",
-		Short:   "Display one or many build resources",
+		Short:   "Display one or many builds",
 		Long:    get_build_long,
 		Example: get_build_example,
+		Aliases: []string{"builds"},
 		Run: func(cmd *cobra.Command, args []string) {
 			options.Cmd = cmd
 			options.Args = args
@@ -58,10 +74,98 @@ func NewCmdGetBuild(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.C
 	}
 
 	cmd.AddCommand(NewCmdGetBuildLogs(f, out, errOut))
+
+	cmd.Flags().IntVarP(&options.BuildCount, "build-count", "", 5, "The number of recent builds to display for each pipeline")
+	options.addGetFlags(cmd)
 	return cmd
 }
 
 // Run implements this command
 func (o *GetBuildOptions) Run() error {
-	return o.Cmd.Help()
+	jenkins, err := o.JenkinsClient()
+	if err != nil {
+		return err
+	}
+	jobs, err := jenkins.GetJobs()
+	if err != nil {
+		return err
+	}
+	summaries := []BuildSummary{}
+	for _, j := range jobs {
+		summaries = o.collectBuilds(jenkins, j.Name, summaries)
+	}
+	if len(summaries) == 0 {
+		return outputEmptyListWarning(o.Out)
+	}
+
+	if o.Output != "" {
+		return o.renderResult(summaries, o.Output)
+	}
+
+	table := o.CreateTable()
+	table.AddRow("PIPELINE", "BUILD", "STATUS", "DURATION", "URL")
+	for _, s := range summaries {
+		table.AddRow(s.Pipeline, s.Build, s.Status, s.Duration, s.URL)
+	}
+	table.Render()
+	return nil
+}
+
+// collectBuilds walks the job and its children appending the recent build summaries
+func (o *GetBuildOptions) collectBuilds(jenkins *gojenkins.Jenkins, name string, summaries []BuildSummary) []BuildSummary {
+	job, err := jenkins.GetJob(name)
+	if err != nil {
+		return summaries
+	}
+	if job.Jobs != nil {
+		for _, child := range job.Jobs {
+			summaries = o.collectBuilds(jenkins, job.FullName+"/"+child.Name, summaries)
+		}
+		return summaries
+	}
+	if !o.matchesBuildFilter(&job) {
+		return summaries
+	}
+	last, err := jenkins.GetLastBuild(job)
+	if err != nil {
+		return summaries
+	}
+	first := last.Number - o.BuildCount + 1
+	if first < 1 {
+		first = 1
+	}
+	for number := last.Number; number >= first; number-- {
+		build, err := jenkins.GetBuild(job, number)
+		if err != nil {
+			continue
+		}
+		status := build.Result
+		duration := time.Duration(build.Duration) * time.Millisecond
+		if build.Building {
+			status = "Building"
+			duration = time.Duration(build.EstimatedDuration) * time.Millisecond
+		}
+		summaries = append(summaries, BuildSummary{
+			Pipeline: job.FullName,
+			Build:    "#" + build.Id,
+			Status:   status,
+			Duration: duration.String(),
+			URL:      build.Url,
+		})
+	}
+	return summaries
+}
+
+func (o *GetBuildOptions) matchesBuildFilter(job *gojenkins.Job) bool {
+	args := o.Args
+	if len(args) == 0 {
+		return true
+	}
+	name := job.FullName
+	for _, arg := range args {
+		if strings.Contains(name, arg) {
+			return true
+		}
+	}
+	return false
 }
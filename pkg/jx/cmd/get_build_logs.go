@@ -6,6 +6,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"fmt"
+	"github.com/jenkins-x/golang-jenkins"
+	"github.com/jenkins-x/jx/pkg/jenkins"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
 	"github.com/jenkins-x/jx/pkg/util"
@@ -19,17 +21,27 @@ type GetBuildLogsOptions struct {
 
 	Tail   bool
 	Filter string
+	Build  int
+	Stage  string
 }
 
 var (
 	get_build_log_long = templates.LongDesc(`
-		Display the git server URLs.
+		Display the log of a pipeline build.
 
+		You can display the whole console log or just the log of a given stage
+		using the workflow API.
 `)
 
 	get_build_log_example = templates.Examples(`
-		# List all registered git server URLs
-		jx get git
+		# Display a build log picking the pipeline interactively
+		jx get build log
+
+		# Display the log of build 12 of the master branch
+		jx get build log myorg/myrepo/master -b 12
+
+		# Display just the log of the 'Deploy Staging' stage
+		jx get build log myorg/myrepo/master -b 12 --stage 'Deploy Staging'
 	`)
 )
 
@@ -60,6 +72,8 @@ func NewCmdGetBuildLogs(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cob
 	}
 	cmd.Flags().BoolVarP(&options.Tail, "tail", "t", true, "Tails the build log to the current terminal")
 	cmd.Flags().StringVarP(&options.Filter, "filter", "f", "", "Filters all the available jobs by those that contain the given text")
+	cmd.Flags().IntVarP(&options.Build, "build", "b", 0, "The build number to view. Defaults to the last build")
+	cmd.Flags().StringVarP(&options.Stage, "stage", "", "", "Display only the log of the given stage using the workflow API")
 
 	return cmd
 }
@@ -107,10 +121,67 @@ func (o *GetBuildLogsOptions) Run() error {
 	if job == nil {
 		return util.InvalidArg(name, names)
 	}
-	last, err := jenkinsClient.GetLastBuild(*job)
+	var build gojenkins.Build
+	if o.Build > 0 {
+		build, err = jenkinsClient.GetBuild(*job, o.Build)
+		if err != nil {
+			return fmt.Errorf("Failed to find build %d of %s due to: %s", o.Build, name, err)
+		}
+	} else {
+		build, err = jenkinsClient.GetLastBuild(*job)
+		if err != nil {
+			return err
+		}
+	}
+	if o.Stage != "" {
+		return o.showStageLog(jenkinsClient, name, &build)
+	}
+	o.Printf("%s %s\n", util.ColorStatus("view the log at:"), util.ColorInfo(util.UrlJoin(build.Url, "/console")))
+	if o.Tail {
+		return o.tailBuild(name, &build)
+	}
+	buildLog, err := jenkinsClient.GetBuildConsoleOutput(build)
+	if err != nil {
+		return fmt.Errorf("Failed to fetch the console log of %s #%d due to: %s", name, build.Number, err)
+	}
+	_, err = o.Out.Write(buildLog)
+	return err
+}
+
+// showStageLog displays the log of the steps of the given stage using the workflow API
+func (o *GetBuildLogsOptions) showStageLog(jenkinsClient *gojenkins.Jenkins, name string, build *gojenkins.Build) error {
+	run, err := jenkins.GetWorkflowRun(jenkinsClient, build)
+	if err != nil {
+		return err
+	}
+	var stage *jenkins.WorkflowStage
+	for i, s := range run.Stages {
+		if strings.EqualFold(s.Name, o.Stage) {
+			stage = &run.Stages[i]
+			break
+		}
+	}
+	if stage == nil {
+		return util.InvalidArg(o.Stage, run.StageNames())
+	}
+	stage, err = jenkins.GetWorkflowStage(jenkinsClient, stage)
 	if err != nil {
 		return err
 	}
-	o.Printf("%s %s\n", util.ColorStatus("view the log at:"), util.ColorInfo(util.UrlJoin(last.Url, "/console")))
-	return o.tailBuild(name, &last)
+	o.Printf("%s %s %s\n", util.ColorStatus("stage"), util.ColorInfo(stage.Name), util.ColorStatus(stage.Status))
+	for i, node := range stage.StageFlowNodes {
+		nodeLog, err := jenkins.GetWorkflowNodeLog(jenkinsClient, &stage.StageFlowNodes[i])
+		if err != nil {
+			return err
+		}
+		o.Printf("%s %s %s\n", util.ColorStatus("step"), util.ColorInfo(node.Name), util.ColorStatus(node.Status))
+		if nodeLog != nil && nodeLog.Text != "" {
+			// the workflow API preserves any ANSI colors in the log text
+			o.Printf("%s", nodeLog.Text)
+			if !strings.HasSuffix(nodeLog.Text, "\n") {
+				o.Printf("\n")
+			}
+		}
+	}
+	return nil
 }
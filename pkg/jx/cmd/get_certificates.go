@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// certificateDialTimeout the timeout used when connecting to a TLS endpoint to inspect its certificate
+const certificateDialTimeout = 10 * time.Second
+
+// GetCertificatesOptions the command line options
+type GetCertificatesOptions struct {
+	GetOptions
+
+	ExpiryDays int
+}
+
+// CertificateSummary the summary of the certificate of an exposed service
+type CertificateSummary struct {
+	Host      string `json:"host"`
+	Namespace string `json:"namespace"`
+	Ingress   string `json:"ingress"`
+	Issuer    string `json:"issuer"`
+	Expires   string `json:"expires"`
+	Status    string `json:"status"`
+}
+
+var (
+	getCertificatesLong = templates.LongDesc(`
+		Display the TLS certificates of the exposed services along with their expiry date and issuer.
+
+		The TLS hosts are discovered from the Ingress resources of the team and environment
+		namespaces and each endpoint is connected to so the actual served certificate is shown.
+`)
+
+	getCertificatesExample = templates.Examples(`
+		# List the certificates of all exposed services
+		jx get certificates
+
+		# List the certificates flagging any that expire within 30 days
+		jx get certificates --expiry-days 30
+
+		# List the certificates as YAML
+		jx get certificates -o yaml
+	`)
+)
+
+// NewCmdGetCertificates creates the command
+func NewCmdGetCertificates(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetCertificatesOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "certificates",
+		Short:   "Display the TLS certificates of the exposed services",
+		Long:    getCertificatesLong,
+		Example: getCertificatesExample,
+		Aliases: []string{"certificate", "certs", "cert"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.Flags().IntVarP(&options.ExpiryDays, "expiry-days", "", 14, "The number of days before expiry a certificate is flagged as expiring")
+	options.addGetFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GetCertificatesOptions) Run() error {
+	summaries, err := o.certificateSummaries(o.ExpiryDays)
+	if err != nil {
+		return err
+	}
+	if len(summaries) == 0 {
+		return outputEmptyListWarning(o.Out)
+	}
+
+	if o.Output != "" {
+		return o.renderResult(summaries, o.Output)
+	}
+
+	table := o.CreateTable()
+	table.AddRow("HOST", "NAMESPACE", "INGRESS", "ISSUER", "EXPIRES", "STATUS")
+	for _, s := range summaries {
+		table.AddRow(s.Host, s.Namespace, s.Ingress, s.Issuer, s.Expires, s.Status)
+	}
+	table.Render()
+	return nil
+}
+
+// certificateSummaries returns the certificate summaries of the TLS hosts exposed via
+// Ingress resources in the team and environment namespaces
+func (o *CommonOptions) certificateSummaries(expiryDays int) ([]CertificateSummary, error) {
+	kubeClient, currentNs, err := o.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	ns, _, err := kube.GetDevNamespace(kubeClient, currentNs)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := []string{ns}
+	jxClient, _, err := o.JXClient()
+	if err == nil {
+		envs, err := jxClient.JenkinsV1().Environments(ns).List(metav1.ListOptions{})
+		if err == nil {
+			for _, env := range envs.Items {
+				envNs := env.Spec.Namespace
+				if envNs != "" && util.StringArrayIndex(namespaces, envNs) < 0 {
+					namespaces = append(namespaces, envNs)
+				}
+			}
+		}
+	}
+	summaries := []CertificateSummary{}
+	now := time.Now()
+	for _, namespace := range namespaces {
+		ingresses, err := kubeClient.ExtensionsV1beta1().Ingresses(namespace).List(metav1.ListOptions{})
+		if err != nil {
+			o.warnf("Failed to list the Ingress resources in namespace %s: %s\n", namespace, err)
+			continue
+		}
+		for _, ing := range ingresses.Items {
+			for _, tlsConfig := range ing.Spec.TLS {
+				for _, host := range tlsConfig.Hosts {
+					summaries = append(summaries, certificateSummary(host, namespace, ing.Name, expiryDays, now))
+				}
+			}
+		}
+	}
+	return summaries, nil
+}
+
+// certificateSummary connects to the TLS endpoint of the host and summarises the certificate it serves
+func certificateSummary(host string, namespace string, ingress string, expiryDays int, now time.Time) CertificateSummary {
+	summary := CertificateSummary{
+		Host:      host,
+		Namespace: namespace,
+		Ingress:   ingress,
+	}
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: certificateDialTimeout}, "tcp",
+		net.JoinHostPort(host, "443"), &tls.Config{ServerName: host, InsecureSkipVerify: true})
+	if err != nil {
+		summary.Status = fmt.Sprintf("Not available: %s", err)
+		return summary
+	}
+	defer conn.Close()
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		summary.Status = "Not available: no certificate returned"
+		return summary
+	}
+	cert := certs[0]
+	summary.Issuer = cert.Issuer.CommonName
+	summary.Expires = cert.NotAfter.Format(time.RFC3339)
+	if now.After(cert.NotAfter) {
+		summary.Status = "Expired"
+	} else if now.Add(time.Duration(expiryDays) * 24 * time.Hour).After(cert.NotAfter) {
+		summary.Status = fmt.Sprintf("Expiring in %d day(s)", int(cert.NotAfter.Sub(now).Hours()/24))
+	} else {
+		summary.Status = "Valid"
+	}
+	return summary
+}
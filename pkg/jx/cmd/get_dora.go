@@ -0,0 +1,300 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetDoraOptions containers the CLI options
+type GetDoraOptions struct {
+	GetOptions
+
+	Filter  string
+	Days    int
+	PushURL string
+}
+
+// DoraMetrics are the DORA metrics of one application over the report window
+type DoraMetrics struct {
+	Application string `json:"application"`
+
+	// Deployments is the number of successful pipelines which promoted a
+	// version in the window
+	Deployments int `json:"deployments"`
+
+	// DeploymentsPerDay is the deployment frequency
+	DeploymentsPerDay float64 `json:"deploymentsPerDay"`
+
+	// LeadTime is the mean duration from the start of a pipeline to its
+	// promotion completing
+	LeadTime string `json:"leadTime,omitempty"`
+
+	// ChangeFailureRate is the fraction of completed pipelines which failed
+	ChangeFailureRate float64 `json:"changeFailureRate"`
+
+	// MTTR is the mean time from a failed pipeline to the next success
+	MTTR string `json:"mttr,omitempty"`
+}
+
+var (
+	getDoraLong = templates.LongDesc(`
+		Display the DORA metrics of the applications in the current team
+		computed from the PipelineActivity resources: deployment frequency,
+		lead time for changes, change failure rate and mean time to recovery.
+`)
+
+	getDoraExample = templates.Examples(`
+		# Display the DORA metrics of the last 30 days
+		jx get dora
+
+		# Display the metrics of the last quarter as JSON
+		jx get dora --days 90 -o json
+
+		# Push the metrics to a metrics backend
+		jx get dora --push-url http://pushgateway/api/dora
+	`)
+)
+
+// NewCmdGetDora creates the new command for: jx get dora
+func NewCmdGetDora(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetDoraOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "dora",
+		Short:   "Display the DORA metrics of the applications",
+		Aliases: []string{"wait-times"},
+		Long:    getDoraLong,
+		Example: getDoraExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Filter, "filter", "f", "", "Filter the applications by name")
+	cmd.Flags().IntVarP(&options.Days, "days", "d", 30, "The number of days to report over")
+	cmd.Flags().StringVarP(&options.PushURL, "push-url", "", "", "POST the metrics as JSON to this URL so they can be recorded in a metrics backend")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", "", "The output format such as 'json' or 'yaml'")
+	return cmd
+}
+
+// Run implements this command
+func (o *GetDoraOptions) Run() error {
+	client, currentNs, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err := kube.GetDevNamespace(kubeClient, currentNs)
+	if err != nil {
+		return err
+	}
+	list, err := client.JenkinsV1().PipelineActivities(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	since := time.Now().AddDate(0, 0, -o.Days)
+	metrics := calculateDoraMetrics(list.Items, since)
+	if o.Filter != "" {
+		filtered := []*DoraMetrics{}
+		for _, m := range metrics {
+			if strings.Contains(m.Application, o.Filter) {
+				filtered = append(filtered, m)
+			}
+		}
+		metrics = filtered
+	}
+	if o.PushURL != "" {
+		err = o.pushMetrics(metrics)
+		if err != nil {
+			return err
+		}
+	}
+	if o.Output != "" {
+		return o.renderResult(metrics, o.Output)
+	}
+	table := o.CreateTable()
+	table.AddRow("APPLICATION", "DEPLOYS", "DEPLOYS/DAY", "LEAD TIME", "FAILURE RATE", "MTTR")
+	for _, m := range metrics {
+		table.AddRow(m.Application,
+			fmt.Sprintf("%d", m.Deployments),
+			fmt.Sprintf("%.2f", m.DeploymentsPerDay),
+			m.LeadTime,
+			fmt.Sprintf("%.0f%%", m.ChangeFailureRate*100),
+			m.MTTR)
+	}
+	table.Render()
+	return nil
+}
+
+// pushMetrics posts the metrics as JSON to the configured backend
+func (o *GetDoraOptions) pushMetrics(metrics []*DoraMetrics) error {
+	data, err := json.Marshal(metrics)
+	if err != nil {
+		return err
+	}
+	response, err := http.Post(o.PushURL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("Failed to push the metrics to %s due to: %s", o.PushURL, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("Failed to push the metrics to %s: status %s", o.PushURL, response.Status)
+	}
+	o.Printf("Pushed the metrics of %d application(s) to %s\n", len(metrics), o.PushURL)
+	return nil
+}
+
+// calculateDoraMetrics computes the DORA metrics per application from the
+// pipeline activities started since the given time
+func calculateDoraMetrics(activities []v1.PipelineActivity, since time.Time) []*DoraMetrics {
+	type appData struct {
+		deployments int
+		leadTimes   []time.Duration
+		completed   int
+		failed      int
+		failures    []time.Time
+		recoveries  []time.Duration
+	}
+	apps := map[string]*appData{}
+	sort.Slice(activities, func(i, j int) bool {
+		return activityCompletedTime(&activities[i]).Before(activityCompletedTime(&activities[j]))
+	})
+	for i := range activities {
+		activity := &activities[i]
+		started := activity.Spec.StartedTimestamp
+		if started == nil || started.Time.Before(since) {
+			continue
+		}
+		name := activityApplication(activity)
+		if name == "" {
+			continue
+		}
+		data := apps[name]
+		if data == nil {
+			data = &appData{}
+			apps[name] = data
+		}
+		switch activity.Spec.Status {
+		case v1.ActivityStatusTypeSucceeded:
+			data.completed++
+			if promoted, ok := activityPromotionTime(activity); ok {
+				data.deployments++
+				data.leadTimes = append(data.leadTimes, promoted.Sub(started.Time))
+			}
+			completed := activityCompletedTime(activity)
+			for _, failure := range data.failures {
+				data.recoveries = append(data.recoveries, completed.Sub(failure))
+			}
+			data.failures = nil
+		case v1.ActivityStatusTypeFailed, v1.ActivityStatusTypeError:
+			data.completed++
+			data.failed++
+			data.failures = append(data.failures, activityCompletedTime(activity))
+		}
+	}
+	names := []string{}
+	for name := range apps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	days := time.Since(since).Hours() / 24
+	answer := []*DoraMetrics{}
+	for _, name := range names {
+		data := apps[name]
+		m := &DoraMetrics{
+			Application: name,
+			Deployments: data.deployments,
+			LeadTime:    meanDuration(data.leadTimes),
+			MTTR:        meanDuration(data.recoveries),
+		}
+		if days > 0 {
+			m.DeploymentsPerDay = float64(data.deployments) / days
+		}
+		if data.completed > 0 {
+			m.ChangeFailureRate = float64(data.failed) / float64(data.completed)
+		}
+		answer = append(answer, m)
+	}
+	return answer
+}
+
+// activityApplication returns the application name of the activity from its
+// pipeline name of the form owner/repository/branch
+func activityApplication(activity *v1.PipelineActivity) string {
+	if activity.Spec.GitRepository != "" {
+		return activity.Spec.GitRepository
+	}
+	parts := strings.Split(activity.Spec.Pipeline, "/")
+	if len(parts) >= 2 {
+		return parts[1]
+	}
+	return activity.Spec.Pipeline
+}
+
+// activityPromotionTime returns when the last successful promotion of the
+// activity completed
+func activityPromotionTime(activity *v1.PipelineActivity) (time.Time, bool) {
+	answer := time.Time{}
+	found := false
+	for _, step := range activity.Spec.Steps {
+		promote := step.Promote
+		if promote == nil || promote.Status != v1.ActivityStatusTypeSucceeded {
+			continue
+		}
+		if promote.CompletedTimestamp != nil && promote.CompletedTimestamp.Time.After(answer) {
+			answer = promote.CompletedTimestamp.Time
+			found = true
+		}
+	}
+	return answer, found
+}
+
+// activityCompletedTime returns when the activity completed falling back to
+// its start time so incomplete activities sort stably
+func activityCompletedTime(activity *v1.PipelineActivity) time.Time {
+	if activity.Spec.CompletedTimestamp != nil {
+		return activity.Spec.CompletedTimestamp.Time
+	}
+	if activity.Spec.StartedTimestamp != nil {
+		return activity.Spec.StartedTimestamp.Time
+	}
+	return time.Time{}
+}
+
+// meanDuration renders the mean of the durations or an empty string when
+// there are none
+func meanDuration(durations []time.Duration) string {
+	if len(durations) == 0 {
+		return ""
+	}
+	total := time.Duration(0)
+	for _, d := range durations {
+		total += d
+	}
+	return (total / time.Duration(len(durations))).Round(time.Second).String()
+}
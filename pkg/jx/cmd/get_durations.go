@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/golang-jenkins"
+	"github.com/jenkins-x/jx/pkg/jenkins"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+)
+
+// GetDurationsOptions the command line options
+type GetDurationsOptions struct {
+	GetOptions
+
+	Filter     string
+	Days       int
+	BuildCount int
+	Stages     bool
+}
+
+// DurationSummary the aggregated durations of a pipeline or one of its stages
+type DurationSummary struct {
+	Pipeline  string `json:"pipeline"`
+	Stage     string `json:"stage,omitempty"`
+	Builds    int    `json:"builds"`
+	Average   string `json:"average"`
+	Deviation string `json:"deviation"`
+	Max       string `json:"max"`
+
+	averageMillis int64
+}
+
+var (
+	get_durations_long = templates.LongDesc(`
+		Display the average build durations of the pipelines.
+
+		The completed builds of each pipeline over the time window are aggregated
+		and ranked by average duration and variance so it is easy to see which
+		pipelines are the slowest or the least predictable and would benefit most
+		from caching or build pack improvements. Use --stages to break the
+		durations down by pipeline stage.
+`)
+
+	get_durations_example = templates.Examples(`
+		# Rank all the pipelines by average build duration over the last week
+		jx get durations
+
+		# Rank the individual pipeline stages over the last month
+		jx get durations --stages --days 30
+
+		# Show the durations of the pipelines of one repository
+		jx get durations --filter myrepo
+	`)
+)
+
+// NewCmdGetDurations creates the command
+func NewCmdGetDurations(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetDurationsOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "durations",
+		Short:   "Display the pipelines ranked by average build duration",
+		Aliases: []string{"duration"},
+		Long:    get_durations_long,
+		Example: get_durations_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Filter, "filter", "f", "", "Filters all the available jobs by those that contain the given text")
+	cmd.Flags().IntVarP(&options.Days, "days", "d", 7, "The number of days of builds to aggregate")
+	cmd.Flags().IntVarP(&options.BuildCount, "build-count", "", 20, "The maximum number of builds per pipeline to aggregate")
+	cmd.Flags().BoolVarP(&options.Stages, "stages", "", false, "Break the durations down by pipeline stage")
+	options.addGetFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GetDurationsOptions) Run() error {
+	jobMap, err := o.getJobMap(o.Filter)
+	if err != nil {
+		return err
+	}
+	jenkinsClient, err := o.JenkinsClient()
+	if err != nil {
+		return err
+	}
+	names := []string{}
+	for name := range jobMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cutoff := time.Now().AddDate(0, 0, -o.Days)
+	cutoffMillis := cutoff.UnixNano() / int64(time.Millisecond)
+
+	samples := map[string][]int64{}
+	for _, name := range names {
+		builds, err := o.completedBuilds(jenkinsClient, jobMap[name], cutoffMillis)
+		if err != nil {
+			o.warnf("Failed to load the builds of %s: %s\n", name, err)
+			continue
+		}
+		for i := range builds {
+			build := &builds[i]
+			if !o.Stages {
+				samples[name] = append(samples[name], int64(build.Duration))
+				continue
+			}
+			run, err := jenkins.GetWorkflowRun(jenkinsClient, build)
+			if err != nil {
+				o.warnf("Failed to load the stages of build %s #%d: %s\n", name, build.Number, err)
+				continue
+			}
+			for _, stage := range run.Stages {
+				if stage.DurationMillis > 0 {
+					key := name + "|" + stage.Name
+					samples[key] = append(samples[key], stage.DurationMillis)
+				}
+			}
+		}
+	}
+	if len(samples) == 0 {
+		return fmt.Errorf("No builds completed in the last %d days. Use --days to aggregate a longer time window", o.Days)
+	}
+
+	answer := []DurationSummary{}
+	for key, durations := range samples {
+		pipeline := key
+		stage := ""
+		if o.Stages {
+			parts := strings.SplitN(key, "|", 2)
+			pipeline = parts[0]
+			if len(parts) > 1 {
+				stage = parts[1]
+			}
+		}
+		average, deviation, max := summariseDurations(durations)
+		answer = append(answer, DurationSummary{
+			Pipeline:      pipeline,
+			Stage:         stage,
+			Builds:        len(durations),
+			Average:       stageDurationText(average),
+			Deviation:     stageDurationText(deviation),
+			Max:           stageDurationText(max),
+			averageMillis: average,
+		})
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		if answer[i].averageMillis != answer[j].averageMillis {
+			return answer[i].averageMillis > answer[j].averageMillis
+		}
+		if answer[i].Pipeline != answer[j].Pipeline {
+			return answer[i].Pipeline < answer[j].Pipeline
+		}
+		return answer[i].Stage < answer[j].Stage
+	})
+
+	if o.Output != "" {
+		return o.renderResult(answer, o.Output)
+	}
+	table := o.CreateTable()
+	if o.Stages {
+		table.AddRow("PIPELINE", "STAGE", "BUILDS", "AVERAGE", "DEVIATION", "MAX")
+		for _, summary := range answer {
+			table.AddRow(summary.Pipeline, summary.Stage, strconv.Itoa(summary.Builds), summary.Average, summary.Deviation, summary.Max)
+		}
+	} else {
+		table.AddRow("PIPELINE", "BUILDS", "AVERAGE", "DEVIATION", "MAX")
+		for _, summary := range answer {
+			table.AddRow(summary.Pipeline, strconv.Itoa(summary.Builds), summary.Average, summary.Deviation, summary.Max)
+		}
+	}
+	table.Render()
+	return nil
+}
+
+// completedBuilds returns the completed builds of the job which finished after
+// the cutoff, newest first
+func (o *GetDurationsOptions) completedBuilds(jenkinsClient *gojenkins.Jenkins, job *gojenkins.Job, cutoffMillis int64) ([]gojenkins.Build, error) {
+	answer := []gojenkins.Build{}
+	last, err := jenkinsClient.GetLastBuild(*job)
+	if err != nil {
+		if jenkinsClient.IsErrNotFound(err) {
+			return answer, nil
+		}
+		return answer, err
+	}
+	lowest := last.Number - o.BuildCount + 1
+	if lowest < 1 {
+		lowest = 1
+	}
+	for number := last.Number; number >= lowest; number-- {
+		build := last
+		if number != last.Number {
+			build, err = jenkinsClient.GetBuild(*job, number)
+			if err != nil {
+				if jenkinsClient.IsErrNotFound(err) {
+					continue
+				}
+				return answer, err
+			}
+		}
+		if int64(build.Timestamp) < cutoffMillis {
+			break
+		}
+		if build.Building || build.Duration <= 0 {
+			continue
+		}
+		answer = append(answer, build)
+	}
+	return answer, nil
+}
+
+// summariseDurations returns the average, standard deviation and maximum of
+// the durations in milliseconds
+func summariseDurations(durations []int64) (int64, int64, int64) {
+	var sum, max int64
+	for _, duration := range durations {
+		sum += duration
+		if duration > max {
+			max = duration
+		}
+	}
+	average := sum / int64(len(durations))
+	var variance float64
+	for _, duration := range durations {
+		diff := float64(duration - average)
+		variance += diff * diff
+	}
+	deviation := int64(math.Sqrt(variance / float64(len(durations))))
+	return average, deviation, max
+}
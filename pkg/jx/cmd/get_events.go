@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetEventsOptions the command line options
+type GetEventsOptions struct {
+	GetOptions
+
+	App         string
+	Environment string
+}
+
+// EventSummary a deduplicated Kubernetes event of an application
+type EventSummary struct {
+	LastSeen string `json:"lastSeen"`
+	Count    int32  `json:"count"`
+	Type     string `json:"type"`
+	Reason   string `json:"reason"`
+	Object   string `json:"object"`
+	Message  string `json:"message"`
+}
+
+var (
+	get_events_long = templates.LongDesc(`
+		Display the Kubernetes events of an application.
+
+		The events of the deployments, pods and ingress of the application are
+		aggregated, deduplicated and sorted by time so the recent history of a
+		promoted release is easy to triage.
+`)
+
+	get_events_example = templates.Examples(`
+		# List the events of the myapp application in the staging environment
+		jx get events --app myapp --env staging
+
+		# List all the events in the current namespace
+		jx get events
+	`)
+)
+
+// NewCmdGetEvents creates the command
+func NewCmdGetEvents(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetEventsOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "events",
+		Short:   "Display the Kubernetes events of an application",
+		Aliases: []string{"event"},
+		Long:    get_events_long,
+		Example: get_events_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.App, "app", "a", "", "Only show the events of the given application")
+	cmd.Flags().StringVarP(&options.Environment, "env", "e", "", "The Environment to show the events of. Defaults to the current namespace")
+	options.addGetFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GetEventsOptions) Run() error {
+	client, currentNs, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns := currentNs
+	if o.Environment != "" {
+		jxClient, _, err := o.JXClient()
+		if err != nil {
+			return err
+		}
+		devNs, _, err := kube.GetDevNamespace(client, currentNs)
+		if err != nil {
+			return err
+		}
+		env, err := jxClient.JenkinsV1().Environments(devNs).Get(o.Environment, metav1.GetOptions{})
+		if err != nil {
+			envNames, nameErr := kube.GetEnvironmentNames(jxClient, devNs)
+			if nameErr == nil {
+				return util.InvalidOption("env", o.Environment, envNames)
+			}
+			return err
+		}
+		if env.Spec.Namespace != "" {
+			ns = env.Spec.Namespace
+		}
+	}
+	events, err := client.CoreV1().Events(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	summaries := map[string]*EventSummary{}
+	lastSeen := map[string]time.Time{}
+	for _, event := range events.Items {
+		if o.App != "" && !eventMatchesApp(&event, o.App) {
+			continue
+		}
+		object := event.InvolvedObject.Name
+		if event.InvolvedObject.Kind != "" {
+			object = strings.ToLower(event.InvolvedObject.Kind) + "/" + object
+		}
+		key := object + "|" + event.Reason + "|" + event.Message
+		when := event.LastTimestamp.Time
+		summary := summaries[key]
+		if summary == nil {
+			summary = &EventSummary{
+				Type:    event.Type,
+				Reason:  event.Reason,
+				Object:  object,
+				Message: event.Message,
+			}
+			summaries[key] = summary
+		}
+		count := event.Count
+		if count == 0 {
+			count = 1
+		}
+		summary.Count += count
+		if when.After(lastSeen[key]) {
+			lastSeen[key] = when
+			summary.LastSeen = time.Since(when).Round(time.Second).String()
+		}
+	}
+
+	keys := []string{}
+	for key := range summaries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return lastSeen[keys[i]].Before(lastSeen[keys[j]])
+	})
+	answer := []EventSummary{}
+	for _, key := range keys {
+		answer = append(answer, *summaries[key])
+	}
+
+	if o.Output != "" {
+		return o.renderResult(answer, o.Output)
+	}
+	if len(answer) == 0 {
+		if o.App != "" {
+			o.Printf("No events found for app %s in namespace %s\n", util.ColorInfo(o.App), util.ColorInfo(ns))
+		} else {
+			o.Printf("No events found in namespace %s\n", util.ColorInfo(ns))
+		}
+		return nil
+	}
+	table := o.CreateTable()
+	table.AddRow("LAST SEEN", "COUNT", "TYPE", "REASON", "OBJECT", "MESSAGE")
+	for _, summary := range answer {
+		reason := summary.Reason
+		if summary.Type == corev1.EventTypeWarning {
+			reason = util.ColorWarning(reason)
+		}
+		table.AddRow(summary.LastSeen, util.Int32ToA(summary.Count), summary.Type, reason, summary.Object, summary.Message)
+	}
+	table.Render()
+	return nil
+}
+
+// eventMatchesApp returns true if the event relates to the deployments, pods
+// or ingress of the given application
+func eventMatchesApp(event *corev1.Event, app string) bool {
+	name := event.InvolvedObject.Name
+	return name == app || strings.HasPrefix(name, app+"-")
+}
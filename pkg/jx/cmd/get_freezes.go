@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetFreezesOptions the command line options
+type GetFreezesOptions struct {
+	GetOptions
+}
+
+var (
+	get_freezes_long = templates.LongDesc(`
+		Display the freeze windows of the team and whether each one is currently
+		blocking promotions.
+`)
+
+	get_freezes_example = templates.Examples(`
+		# List the freeze windows
+		jx get freezes
+	`)
+)
+
+// NewCmdGetFreezes creates the command
+func NewCmdGetFreezes(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetFreezesOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "freezes",
+		Short:   "Display the freeze windows of the team",
+		Aliases: []string{"freeze"},
+		Long:    get_freezes_long,
+		Example: get_freezes_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	options.addGetFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GetFreezesOptions) Run() error {
+	jxClient, ns, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	env, err := jxClient.JenkinsV1().Environments(ns).Get(kube.LabelValueDevEnvironment, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	windows := env.Spec.TeamSettings.FreezeWindows
+	if len(windows) == 0 {
+		return outputEmptyListWarning(o.Out)
+	}
+
+	if o.Output != "" {
+		return o.renderResult(windows, o.Output)
+	}
+
+	now := time.Now()
+	table := o.CreateTable()
+	table.AddRow("NAME", "ENVIRONMENTS", "FROM", "TO", "STATUS", "DESCRIPTION")
+	for i := range windows {
+		window := &windows[i]
+		envText := "all"
+		if len(window.Environments) > 0 {
+			envText = strings.Join(window.Environments, ", ")
+		}
+		table.AddRow(window.Name, envText, freezeTimeText(window.From), freezeTimeText(window.To), freezeStatus(window, now), window.Description)
+	}
+	table.Render()
+	return nil
+}
+
+// freezeTimeText renders a freeze window boundary where the zero time means unbounded
+func freezeTimeText(t metav1.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Time.Format(time.RFC3339)
+}
+
+// freezeStatus returns whether the window is blocking promotions now
+func freezeStatus(window *v1.FreezeWindow, now time.Time) string {
+	if !window.From.IsZero() && now.Before(window.From.Time) {
+		return "Pending"
+	}
+	if !window.To.IsZero() && now.After(window.To.Time) {
+		return "Expired"
+	}
+	return "Active"
+}
@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"io"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
 )
@@ -12,6 +15,8 @@ import (
 // GetGitOptions the command line options
 type GetGitOptions struct {
 	GetOptions
+
+	Capabilities bool
 }
 
 var (
@@ -52,6 +57,7 @@ func NewCmdGetGit(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Com
 		},
 	}
 
+	cmd.Flags().BoolVarP(&options.Capabilities, "capabilities", "", false, "Display the features each git server supports")
 	return cmd
 }
 
@@ -64,15 +70,48 @@ func (o *GetGitOptions) Run() error {
 	config := authConfigSvc.Config()
 
 	table := o.CreateTable()
-	table.AddRow("Name", "Kind", "URL")
+	if o.Capabilities {
+		table.AddRow("Name", "Kind", "URL", "Webhooks", "Pull Requests", "Commit Status", "Forks")
+	} else {
+		table.AddRow("Name", "Kind", "URL", "Users", "Current")
+	}
 
 	for _, s := range config.Servers {
 		kind := s.Kind
 		if kind == "" {
 			kind = "github"
 		}
-		table.AddRow(s.Name, kind, s.URL)
+		if o.Capabilities {
+			c := gits.ProviderCapabilities(kind)
+			table.AddRow(s.Name, kind, s.URL, capabilityText(c.SupportsWebhooks), capabilityText(c.SupportsPullRequests),
+				capabilityText(c.SupportsCommitStatus), capabilityText(c.SupportsForks))
+		} else {
+			table.AddRow(s.Name, kind, s.URL, serverUserNames(s), capabilityText(s.URL == config.CurrentServer))
+		}
 	}
 	table.Render()
 	return nil
 }
+
+// serverUserNames returns the user names configured on the server marking the
+// current user. API tokens are never displayed
+func serverUserNames(server *auth.AuthServer) string {
+	names := []string{}
+	for _, user := range server.Users {
+		name := user.Username
+		if name != "" && name == server.CurrentUser {
+			name = name + "*"
+		}
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func capabilityText(flag bool) string {
+	if flag {
+		return "yes"
+	}
+	return "no"
+}
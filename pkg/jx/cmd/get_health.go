@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// healthErrorRateWarning the error rate fraction at which an application goes amber
+	healthErrorRateWarning = 0.01
+
+	// healthErrorRateCritical the error rate fraction at which an application goes red
+	healthErrorRateCritical = 0.05
+)
+
+// GetHealthOptions the command line options
+type GetHealthOptions struct {
+	GetOptions
+
+	Environment string
+}
+
+var (
+	get_health_long = templates.LongDesc(`
+		Display a health summary of the applications in an environment.
+
+		For each application this aggregates the pod readiness, container restart
+		counts, probe failures and HorizontalPodAutoscaler status into a single
+		status column. If a Prometheus service is available in the environment
+		namespace the recent HTTP error rate per application is included too.
+`)
+
+	get_health_example = templates.Examples(`
+		# Show the health of the applications in the production environment
+		jx get health --env production
+	`)
+)
+
+// NewCmdGetHealth creates the command
+func NewCmdGetHealth(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetHealthOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "health",
+		Short:   "Display a health summary of the applications in an environment",
+		Long:    get_health_long,
+		Example: get_health_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Environment, "env", "e", "", "The environment to report on")
+	return cmd
+}
+
+// appHealth the aggregated health state of one application
+type appHealth struct {
+	pods          string
+	ready         bool
+	restarts      int32
+	probeFailures int32
+	hpa           string
+	hpaAtMax      bool
+	errorRate     float64
+	hasErrorRate  bool
+}
+
+// Run implements this command
+func (o *GetHealthOptions) Run() error {
+	if o.Environment == "" {
+		return util.MissingOption("env")
+	}
+	ns, err := o.environmentNamespace(o.Environment)
+	if err != nil {
+		return err
+	}
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	deployments, err := kube.GetDeployments(client, ns)
+	if err != nil {
+		return fmt.Errorf("Failed to load deployments in namespace %s due to: %s", ns, err)
+	}
+	if len(deployments) == 0 {
+		o.Printf("No applications found in environment %s\n", util.ColorInfo(o.Environment))
+		return nil
+	}
+	podList, err := client.CoreV1().Pods(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to load pods in namespace %s due to: %s", ns, err)
+	}
+	probeFailures := o.probeFailureCounts(client, ns)
+	hpas := map[string]string{}
+	hpaAtMax := map[string]bool{}
+	hpaList, err := client.AutoscalingV1().HorizontalPodAutoscalers(ns).List(metav1.ListOptions{})
+	if err == nil {
+		for _, hpa := range hpaList.Items {
+			target := hpa.Spec.ScaleTargetRef.Name
+			hpas[target] = fmt.Sprintf("%d/%d", hpa.Status.CurrentReplicas, hpa.Spec.MaxReplicas)
+			hpaAtMax[target] = hpa.Status.CurrentReplicas >= hpa.Spec.MaxReplicas
+		}
+	}
+	errorRates := o.prometheusErrorRates(client, ns)
+
+	health := map[string]*appHealth{}
+	names := []string{}
+	for name, d := range deployments {
+		appName := kube.GetAppName(name, ns)
+		h := &appHealth{}
+		var desired int32 = 1
+		if d.Spec.Replicas != nil {
+			desired = *d.Spec.Replicas
+		}
+		h.pods = formatInt32(d.Status.ReadyReplicas) + "/" + formatInt32(desired)
+		h.ready = d.Status.ReadyReplicas >= desired
+		for _, pod := range podList.Items {
+			if !strings.HasPrefix(pod.Name, name+"-") {
+				continue
+			}
+			for _, cs := range pod.Status.ContainerStatuses {
+				h.restarts += cs.RestartCount
+			}
+			h.probeFailures += probeFailures[pod.Name]
+		}
+		h.hpa = hpas[name]
+		h.hpaAtMax = hpaAtMax[name]
+		if rate, ok := errorRates[appName]; ok {
+			h.errorRate = rate
+			h.hasErrorRate = true
+		}
+		health[appName] = h
+		names = append(names, appName)
+	}
+	sort.Strings(names)
+
+	table := o.CreateTable()
+	table.AddRow("APPLICATION", "PODS", "RESTARTS", "PROBE FAILURES", "HPA", "ERROR RATE", "STATUS")
+	for _, name := range names {
+		h := health[name]
+		errorRate := ""
+		if h.hasErrorRate {
+			errorRate = fmt.Sprintf("%.2f%%", h.errorRate*100)
+		}
+		table.AddRow(name, h.pods, formatInt32(h.restarts), formatInt32(h.probeFailures), h.hpa, errorRate, healthStatus(h))
+	}
+	table.Render()
+	return nil
+}
+
+// healthStatus summarises the application health as a coloured status value
+func healthStatus(h *appHealth) string {
+	if !h.ready || (h.hasErrorRate && h.errorRate >= healthErrorRateCritical) {
+		return util.ColorError("CRITICAL")
+	}
+	if h.restarts > 0 || h.probeFailures > 0 || h.hpaAtMax || (h.hasErrorRate && h.errorRate >= healthErrorRateWarning) {
+		return util.ColorWarning("WARNING")
+	}
+	return util.ColorInfo("OK")
+}
+
+// probeFailureCounts returns the number of recent liveness/readiness probe
+// failure events per pod name
+func (o *GetHealthOptions) probeFailureCounts(client *kubernetes.Clientset, ns string) map[string]int32 {
+	answer := map[string]int32{}
+	events, err := client.CoreV1().Events(ns).List(metav1.ListOptions{})
+	if err != nil {
+		o.warnf("Could not load events in namespace %s: %s\n", ns, err)
+		return answer
+	}
+	for _, event := range events.Items {
+		if event.Reason == "Unhealthy" && event.InvolvedObject.Kind == "Pod" {
+			answer[event.InvolvedObject.Name] += event.Count
+		}
+	}
+	return answer
+}
+
+// prometheusErrorRates queries a Prometheus service in the namespace, if there
+// is one, for the HTTP error rate fraction per application over the last hour
+func (o *GetHealthOptions) prometheusErrorRates(client *kubernetes.Clientset, ns string) map[string]float64 {
+	answer := map[string]float64{}
+	prometheusURL := ""
+	for _, name := range []string{"prometheus", "prometheus-server"} {
+		u, err := kube.FindServiceURL(client, ns, name)
+		if err == nil && u != "" {
+			prometheusURL = u
+			break
+		}
+	}
+	if prometheusURL == "" {
+		return answer
+	}
+	query := fmt.Sprintf(`sum(rate(http_requests_total{kubernetes_namespace="%s",status=~"5.."}[1h])) by (app) / sum(rate(http_requests_total{kubernetes_namespace="%s"}[1h])) by (app)`, ns, ns)
+	resp, err := http.Get(util.UrlJoin(prometheusURL, "api/v1/query") + "?query=" + url.QueryEscape(query))
+	if err != nil {
+		o.warnf("Could not query Prometheus at %s: %s\n", prometheusURL, err)
+		return answer
+	}
+	defer resp.Body.Close()
+	result := &prometheusQueryResult{}
+	err = json.NewDecoder(resp.Body).Decode(result)
+	if err != nil {
+		o.warnf("Could not parse the Prometheus response: %s\n", err)
+		return answer
+	}
+	for _, sample := range result.Data.Result {
+		app := sample.Metric["app"]
+		if app == "" || len(sample.Value) < 2 {
+			continue
+		}
+		text, ok := sample.Value[1].(string)
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseFloat(text, 64)
+		if err == nil {
+			answer[app] = value
+		}
+	}
+	return answer
+}
+
+// prometheusQueryResult the subset of the Prometheus query API response we use
+type prometheusQueryResult struct {
+	Status string `json:"status"`
+	Data   struct {
+		Result []struct {
+			Metric map[string]string `json:"metric"`
+			Value  []interface{}     `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
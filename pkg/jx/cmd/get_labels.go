@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// GetLabelsOptions the command line options
+type GetLabelsOptions struct {
+	GetOptions
+
+	Organisation string
+	GitHost      string
+	GitHub       bool
+	Filter       string
+}
+
+var (
+	get_labels_long = templates.LongDesc(`
+		Display the labels of the repositories of an organisation so that drift
+		from the standard label set is easy to spot.
+`)
+
+	get_labels_example = templates.Examples(`
+		# Show the labels of all repositories of an organisation
+		jx get labels --github --org myorg
+	`)
+)
+
+// NewCmdGetLabels creates the command
+func NewCmdGetLabels(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetLabelsOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "labels",
+		Short:   "Display the labels of the repositories of an organisation",
+		Aliases: []string{"label"},
+		Long:    get_labels_long,
+		Example: get_labels_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Organisation, "org", "o", "", "The git provider organisation to query")
+	cmd.Flags().StringVarP(&options.GitHost, "git-host", "g", "", "The Git server host if not using GitHub")
+	cmd.Flags().BoolVarP(&options.GitHub, "github", "", false, "If the repositories are on GitHub")
+	cmd.Flags().StringVarP(&options.Filter, "filter", "", "", "Only show repositories whose name contains this text")
+	return cmd
+}
+
+// Run implements the command
+func (o *GetLabelsOptions) Run() error {
+	if o.Organisation == "" {
+		return util.MissingOption("org")
+	}
+	provider, err := o.gitProviderForHost(o.GitHub, o.GitHost, "Pick the git server of the organisation")
+	if err != nil {
+		return err
+	}
+	ghProvider, ok := provider.(*gits.GitHubProvider)
+	if !ok {
+		return fmt.Errorf("Listing labels is currently only supported for GitHub repositories")
+	}
+	repos, err := provider.ListRepositories(o.Organisation)
+	if err != nil {
+		return fmt.Errorf("Failed to list the repositories of %s due to: %s", o.Organisation, err)
+	}
+	table := o.CreateTable()
+	table.AddRow("REPOSITORY", "LABELS")
+	for _, repo := range repos {
+		if o.Filter != "" && !strings.Contains(repo.Name, o.Filter) {
+			continue
+		}
+		labels, err := ghProvider.ListRepositoryLabels(o.Organisation, repo.Name)
+		if err != nil {
+			o.warnf("Failed to list the labels of %s: %s\n", repo.Name, err)
+			continue
+		}
+		names := []string{}
+		for _, label := range labels {
+			names = append(names, label.Name)
+		}
+		table.AddRow(repo.Name, strings.Join(names, " "))
+	}
+	table.Render()
+	return nil
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// GetMergeQueueOptions the command line options
+type GetMergeQueueOptions struct {
+	GetOptions
+
+	URL   string
+	Label string
+}
+
+var (
+	get_mergequeue_long = templates.LongDesc(`
+		Display the Pull Requests queued for merging in a repository.
+
+		The merge queue contains the open Pull Requests labelled as ready to
+		merge in the order the merge queue controller will process them.
+`)
+
+	get_mergequeue_example = templates.Examples(`
+		# List the merge queue of a repository
+		jx get mergequeue https://github.com/jstrachan/myapp.git
+	`)
+)
+
+// NewCmdGetMergeQueue creates the command
+func NewCmdGetMergeQueue(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetMergeQueueOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "mergequeue [url]",
+		Short:   "Display the Pull Requests queued for merging in a repository",
+		Aliases: []string{"mq"},
+		Long:    get_mergequeue_long,
+		Example: get_mergequeue_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.URL, "url", "u", "", "The git URL of the repository")
+	cmd.Flags().StringVarP(&options.Label, "label", "l", defaultMergeQueueLabel, "The label which queues a Pull Request for merging")
+	return cmd
+}
+
+// Run implements this command
+func (o *GetMergeQueueOptions) Run() error {
+	url := o.URL
+	if url == "" && len(o.Args) > 0 {
+		url = o.Args[0]
+	}
+	if url == "" {
+		return util.MissingOption("url")
+	}
+	gitInfo, err := gits.ParseGitURL(url)
+	if err != nil {
+		return err
+	}
+	provider, err := o.gitProviderForURL(url, "user name to access the merge queue")
+	if err != nil {
+		return err
+	}
+	ghProvider, ok := provider.(*gits.GitHubProvider)
+	if !ok {
+		return fmt.Errorf("The merge queue currently only supports GitHub repositories")
+	}
+	queue, err := mergeQueuePullRequests(ghProvider, gitInfo.Organisation, gitInfo.Name, o.Label)
+	if err != nil {
+		return err
+	}
+	if len(queue) == 0 {
+		o.Printf("The merge queue of %s is empty\n", util.ColorInfo(gitInfo.HttpURL()))
+		return nil
+	}
+	table := o.CreateTable()
+	table.AddRow("PR", "TITLE", "STATUS", "MERGEABLE")
+	for _, pr := range queue {
+		status, err := ghProvider.PullRequestLastCommitStatus(pr)
+		if err != nil {
+			status = ""
+		}
+		mergeable := ""
+		if pr.Mergeable != nil {
+			if *pr.Mergeable {
+				mergeable = "true"
+			} else {
+				mergeable = util.ColorWarning("false")
+			}
+		}
+		table.AddRow("#"+formatInt(pr.Number), pr.Title, mergeQueueStatusText(status), mergeable)
+	}
+	table.Render()
+	return nil
+}
+
+// mergeQueueStatusText colours the commit status for display
+func mergeQueueStatusText(status string) string {
+	switch status {
+	case "success":
+		return util.ColorInfo(status)
+	case "failure", "error":
+		return util.ColorError(status)
+	default:
+		return status
+	}
+}
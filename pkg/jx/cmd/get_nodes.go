@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetNodesOptions containers the CLI options
+type GetNodesOptions struct {
+	GetOptions
+}
+
+var (
+	getNodesLong = templates.LongDesc(`
+		Display the nodes of the cluster with their allocatable and requested
+		resources and summarise how much capacity is consumed by the CI build
+		agents, the preview environments and the permanent environments so
+		that platform admins can right-size the cluster.
+`)
+
+	getNodesExample = templates.Examples(`
+		# List the nodes and the capacity summary
+		jx get nodes
+	`)
+
+	// nodePoolLabels are the well known labels the cloud providers use to mark
+	// the node pool a node belongs to
+	nodePoolLabels = []string{"cloud.google.com/gke-nodepool", "agentpool", "kops.k8s.io/instancegroup"}
+)
+
+// NewCmdGetNodes creates the new command for: jx get nodes
+func NewCmdGetNodes(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetNodesOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "nodes",
+		Short:   "Display the cluster nodes and their capacity",
+		Aliases: []string{"node", "capacity"},
+		Long:    getNodesLong,
+		Example: getNodesExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	return cmd
+}
+
+// Run implements this command
+func (o *GetNodesOptions) Run() error {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	nodes, err := kubeClient.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to list the cluster nodes due to: %s", err)
+	}
+	pods, err := kubeClient.CoreV1().Pods(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to list the pods due to: %s", err)
+	}
+	requests := map[string]*podRequests{}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		r := requests[pod.Spec.NodeName]
+		if r == nil {
+			r = &podRequests{}
+			requests[pod.Spec.NodeName] = r
+		}
+		addPodRequests(r, &pod)
+	}
+
+	table := o.CreateTable()
+	table.AddRow("NAME", "POOL", "STATUS", "CPU REQ/ALLOC", "MEMORY REQ/ALLOC")
+	for _, node := range nodes.Items {
+		r := requests[node.Name]
+		if r == nil {
+			r = &podRequests{}
+		}
+		cpu := node.Status.Allocatable[corev1.ResourceCPU]
+		memory := node.Status.Allocatable[corev1.ResourceMemory]
+		table.AddRow(node.Name, nodePool(&node), nodeStatus(&node),
+			r.cpu.String()+"/"+cpu.String(),
+			r.memory.String()+"/"+memory.String())
+	}
+	table.Render()
+	o.Printf("\n")
+	return o.printCapacitySummary(pods.Items)
+}
+
+// podRequests sums the container resource requests of a set of pods
+type podRequests struct {
+	cpu    resource.Quantity
+	memory resource.Quantity
+}
+
+// addPodRequests adds the container requests of the pod to the sums
+func addPodRequests(r *podRequests, pod *corev1.Pod) {
+	for _, container := range pod.Spec.Containers {
+		if cpu, ok := container.Resources.Requests[corev1.ResourceCPU]; ok {
+			r.cpu.Add(cpu)
+		}
+		if memory, ok := container.Resources.Requests[corev1.ResourceMemory]; ok {
+			r.memory.Add(memory)
+		}
+	}
+}
+
+// printCapacitySummary summarises the requested resources by what consumes
+// them: the CI build agents, previews, environments and everything else
+func (o *GetNodesOptions) printCapacitySummary(pods []corev1.Pod) error {
+	previewNamespaces := map[string]bool{}
+	environmentNamespaces := map[string]bool{}
+	jxClient, ns, err := o.JXClient()
+	if err == nil {
+		envs, err := jxClient.JenkinsV1().Environments(ns).List(metav1.ListOptions{})
+		if err == nil {
+			for _, env := range envs.Items {
+				if env.Spec.Namespace == "" {
+					continue
+				}
+				if env.Spec.Kind == v1.EnvironmentKindTypePreview {
+					previewNamespaces[env.Spec.Namespace] = true
+				} else {
+					environmentNamespaces[env.Spec.Namespace] = true
+				}
+			}
+		}
+	}
+	kubeClient, currentNs, err := o.KubeClient()
+	devNs := currentNs
+	if err == nil {
+		devNs, _, _ = kube.GetDevNamespace(kubeClient, currentNs)
+	}
+	agents := &podRequests{}
+	previews := &podRequests{}
+	environments := &podRequests{}
+	other := &podRequests{}
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		switch {
+		case isAgentPod(pod):
+			addPodRequests(agents, pod)
+		case previewNamespaces[pod.Namespace]:
+			addPodRequests(previews, pod)
+		case environmentNamespaces[pod.Namespace] || pod.Namespace == devNs:
+			addPodRequests(environments, pod)
+		default:
+			addPodRequests(other, pod)
+		}
+	}
+	table := o.CreateTable()
+	table.AddRow("CONSUMER", "CPU REQUESTED", "MEMORY REQUESTED")
+	table.AddRow("CI agents", agents.cpu.String(), agents.memory.String())
+	table.AddRow("Previews", previews.cpu.String(), previews.memory.String())
+	table.AddRow("Environments", environments.cpu.String(), environments.memory.String())
+	table.AddRow("Other", other.cpu.String(), other.memory.String())
+	table.Render()
+	return nil
+}
+
+// isAgentPod returns true if the pod is a Jenkins build agent created by the
+// kubernetes plugin which labels its pods with jenkins=slave
+func isAgentPod(pod *corev1.Pod) bool {
+	return pod.Labels["jenkins"] == "slave"
+}
+
+// nodePool returns the node pool of the node from the well known cloud
+// provider labels
+func nodePool(node *corev1.Node) string {
+	for _, label := range nodePoolLabels {
+		if value := node.Labels[label]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// nodeStatus returns a short status of the node
+func nodeStatus(node *corev1.Node) string {
+	status := "NotReady"
+	if isNodeReady(node) {
+		status = "Ready"
+	}
+	if node.Spec.Unschedulable {
+		status += ",SchedulingDisabled"
+	}
+	return status
+}
@@ -57,6 +57,8 @@ func NewCmdGetPipeline(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobr
 		},
 	}
 
+	cmd.AddCommand(NewCmdGetPipelineGraph(f, out, errOut))
+
 	options.addGetFlags(cmd)
 	return cmd
 }
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/golang-jenkins"
+	"github.com/jenkins-x/jx/pkg/jenkins"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// GetPipelineGraphOptions the command line options
+type GetPipelineGraphOptions struct {
+	GetOptions
+
+	Build  int
+	Filter string
+}
+
+var (
+	get_pipeline_graph_long = templates.LongDesc(`
+		Display the stage graph of a pipeline build in the terminal.
+
+		The stages, their statuses and durations are fetched using the
+		workflow API so you can diagnose a build without the web UI.
+`)
+
+	get_pipeline_graph_example = templates.Examples(`
+		# Display the stage graph of the last build picking the pipeline interactively
+		jx get pipeline graph
+
+		# Display the stage graph of build 12
+		jx get pipeline graph myorg/myrepo/master -b 12
+	`)
+)
+
+// NewCmdGetPipelineGraph creates the command
+func NewCmdGetPipelineGraph(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetPipelineGraphOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "graph [flags]",
+		Short:   "Display the stage graph of a pipeline build",
+		Long:    get_pipeline_graph_long,
+		Example: get_pipeline_graph_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().IntVarP(&options.Build, "build", "b", 0, "The build number to view. Defaults to the last build")
+	cmd.Flags().StringVarP(&options.Filter, "filter", "f", "", "Filters all the available jobs by those that contain the given text")
+	return cmd
+}
+
+// Run implements this command
+func (o *GetPipelineGraphOptions) Run() error {
+	jobMap, err := o.getJobMap(o.Filter)
+	if err != nil {
+		return err
+	}
+	jenkinsClient, err := o.JenkinsClient()
+	if err != nil {
+		return err
+	}
+
+	args := o.Args
+	names := []string{}
+	for k := range jobMap {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return fmt.Errorf("No pipelines have been built!")
+	}
+	if len(args) == 0 {
+		defaultName := ""
+		for _, n := range names {
+			if strings.HasSuffix(n, "/master") {
+				defaultName = n
+				break
+			}
+		}
+		name, err := util.PickNameWithDefault(names, "Which pipeline do you want to view?: ", defaultName)
+		if err != nil {
+			return err
+		}
+		args = []string{name}
+	}
+	name := args[0]
+	job := jobMap[name]
+	if job == nil {
+		return util.InvalidArg(name, names)
+	}
+	var build gojenkins.Build
+	if o.Build > 0 {
+		build, err = jenkinsClient.GetBuild(*job, o.Build)
+		if err != nil {
+			return fmt.Errorf("Failed to find build %d of %s due to: %s", o.Build, name, err)
+		}
+	} else {
+		build, err = jenkinsClient.GetLastBuild(*job)
+		if err != nil {
+			return err
+		}
+	}
+	run, err := jenkins.GetWorkflowRun(jenkinsClient, &build)
+	if err != nil {
+		return err
+	}
+	o.Printf("%s %s #%d %s\n\n", util.ColorStatus("pipeline"), util.ColorInfo(name), build.Number, stageStatusText(run.Status))
+	for i, stage := range run.Stages {
+		o.Printf("  %s %s  %s  %s\n", stageStatusSymbol(stage.Status), util.ColorInfo(stage.Name), stageStatusText(stage.Status), stageDurationText(stage.DurationMillis))
+		detail, err := jenkins.GetWorkflowStage(jenkinsClient, &run.Stages[i])
+		if err == nil {
+			branches := parallelBranchNames(detail)
+			for _, branch := range branches {
+				o.Printf("  │   ├─ %s\n", branch)
+			}
+		}
+		if i < len(run.Stages)-1 {
+			o.Printf("  │\n")
+		}
+	}
+	return nil
+}
+
+// parallelBranchNames returns the names of any parallel branches within the stage
+func parallelBranchNames(stage *jenkins.WorkflowStage) []string {
+	answer := []string{}
+	for _, node := range stage.StageFlowNodes {
+		if strings.HasPrefix(node.Name, "Branch: ") {
+			answer = append(answer, strings.TrimPrefix(node.Name, "Branch: "))
+		}
+	}
+	return answer
+}
+
+func stageStatusSymbol(status string) string {
+	switch status {
+	case "SUCCESS":
+		return util.ColorInfo("●")
+	case "FAILED", "FAILURE", "ABORTED":
+		return util.ColorError("●")
+	case "IN_PROGRESS", "RUNNING":
+		return util.ColorStatus("◐")
+	default:
+		return "○"
+	}
+}
+
+func stageStatusText(status string) string {
+	switch status {
+	case "SUCCESS":
+		return util.ColorInfo(status)
+	case "FAILED", "FAILURE", "ABORTED":
+		return util.ColorError(status)
+	default:
+		return util.ColorStatus(status)
+	}
+}
+
+func stageDurationText(millis int64) string {
+	if millis <= 0 {
+		return ""
+	}
+	d := time.Duration(millis) * time.Millisecond
+	return d.Round(time.Second).String()
+}
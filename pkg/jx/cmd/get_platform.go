@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// platformChartPrefix the chart name prefix of platform releases in helm list output
+const platformChartPrefix = "jenkins-x-platform-"
+
+// platformRelease a deployed platform release of a team namespace
+type platformRelease struct {
+	Release   string `json:"release"`
+	Namespace string `json:"namespace"`
+	Version   string `json:"version"`
+	Status    string `json:"status"`
+}
+
+// GetPlatformOptions the command line options
+type GetPlatformOptions struct {
+	GetOptions
+}
+
+// GetPlatformVersionsOptions the command line options
+type GetPlatformVersionsOptions struct {
+	GetOptions
+}
+
+var (
+	getPlatformVersionsLong = templates.LongDesc(`
+		Display the platform version of each team in the cluster.
+
+		In a shared cluster each team can pin its own platform version so upgrades
+		can be canaried on a single team first via 'jx upgrade platform --team'.
+		This command shows the version spread across the teams.
+`)
+
+	getPlatformVersionsExample = templates.Examples(`
+		# List the platform version of each team
+		jx get platform versions
+	`)
+)
+
+// NewCmdGetPlatform creates the command
+func NewCmdGetPlatform(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetPlatformOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "platform",
+		Short: "Display information about the installed platform",
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.AddCommand(NewCmdGetPlatformVersions(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *GetPlatformOptions) Run() error {
+	return o.Cmd.Help()
+}
+
+// NewCmdGetPlatformVersions creates the command
+func NewCmdGetPlatformVersions(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetPlatformVersionsOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "versions",
+		Short:   "Display the platform version of each team in the cluster",
+		Long:    getPlatformVersionsLong,
+		Example: getPlatformVersionsExample,
+		Aliases: []string{"version"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	options.addGetFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GetPlatformVersionsOptions) Run() error {
+	client, _, err := o.Factory.CreateClient()
+	if err != nil {
+		return err
+	}
+	releases, err := o.listPlatformReleases()
+	if err != nil {
+		return err
+	}
+	releasesByNs := map[string]platformRelease{}
+	for _, release := range releases {
+		releasesByNs[release.Namespace] = release
+	}
+
+	namespaces, err := client.CoreV1().Namespaces().List(metav1.ListOptions{
+		LabelSelector: kube.LabelEnvironment + "=" + kube.LabelValueDevEnvironment,
+	})
+	if err != nil {
+		return err
+	}
+
+	if o.Output != "" {
+		return o.renderResult(releases, o.Output)
+	}
+
+	table := o.CreateTable()
+	table.AddRow("TEAM", "NAMESPACE", "VERSION", "STATUS")
+	versionCounts := map[string]int{}
+	for _, ns := range namespaces.Items {
+		team := ns.Labels[kube.LabelTeam]
+		if team == "" {
+			team = ns.Name
+		}
+		release, found := releasesByNs[ns.Name]
+		if !found {
+			table.AddRow(team, ns.Name, "", "No platform release found")
+			continue
+		}
+		table.AddRow(team, ns.Name, release.Version, release.Status)
+		versionCounts[release.Version]++
+	}
+	table.Render()
+
+	if len(versionCounts) > 1 {
+		versions := []string{}
+		for version := range versionCounts {
+			versions = append(versions, version)
+		}
+		sort.Strings(versions)
+		spread := []string{}
+		for _, version := range versions {
+			spread = append(spread, fmt.Sprintf("%s (%d team(s))", version, versionCounts[version]))
+		}
+		o.Printf("\nThe teams are spread across platform versions: %s\n", util.ColorWarning(strings.Join(spread, ", ")))
+	}
+	return nil
+}
+
+// listPlatformReleases returns the deployed platform releases parsed from the
+// helm release list
+func (o *CommonOptions) listPlatformReleases() ([]platformRelease, error) {
+	output, err := o.getCommandOutput("", "helm", "list")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list the helm releases due to: %s", err)
+	}
+	releases := []platformRelease{}
+	for i, line := range strings.Split(output, "\n") {
+		if i == 0 || strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) < 6 {
+			continue
+		}
+		chart := strings.TrimSpace(fields[4])
+		if !strings.HasPrefix(chart, platformChartPrefix) {
+			continue
+		}
+		releases = append(releases, platformRelease{
+			Release:   strings.TrimSpace(fields[0]),
+			Status:    strings.TrimSpace(fields[3]),
+			Version:   strings.TrimPrefix(chart, platformChartPrefix),
+			Namespace: strings.TrimSpace(fields[5]),
+		})
+	}
+	return releases, nil
+}
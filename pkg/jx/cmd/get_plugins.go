@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jenkins"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// GetPluginsOptions the command line options
+type GetPluginsOptions struct {
+	GetOptions
+}
+
+var (
+	get_plugins_long = templates.LongDesc(`
+		Display the plugins installed on the Jenkins master comparing them against
+		the recommended plugin manifest for the platform.
+
+		Plugins which are behind the recommended version or which have a published
+		security advisory are highlighted so that drift across team masters is easy
+		to spot. Use 'jx upgrade jenkins plugins' to converge them.
+`)
+
+	get_plugins_example = templates.Examples(`
+		# List the Jenkins plugins and any drift from the recommended versions
+		jx get plugins
+	`)
+)
+
+// NewCmdGetPlugins creates the command
+func NewCmdGetPlugins(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetPluginsOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "plugins [flags]",
+		Short:   "Display the Jenkins plugins and any drift from the recommended versions",
+		Long:    get_plugins_long,
+		Example: get_plugins_example,
+		Aliases: []string{"plugin"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	return cmd
+}
+
+// Run implements this command
+func (o *GetPluginsOptions) Run() error {
+	jenk, err := o.JenkinsClient()
+	if err != nil {
+		return err
+	}
+	plugins, err := jenk.GetPlugins(1)
+	if err != nil {
+		return err
+	}
+
+	installed := map[string]string{}
+	for _, plugin := range plugins.Plugins {
+		installed[plugin.ShortName] = plugin.Version
+	}
+
+	table := o.CreateTable()
+	table.AddRow("NAME", "VERSION", "RECOMMENDED", "STATUS")
+	for _, plugin := range plugins.Plugins {
+		recommended := jenkins.RequiredPluginVersion(plugin.ShortName)
+		status := ""
+		if jenkins.IsVulnerablePluginVersion(plugin.ShortName, plugin.Version) {
+			status = util.ColorError("vulnerable")
+		} else if recommended != "" && jenkins.ComparePluginVersions(plugin.Version, recommended) < 0 {
+			status = util.ColorWarning("upgrade available")
+		} else if recommended != "" {
+			status = util.ColorInfo("ok")
+		}
+		table.AddRow(plugin.ShortName, plugin.Version, recommended, status)
+	}
+	for _, required := range jenkins.RequiredPlugins {
+		if _, ok := installed[required.Name]; !ok {
+			table.AddRow(required.Name, "", required.Version, util.ColorError("missing"))
+		}
+	}
+	table.Render()
+	return nil
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	gojenkins "github.com/jenkins-x/golang-jenkins"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+)
+
+// GetQueueOptions containers the CLI options
+type GetQueueOptions struct {
+	GetOptions
+
+	Filter string
+}
+
+var (
+	getQueueLong = templates.LongDesc(`
+		Display the Jenkins build queue with the reason each build is waiting
+		and for how long, so that capacity problems are easy to spot.
+`)
+
+	getQueueExample = templates.Examples(`
+		# List the queued builds
+		jx get queue
+
+		# List the queued builds of a project
+		jx get queue --filter myapp
+	`)
+)
+
+// NewCmdGetQueue creates the new command for: jx get queue
+func NewCmdGetQueue(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetQueueOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "queue",
+		Short:   "Display the Jenkins build queue",
+		Aliases: []string{"queues"},
+		Long:    getQueueLong,
+		Example: getQueueExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Filter, "filter", "f", "", "Filter the queued builds by name")
+	return cmd
+}
+
+// Run implements this command
+func (o *GetQueueOptions) Run() error {
+	jenkins, err := o.JenkinsClient()
+	if err != nil {
+		return err
+	}
+	queue, err := jenkins.GetQueue()
+	if err != nil {
+		return err
+	}
+	items := queue.Items
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].InQueueSince < items[j].InQueueSince
+	})
+	table := o.CreateTable()
+	table.AddRow("NAME", "WAITING", "STATUS", "WHY")
+	count := 0
+	for _, item := range items {
+		name := item.Task.Name
+		if o.Filter != "" && !strings.Contains(name, o.Filter) {
+			continue
+		}
+		count++
+		table.AddRow(name, queueWaitTime(&item, time.Now()), queueItemStatus(&item), item.Why)
+	}
+	table.Render()
+	if count == 0 {
+		o.Printf("The build queue is empty\n")
+	}
+	return nil
+}
+
+// queueWaitTime returns how long the item has been waiting in the queue
+func queueWaitTime(item *gojenkins.Item, now time.Time) string {
+	if item.InQueueSince <= 0 {
+		return ""
+	}
+	since := time.Unix(item.InQueueSince/1000, 0)
+	return now.Sub(since).Round(time.Second).String()
+}
+
+// queueItemStatus returns a short status of the queue item
+func queueItemStatus(item *gojenkins.Item) string {
+	switch {
+	case item.Stuck:
+		return "Stuck"
+	case item.Blocked:
+		return "Blocked"
+	case item.Buildable:
+		return "Buildable"
+	case item.Pending:
+		return "Pending"
+	}
+	return "Waiting"
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// GetReposOptions the command line options
+type GetReposOptions struct {
+	GetOptions
+
+	Organisation string
+	GitHost      string
+	GitHub       bool
+	Managed      bool
+}
+
+var (
+	get_repos_long = templates.LongDesc(`
+		Display the repositories in a git provider organisation.
+
+		Repositories imported into the platform are tagged with the '` + gits.ManagedRepoTopic + `' topic
+		so you can use '--managed' to show only the repositories managed by the platform.
+`)
+
+	get_repos_example = templates.Examples(`
+		# List the repositories in a github organisation
+		jx get repos --github --org myname
+
+		# List only the repositories managed by the platform
+		jx get repos --github --org myname --managed
+	`)
+)
+
+// NewCmdGetRepos creates the command
+func NewCmdGetRepos(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetReposOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "repos",
+		Short:   "Display the repositories in a git provider organisation",
+		Aliases: []string{"repo", "repositories"},
+		Long:    get_repos_long,
+		Example: get_repos_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Organisation, "org", "o", "", "Specify the git provider organisation to list the repositories of")
+	cmd.Flags().StringVarP(&options.GitHost, "git-host", "g", "", "The Git server host if not using GitHub")
+	cmd.Flags().BoolVarP(&options.GitHub, "github", "", false, "If you wish to list the repositories from GitHub")
+	cmd.Flags().BoolVarP(&options.Managed, "managed", "", false, "Only show the repositories managed by the platform")
+	return cmd
+}
+
+// Run implements this command
+func (o *GetReposOptions) Run() error {
+	authConfigSvc, err := o.Factory.CreateGitAuthConfigService()
+	if err != nil {
+		return err
+	}
+	var server *auth.AuthServer
+	config := authConfigSvc.Config()
+	if o.GitHub {
+		server = config.GetOrCreateServer(gits.GitHubHost)
+	} else {
+		if o.GitHost != "" {
+			server = config.GetOrCreateServer(o.GitHost)
+		} else {
+			server, err = config.PickServer("Pick the git server to search for repositories")
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if server == nil {
+		return fmt.Errorf("No git server provided!")
+	}
+	userAuth, err := config.PickServerUserAuth(server, "git user name", o.BatchMode)
+	if err != nil {
+		return err
+	}
+	provider, err := gits.CreateProvider(server, userAuth)
+	if err != nil {
+		return err
+	}
+	org := o.Organisation
+	if org == "" {
+		org, err = gits.PickOrganisation(provider, userAuth.Username)
+		if err != nil {
+			return err
+		}
+	}
+
+	table := o.CreateTable()
+	table.AddRow("NAME", "TOPICS", "URL")
+	count := 0
+	if githubProvider, ok := provider.(*gits.GitHubProvider); ok {
+		// fetch the topics in the same call as the repositories
+		details, err := githubProvider.ListRepositoriesWithDetails(org)
+		if err != nil {
+			return err
+		}
+		for _, detail := range details {
+			if o.Managed && util.StringArrayIndex(detail.Topics, gits.ManagedRepoTopic) < 0 {
+				continue
+			}
+			table.AddRow(detail.Name, strings.Join(detail.Topics, ", "), detail.HTMLURL)
+			count++
+		}
+	} else {
+		repos, err := provider.ListRepositories(org)
+		if err != nil {
+			return err
+		}
+		topicsProvider, supportsTopics := provider.(gits.GitTopicsProvider)
+		if o.Managed && !supportsTopics {
+			return fmt.Errorf("The git provider %s does not support repository topics so cannot filter managed repositories", provider.Label())
+		}
+		for _, repo := range repos {
+			topics := []string{}
+			if supportsTopics {
+				topics, err = topicsProvider.ListRepositoryTopics(org, repo.Name)
+				if err != nil {
+					return err
+				}
+			}
+			if o.Managed && util.StringArrayIndex(topics, gits.ManagedRepoTopic) < 0 {
+				continue
+			}
+			table.AddRow(repo.Name, strings.Join(topics, ", "), repo.HTMLURL)
+			count++
+		}
+	}
+	if count == 0 {
+		return outputEmptyListWarning(o.Out)
+	}
+	table.Render()
+	return nil
+}
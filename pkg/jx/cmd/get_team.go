@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetTeamOptions the command line options
+type GetTeamOptions struct {
+	GetOptions
+}
+
+var (
+	get_team_long = templates.LongDesc(`
+		Display the teams in the current cluster.
+
+		A team is a namespace labelled as a dev environment, typically created
+		via 'jx create team'.
+`)
+
+	get_team_example = templates.Examples(`
+		# List the teams in the cluster
+		jx get teams
+	`)
+)
+
+// NewCmdGetTeam creates the command
+func NewCmdGetTeam(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetTeamOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "teams [flags]",
+		Short:   "Display the teams in the cluster",
+		Long:    get_team_long,
+		Example: get_team_example,
+		Aliases: []string{"team"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	return cmd
+}
+
+// Run implements this command
+func (o *GetTeamOptions) Run() error {
+	client, _, err := o.Factory.CreateClient()
+	if err != nil {
+		return err
+	}
+	options := metav1.ListOptions{
+		LabelSelector: kube.LabelEnvironment + "=" + kube.LabelValueDevEnvironment,
+	}
+	list, err := client.CoreV1().Namespaces().List(options)
+	if err != nil {
+		return err
+	}
+
+	table := o.CreateTable()
+	table.AddRow("NAME", "NAMESPACE", "STATUS")
+	for _, ns := range list.Items {
+		team := ns.Labels[kube.LabelTeam]
+		if team == "" {
+			team = ns.Name
+		}
+		table.AddRow(team, ns.Name, string(ns.Status.Phase))
+	}
+	table.Render()
+	return nil
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+)
+
+// GetTrackerOptions the command line options
+type GetTrackerOptions struct {
+	GetOptions
+}
+
+var (
+	get_tracker_long = templates.LongDesc(`
+		Display the issue tracker server URLs.
+
+`)
+
+	get_tracker_example = templates.Examples(`
+		# List all registered issue tracker server URLs
+		jx get trackers
+	`)
+)
+
+// NewCmdGetTrackers creates the command
+func NewCmdGetTrackers(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetTrackerOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "trackers [flags]",
+		Short:   "Display the current registered issue tracker service URLs",
+		Long:    get_tracker_long,
+		Example: get_tracker_example,
+		Aliases: []string{"tracker", "issuetrackers"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	return cmd
+}
+
+// Run implements this command
+func (o *GetTrackerOptions) Run() error {
+	authConfigSvc, err := o.Factory.CreateIssueTrackerAuthConfigService()
+	if err != nil {
+		return err
+	}
+	config := authConfigSvc.Config()
+
+	table := o.CreateTable()
+	table.AddRow("Name", "Kind", "URL")
+
+	for _, s := range config.Servers {
+		kind := s.Kind
+		if kind == "" {
+			kind = "github"
+		}
+		table.AddRow(s.Name, kind, s.URL)
+	}
+	table.Render()
+	return nil
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetTrainsOptions the command line options
+type GetTrainsOptions struct {
+	GetOptions
+}
+
+var (
+	get_trains_long = templates.LongDesc(`
+		Display the release trains of the team and the promotion progress of each
+		application on them.
+`)
+
+	get_trains_example = templates.Examples(`
+		# List the release trains
+		jx get trains
+	`)
+)
+
+// NewCmdGetTrains creates the command
+func NewCmdGetTrains(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetTrainsOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "trains",
+		Short:   "Display the release trains of the team",
+		Aliases: []string{"train"},
+		Long:    get_trains_long,
+		Example: get_trains_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	options.addGetFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GetTrainsOptions) Run() error {
+	jxClient, ns, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	env, err := jxClient.JenkinsV1().Environments(ns).Get(kube.LabelValueDevEnvironment, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	trains := env.Spec.TeamSettings.ReleaseTrains
+	if len(trains) == 0 {
+		return outputEmptyListWarning(o.Out)
+	}
+
+	if o.Output != "" {
+		return o.renderResult(trains, o.Output)
+	}
+
+	activities, err := jxClient.JenkinsV1().PipelineActivities(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	table := o.CreateTable()
+	table.AddRow("TRAIN", "APP", "VERSION", "STATUS")
+	for _, train := range trains {
+		for _, app := range train.Apps {
+			table.AddRow(train.Name, app.Name, app.Version, trainAppStatus(activities.Items, app.Name, app.Version))
+		}
+	}
+	table.Render()
+	return nil
+}
+
+// trainAppStatus returns the status of the pipeline which released the given
+// version of the app or Pending when there has been no matching pipeline yet
+func trainAppStatus(activities []v1.PipelineActivity, app string, version string) string {
+	status := "Pending"
+	for i := range activities {
+		activity := &activities[i]
+		if activity.Spec.Version != version {
+			continue
+		}
+		if activityApplication(activity) != app {
+			continue
+		}
+		status = string(activity.Spec.Status)
+	}
+	return strings.Title(status)
+}
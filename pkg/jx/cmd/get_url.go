@@ -13,17 +13,25 @@ import (
 // GetURLOptions the command line options
 type GetURLOptions struct {
 	GetOptions
+
+	Namespace string
 }
 
 var (
 	get_url_long = templates.LongDesc(`
 		Display one or many URLs from the running services.
 
+		The URLs are discovered from the exposecontroller annotations of the services
+		falling back to the hosts of the Ingress rules in the namespace.
+
 `)
 
 	get_url_example = templates.Examples(`
 		# List all URLs in this namespace
 		jx get url
+
+		# List all URLs in the staging namespace as YAML
+		jx get url -n jx-staging -o yaml
 	`)
 )
 
@@ -53,20 +61,22 @@ func NewCmdGetURL(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Com
 		},
 	}
 
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace to list the URLs of. Defaults to the current namespace")
+	options.addGetFlags(cmd)
 	return cmd
 }
 
 // Run implements this command
 func (o *GetURLOptions) Run() error {
-	f := o.Factory
-	client, ns, err := f.CreateClient()
+	urls, err := o.serviceURLs()
 	if err != nil {
 		return err
 	}
-	urls, err := kube.FindServiceURLs(client, ns)
-	if err != nil {
-		return err
+
+	if o.Output != "" {
+		return o.renderResult(urls, o.Output)
 	}
+
 	table := o.CreateTable()
 	table.AddRow("Name", "URL")
 
@@ -76,3 +86,37 @@ func (o *GetURLOptions) Run() error {
 	table.Render()
 	return nil
 }
+
+// serviceURLs returns the exposed URLs of the namespace merging the service
+// annotations with the Ingress rules
+func (o *GetURLOptions) serviceURLs() ([]kube.ServiceURL, error) {
+	f := o.Factory
+	client, curNs, err := f.CreateClient()
+	if err != nil {
+		return nil, err
+	}
+	ns := o.Namespace
+	if ns == "" {
+		ns = curNs
+	}
+	urls, err := kube.FindServiceURLs(client, ns)
+	if err != nil {
+		return nil, err
+	}
+	ingressURLs, err := kube.FindIngressURLs(client, ns)
+	if err == nil {
+		for _, ingressURL := range ingressURLs {
+			found := false
+			for _, url := range urls {
+				if url.URL == ingressURL.URL {
+					found = true
+					break
+				}
+			}
+			if !found {
+				urls = append(urls, ingressURL)
+			}
+		}
+	}
+	return urls, nil
+}
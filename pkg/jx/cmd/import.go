@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -8,13 +9,19 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/jenkins-x/golang-jenkins"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/buildpacks"
+	"github.com/jenkins-x/jx/pkg/config"
 	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/jenkins"
-	"github.com/jenkins-x/jx/pkg/jx/cmd/log"
+	"github.com/jenkins-x/jx/pkg/jenkinsfile"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/policies"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1"
@@ -25,6 +32,8 @@ import (
 const (
 	DefaultWritePermissions = 0760
 
+	defaultMaxImportFileSize = 5 * 1024 * 1024
+
 	defaultGitIgnoreFile = `
 .project
 .classpath
@@ -44,108 +53,6 @@ EXPOSE 8080
 COPY target/*.jar /opt/app.jar
 WORKDIR /opt
 CMD ["java", "-jar", "app.jar"]
-`
-
-	// TODO replace with the jx-pipelines-plugin version when its available
-	defaultJenkinsfile = `
-pipeline {
-    agent {
-      label "jenkins-maven"
-    }
-
-    environment {
-      ORG 		= 'jenkinsx'
-      APP_NAME    = '%s'
-      GIT_CREDS = credentials('jenkins-x-git')
-      CHARTMUSEUM_CREDS = credentials('jenkins-x-chartmuseum')
-
-      GIT_USERNAME = "$GIT_CREDS_USR"
-      GIT_API_TOKEN = "$GIT_CREDS_PSW"
-    }
-
-    stages {
-      stage('CI Build and push snapshpt') {
-        when {
-          branch 'PR-*'
-        }
-        environment {
-          PREVIEW_VERSION = "0.0.0-SNAPSHOT-$BRANCH_NAME-$BUILD_NUMBER"
-          PREVIEW_NAMESPACE = "$APP_NAME-$BRANCH_NAME".toLowerCase()
-          HELM_RELEASE = "$PREVIEW_NAMESPACE".toLowerCase()
-        }
-        steps {
-          container('maven') {
-            sh "mvn versions:set -DnewVersion=$PREVIEW_VERSION"
-            sh "mvn install"
-            sh "docker build -f Dockerfile.release -t $JENKINS_X_DOCKER_REGISTRY_SERVICE_HOST:$JENKINS_X_DOCKER_REGISTRY_SERVICE_PORT/$ORG/$APP_NAME:$PREVIEW_VERSION ."
-            sh "docker push $JENKINS_X_DOCKER_REGISTRY_SERVICE_HOST:$JENKINS_X_DOCKER_REGISTRY_SERVICE_PORT/$ORG/$APP_NAME:$PREVIEW_VERSION"
-          }
-
-		  // comment out until draft pack includes preview environment charts
-          //dir ('./charts/preview') {
-          //  container('maven') {
-          //    sh "make preview"
-          //  }
-          //}
-        }
-      }
-
-      stage('Build Release') {
-        when {
-          branch 'master'
-        }
-        steps {
-          container('maven') {
-            // ensure we're not on a detached head
-            sh "git checkout master"
-
-            // until we switch to the new kubernetes / jenkins credential implementation use git credentials store
-            sh "git config --global credential.helper store"
-
-            // so we can retrieve the version in later steps
-            sh "echo \$(jx-release-version) > VERSION"
-            sh "mvn versions:set -DnewVersion=\$(cat VERSION)"
-          }
-
-          dir ('./charts/%s') {
-            container('maven') {
-              sh "make tag"
-            }
-          }
-
-          container('maven') {
-            sh 'mvn clean deploy'
-            sh "docker build -f Dockerfile.release -t $JENKINS_X_DOCKER_REGISTRY_SERVICE_HOST:$JENKINS_X_DOCKER_REGISTRY_SERVICE_PORT/$ORG/$APP_NAME:\$(cat VERSION) ."
-            sh "docker push $JENKINS_X_DOCKER_REGISTRY_SERVICE_HOST:$JENKINS_X_DOCKER_REGISTRY_SERVICE_PORT/$ORG/$APP_NAME:\$(cat VERSION)"
-            sh 'jx step changelog --version \$(cat VERSION)'
-          }
-        }
-      }
-
-      stage('Promote to Environments') {
-        environment {
-          GIT_USERNAME = "$GIT_CREDS_USR"
-          GIT_API_TOKEN = "$GIT_CREDS_PSW"
-        }
-        when {
-          branch 'master'
-        }
-        steps {
-          dir ('./charts/%s') {
-            container('maven') {
-
-              // release the helm chart
-              sh 'make release'
-
-              // promote through all 'Auto' promotion Environments
-              sh 'jx promote -b --all-auto --timeout 1h --version \$(cat ../../VERSION)'
-            }
-          }
-        }
-      }
-
-    }
-  }
 `
 )
 
@@ -166,6 +73,20 @@ type ImportOptions struct {
 	DisableJenkinsfileCheck bool
 	SelectFilter            string
 	Jenkinsfile             string
+	JobExistsMode           string
+	WatchFirstBuild         bool
+	PolicyDir               string
+	DisablePolicies         bool
+	MaxFileSize             int64
+	BranchProtection        bool
+	Owners                  []string
+	BuildTags               bool
+	ForkPRTrust             string
+	OnlyProtectedBranches   bool
+	UpdateReadme            bool
+	JenkinsfileTemplates    string
+	JenkinsfileTemplateURL  string
+	Monorepo                bool
 	GitRepositoryOptions    gits.GitRepositoryOptions
 	ImportGitCommitMessage  string
 
@@ -201,7 +122,10 @@ var (
 		jx import --github --org myname --all 
 
         # Import all repositories from a github organisation which contain the text foo
-		jx import --github --org myname --all --filter foo 
+		jx import --github --org myname --all --filter foo
+
+        # Import a monorepo creating one Jenkins job per sub-project
+		jx import --monorepo
 		`)
 )
 
@@ -245,11 +169,25 @@ func (options *ImportOptions) addImportFlags(cmd *cobra.Command, createProject b
 	cmd.Flags().StringVarP(&options.Organisation, "org", "", "", "Specify the git provider organisation to import the project into (if it is not already in one)")
 	cmd.Flags().StringVarP(&options.Repository, "name", "", notCreateProject("n"), "Specify the git repository name to import the project into (if it is not already in one)")
 	cmd.Flags().StringVarP(&options.Credentials, "credentials", notCreateProject("c"), "", "The Jenkins credentials name used by the job")
-	cmd.Flags().StringVarP(&options.Jenkinsfile, "jenkinsfile", notCreateProject("j"), "", "The name of the Jenkinsfile to use. If not specified then 'Jenkinsfile' will be used")
-	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Performs local changes to the repo but skips the import into Jenkins X")
+	cmd.Flags().StringVarP(&options.Jenkinsfile, "jenkinsfile", notCreateProject("j"), "", "The name of the Jenkinsfile to use, e.g. 'Jenkinsfile.jx'. If not specified then 'Jenkinsfile' will be used and generated if it is missing")
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Performs local changes to the repo then prints the planned remote changes (repository creation, Jenkins job XML) without making them")
 	cmd.Flags().BoolVarP(&options.DisableDraft, "no-draft", "", false, "Disable Draft from trying to default a Dockerfile and Helm Chart")
 	cmd.Flags().BoolVarP(&options.DisableJenkinsfileCheck, "no-jenkinsfile", "", false, "Disable defaulting a Jenkinsfile if its missing")
 	cmd.Flags().StringVarP(&options.ImportGitCommitMessage, "import-commit-message", "", "", "The git commit message for the import")
+	cmd.Flags().StringVarP(&options.JobExistsMode, "job-exists", "", "", "What to do if the job already exists in Jenkins: "+strings.Join(jenkins.JobExistsModes, ", ")+". If not specified you will be asked")
+	cmd.Flags().BoolVarP(&options.WatchFirstBuild, "watch-first-build", "", false, "Watch the first build of the imported project and triage any failure")
+	cmd.Flags().StringVarP(&options.PolicyDir, "policies", "", "", "A directory of rego policies to evaluate against the project with conftest before importing")
+	cmd.Flags().BoolVarP(&options.DisablePolicies, "no-policy", "", false, "Disable the policy checks on the project before importing")
+	cmd.Flags().Int64VarP(&options.MaxFileSize, "max-file-size", "", defaultMaxImportFileSize, "Warn about any staged file larger than this size in bytes before the initial commit")
+	cmd.Flags().BoolVarP(&options.BranchProtection, "branch-protection", "", false, "Protect the master branch of the repository after the import")
+	cmd.Flags().StringArrayVarP(&options.Owners, "owners", "", []string{}, "The code owners to seed a CODEOWNERS file with. Defaults to the team import settings")
+	cmd.Flags().BoolVarP(&options.BuildTags, "build-tags", "", false, "Discover and build tags as well as branches")
+	cmd.Flags().StringVarP(&options.ForkPRTrust, "fork-pr-trust", "", "", "The trust policy for building Pull Requests from forks: "+strings.Join(jenkins.ForkPRTrustPolicies, ", ")+". Defaults to none so fork Pull Requests are not built")
+	cmd.Flags().BoolVarP(&options.OnlyProtectedBranches, "protected-branches-only", "", false, "Only discover and build protected branches")
+	cmd.Flags().BoolVarP(&options.UpdateReadme, "update-readme", "", false, "Insert a CI status badge and build documentation into the README of the project, creating one if needed")
+	cmd.Flags().StringVarP(&options.JenkinsfileTemplates, "jenkinsfile-templates", "", "", "A directory of Jenkinsfile templates named <language>.tmpl or default.tmpl which override the built in pipeline templates")
+	cmd.Flags().StringVarP(&options.JenkinsfileTemplateURL, "jenkinsfile-template-url", "", "", "The URL of a Jenkinsfile template to download and render instead of the built in pipeline templates")
+	cmd.Flags().BoolVarP(&options.Monorepo, "monorepo", "", false, "Import each first level sub-directory which has its own Jenkinsfile or detectable build as a separate Jenkins multibranch job")
 
 	options.addCommonFlags(cmd)
 	addGitRepoOptionsArguments(cmd, &options.GitRepositoryOptions)
@@ -273,6 +211,8 @@ func (o *ImportOptions) Run() error {
 	o.currentNamespace = ns
 	o.kubeClient = client
 
+	o.applyTeamImportDefaults()
+
 	if o.GitHub {
 		return o.ImportProjectsFromGitHub()
 	}
@@ -290,7 +230,7 @@ func (o *ImportOptions) Run() error {
 	}
 	_, o.AppName = filepath.Split(o.Dir)
 
-	checkForJenkinsfile := o.Jenkinsfile == "" && !o.DisableJenkinsfileCheck
+	checkForJenkinsfile := !o.DisableJenkinsfileCheck
 	shouldClone := checkForJenkinsfile || !o.DisableDraft
 
 	if o.RepoURL != "" {
@@ -334,6 +274,41 @@ func (o *ImportOptions) Run() error {
 		}
 	}
 
+	err = o.applyResourceProfile()
+	if err != nil {
+		return err
+	}
+
+	if o.UpdateReadme {
+		err = o.addReadmeDocumentation()
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(o.Owners) > 0 {
+		err = o.CreateDefaultCodeOwnersFile()
+		if err != nil {
+			return err
+		}
+	}
+
+	if !o.DisablePolicies {
+		err = o.VerifyPolicies()
+		if err != nil {
+			return err
+		}
+	}
+
+	err = o.verifyLocalGitState()
+	if err != nil {
+		return err
+	}
+
+	if o.DryRun {
+		return o.reportDryRun()
+	}
+
 	if o.RepoURL == "" {
 		err = o.CreateNewRemoteRepository()
 		if err != nil {
@@ -353,12 +328,16 @@ func (o *ImportOptions) Run() error {
 		return err
 	}
 
-	if o.DryRun {
-		log.Infof("dry-run so skipping import to Jenkins X")
-		return nil
+	err = o.DoImport()
+	if err != nil {
+		return err
 	}
-
-	return o.DoImport()
+	o.tagRepository()
+	o.verifyPodTemplates()
+	if o.WatchFirstBuild {
+		return o.watchFirstBuild()
+	}
+	return nil
 }
 
 func (o *ImportOptions) ImportProjectsFromGitHub() error {
@@ -380,35 +359,50 @@ func (o *ImportOptions) ImportProjectsFromGitHub() error {
 	username := userAuth.Username
 	org := o.Organisation
 	if org == "" {
+		if o.BatchMode {
+			return util.MissingOption("org")
+		}
 		org, err = gits.PickOrganisation(provider, username)
 		if err != nil {
 			return err
 		}
 	}
-	repos, err := gits.PickRepositories(provider, org, "Which repositories do you want to import", o.SelectAll, o.SelectFilter)
+	repos, err := gits.PickRepositories(provider, org, "Which repositories do you want to import", o.BatchMode, o.SelectAll, o.SelectFilter)
 	if err != nil {
 		return err
 	}
 
 	o.Printf("Selected repositories\n")
+	failed := []string{}
 	for _, r := range repos {
 		o2 := ImportOptions{
 			CommonOptions:           o.CommonOptions,
-			Dir:                     o.Dir,
 			RepoURL:                 r.CloneURL,
 			Organisation:            org,
 			Repository:              r.Name,
+			Credentials:             o.Credentials,
+			JobExistsMode:           o.JobExistsMode,
+			BuildTags:               o.BuildTags,
+			ForkPRTrust:             o.ForkPRTrust,
+			OnlyProtectedBranches:   o.OnlyProtectedBranches,
 			Jenkins:                 o.Jenkins,
 			GitProvider:             provider,
 			DisableJenkinsfileCheck: o.DisableJenkinsfileCheck,
 			DisableDraft:            o.DisableDraft,
 		}
 		o.Printf("Importing repository %s\n", util.ColorInfo(r.Name))
-		err = o2.Run()
+		// import straight into Jenkins without cloning each repository so that
+		// a whole organisation can be imported in one go
+		err = o2.DoImport()
 		if err != nil {
-			return err
+			o.warnf("Failed to import repository %s: %s\n", r.Name, err)
+			failed = append(failed, r.Name)
 		}
 	}
+	if len(failed) > 0 {
+		return fmt.Errorf("Failed to import %d of the %d repositories: %s", len(failed), len(repos), strings.Join(failed, ", "))
+	}
+	o.Printf("Imported %s repositories into Jenkins\n", util.ColorInfo(fmt.Sprintf("%d", len(repos))))
 	return nil
 }
 
@@ -418,13 +412,15 @@ func (o *ImportOptions) DraftCreate() error {
 	// TODO this is a workaround of this draft issue:
 	// https://github.com/Azure/draft/issues/476
 	dir := o.Dir
-	pomName := filepath.Join(dir, "pom.xml")
-	exists, err := util.FileExists(pomName)
+	pack, err := buildpacks.Detect(dir)
 	if err != nil {
 		return err
 	}
-	if exists {
-		args = []string{"create", "--pack=github.com/jenkins-x/draft-repo/packs/java"}
+	if pack != nil {
+		o.Printf("Detected a %s project\n", util.ColorInfo(pack.Language))
+		if pack.DraftPack != "" {
+			args = []string{"create", "--pack=" + pack.DraftPack}
+		}
 	}
 	e := exec.Command("draft", args...)
 	e.Dir = dir
@@ -439,7 +435,7 @@ func (o *ImportOptions) DraftCreate() error {
 	// chart expects folder name to be the same as app name
 	oldChartsDir := filepath.Join(dir, "charts", "java")
 	newChartsDir := filepath.Join(dir, "charts", o.AppName)
-	exists, err = util.FileExists(oldChartsDir)
+	exists, err := util.FileExists(oldChartsDir)
 	if err != nil {
 		return err
 	}
@@ -465,7 +461,19 @@ func (o *ImportOptions) DraftCreate() error {
 		return err
 	}
 
-	err = gits.GitAdd(dir, "*")
+	// static sites have no draft pack so generate an nginx preview chart for them
+	contentDir, err := staticSiteDir(dir)
+	if err != nil {
+		return err
+	}
+	if contentDir != "" {
+		err = o.createStaticSitePreviewChart(dir, contentDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = o.stageProjectFiles(dir)
 	if err != nil {
 		return err
 	}
@@ -476,10 +484,88 @@ func (o *ImportOptions) DraftCreate() error {
 	return nil
 }
 
+// jenkinsfileName returns the name of the Jenkinsfile of the project which is
+// generated, committed and referenced as the script path of the Jenkins job
+func (o *ImportOptions) jenkinsfileName() string {
+	if o.Jenkinsfile != "" {
+		return o.Jenkinsfile
+	}
+	return jenkins.DefaultJenkinsfile
+}
+
 func (o *ImportOptions) DefaultJenkinsfile() error {
+	if o.Monorepo {
+		projects, err := o.monorepoProjects()
+		if err != nil {
+			return err
+		}
+		if len(projects) == 0 {
+			return fmt.Errorf("No sub-projects found in %s. A sub-project is a first level directory with its own %s or a build detectable by a build pack", o.Dir, o.jenkinsfileName())
+		}
+		o.Printf("Found %s sub-projects in the monorepo: %s\n", util.ColorInfo(len(projects)), util.ColorInfo(strings.Join(projects, ", ")))
+		for _, project := range projects {
+			err = o.defaultProjectJenkinsfile(project)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		err := o.defaultProjectJenkinsfile("")
+		if err != nil {
+			return err
+		}
+	}
+	return gits.GitCommitIfChanges(o.Dir, "Added default Jenkinsfile pipeline")
+}
 
-	dir := o.Dir
-	name := filepath.Join(dir, "Jenkinsfile")
+// monorepoSkipDirs are directories which are never considered to be the
+// sub-projects of a monorepo
+var monorepoSkipDirs = []string{"charts", "docs", "node_modules", "vendor"}
+
+// monorepoProjects returns the first level sub-directories of the repository
+// which contain their own Jenkinsfile or a build detectable by a build pack
+func (o *ImportOptions) monorepoProjects() ([]string, error) {
+	answer := []string{}
+	files, err := ioutil.ReadDir(o.Dir)
+	if err != nil {
+		return answer, err
+	}
+	for _, f := range files {
+		if !f.IsDir() || strings.HasPrefix(f.Name(), ".") || util.StringArrayIndex(monorepoSkipDirs, f.Name()) >= 0 {
+			continue
+		}
+		dir := filepath.Join(o.Dir, f.Name())
+		exists, err := util.FileExists(filepath.Join(dir, o.jenkinsfileName()))
+		if err != nil {
+			return answer, err
+		}
+		if !exists {
+			pack, err := buildpacks.Detect(dir)
+			if err != nil {
+				return answer, err
+			}
+			if pack == nil {
+				continue
+			}
+		}
+		answer = append(answer, f.Name())
+	}
+	return answer, nil
+}
+
+// defaultProjectJenkinsfile generates the Jenkinsfile of the project in the
+// given sub-directory when it does not have one yet. An empty project means
+// the repository root
+func (o *ImportOptions) defaultProjectJenkinsfile(project string) error {
+	dir := filepath.Join(o.Dir, project)
+	appName := o.AppName
+	chartPrefix := "."
+	if project != "" {
+		appName = project
+		chartPrefix = "./" + project
+	}
+	relativeName := filepath.Join(project, o.jenkinsfileName())
+	name := filepath.Join(o.Dir, relativeName)
 	exists, err := util.FileExists(name)
 	if err != nil {
 		return err
@@ -488,20 +574,243 @@ func (o *ImportOptions) DefaultJenkinsfile() error {
 		return nil
 	}
 
-	data := []byte(fmt.Sprintf(defaultJenkinsfile, o.AppName, o.AppName, o.AppName))
-	err = ioutil.WriteFile(name, data, DefaultWritePermissions)
+	pack, err := buildpacks.Detect(dir)
+	if err != nil {
+		return err
+	}
+	language := "maven"
+	model := jenkinsfile.Model{
+		AppName:      appName,
+		Organisation: "jenkinsx",
+		ChartDir:     chartPrefix + "/charts/" + appName,
+		AgentLabel:   "jenkins-maven",
+		Container:    "maven",
+	}
+	if pack != nil {
+		language = pack.Language
+		model.AgentLabel = pack.AgentLabel
+		model.Container = pack.Container
+		model.BuildCommand = pack.BuildCommand
+	}
+	text := ""
+	if o.JenkinsfileTemplateURL != "" {
+		text, err = jenkinsfile.GenerateFromURL(o.JenkinsfileTemplateURL, model)
+		if err != nil {
+			return err
+		}
+		o.Printf("Generated the pipeline from the template %s\n", util.ColorInfo(o.JenkinsfileTemplateURL))
+	} else {
+		text, err = jenkinsfile.Generate(language, model, o.JenkinsfileTemplates)
+		if err != nil {
+			return fmt.Errorf("Failed to generate the %s pipeline due to: %s", language, err)
+		}
+		if pack != nil && language != "maven" {
+			o.Printf("Generated a %s pipeline\n", util.ColorInfo(language))
+		}
+	}
+	projectConfig, err := config.LoadProjectConfig(dir)
+	if err != nil {
+		return err
+	}
+	if projectConfig.Matrix != nil {
+		text, err = generateMatrixJenkinsfile(appName, projectConfig.Matrix)
+		if err != nil {
+			return fmt.Errorf("Failed to generate the matrix pipeline due to: %s", err)
+		}
+		o.Printf("Generated a matrix pipeline with %d parallel stages\n", len(projectConfig.Matrix.Axes))
+	}
+	settings := o.teamSettings()
+	if settings != nil && settings.AgentScheduling.EvictionRetryCount > 0 {
+		text = addPipelineRetryOptions(text, settings.AgentScheduling.EvictionRetryCount)
+		o.Printf("Added retry(%d) to the pipeline so that builds survive spot node evictions\n", settings.AgentScheduling.EvictionRetryCount)
+	}
+	if settings != nil && (settings.PipelinePolicies.StageTimeoutMinutes > 0 || settings.PipelinePolicies.StageRetryCount > 0) {
+		text = addPipelineStagePolicies(text, settings.PipelinePolicies.StageTimeoutMinutes, settings.PipelinePolicies.StageRetryCount)
+		o.Printf("Applied the team stage timeout and retry policies to the pipeline\n")
+	}
+	err = ioutil.WriteFile(name, []byte(text), DefaultWritePermissions)
 	if err != nil {
 		return fmt.Errorf("Failed to write %s due to %s", name, err)
 	}
-	err = gits.GitAdd(dir, "Jenkinsfile")
+	return gits.GitAdd(o.Dir, relativeName)
+}
+
+// applyResourceProfile applies the resource profile selected in the
+// jenkins-x.yml of the project: it derives a pod template with the profile
+// resources in the Jenkins configuration and points the agent label of the
+// Jenkinsfile at it
+func (o *ImportOptions) applyResourceProfile() error {
+	projectConfig, err := config.LoadProjectConfig(o.Dir)
 	if err != nil {
 		return err
 	}
-	err = gits.GitCommitIfChanges(dir, "Added default Jenkinsfile pipeline")
+	name := projectConfig.ResourceProfile
+	if name == "" {
+		return nil
+	}
+	profiles := o.teamResourceProfiles()
+	profile := jenkins.FindResourceProfile(profiles, name)
+	if profile == nil {
+		return fmt.Errorf("No resource profile called %s is defined for the team. The available profiles are: %s", name, strings.Join(jenkins.ResourceProfileNames(profiles), ", "))
+	}
+	jenkinsfileName := filepath.Join(o.Dir, o.jenkinsfileName())
+	exists, err := util.FileExists(jenkinsfileName)
+	if err != nil || !exists {
+		return err
+	}
+	data, err := ioutil.ReadFile(jenkinsfileName)
+	if err != nil {
+		return fmt.Errorf("Failed to load file %s: %s", jenkinsfileName, err)
+	}
+	text := string(data)
+	label := jenkins.FindJenkinsfileAgentLabel(text)
+	if label == "" {
+		o.warnf("Could not find an agent label in %s so the resource profile %s cannot be applied\n", jenkinsfileName, name)
+		return nil
+	}
+	if strings.HasSuffix(label, "-"+name) {
+		// the Jenkinsfile already uses the profile label
+		return nil
+	}
+	profileLabel, err := o.ensureProfilePodTemplate(label, profile)
 	if err != nil {
 		return err
 	}
-	return nil
+	text = strings.Replace(text, `"`+label+`"`, `"`+profileLabel+`"`, -1)
+	text = strings.Replace(text, `'`+label+`'`, `'`+profileLabel+`'`, -1)
+	err = ioutil.WriteFile(jenkinsfileName, []byte(text), DefaultWritePermissions)
+	if err != nil {
+		return fmt.Errorf("Failed to write %s due to %s", jenkinsfileName, err)
+	}
+	o.Printf("Applied the resource profile %s: the pipeline now uses the agent label %s\n", util.ColorInfo(name), util.ColorInfo(profileLabel))
+	err = gits.GitAdd(o.Dir, o.jenkinsfileName())
+	if err != nil {
+		return err
+	}
+	return gits.GitCommitIfChanges(o.Dir, "Use the "+name+" resource profile")
+}
+
+// teamResourceProfiles returns the resource profiles of the team falling back
+// to the default small / medium / large presets
+func (o *ImportOptions) teamResourceProfiles() []jenkins.ResourceProfile {
+	settings := o.teamSettings()
+	if settings != nil && len(settings.ResourceProfiles) > 0 {
+		answer := []jenkins.ResourceProfile{}
+		for _, p := range settings.ResourceProfiles {
+			answer = append(answer, jenkins.ResourceProfile{
+				Name:          p.Name,
+				RequestCpu:    p.RequestCpu,
+				RequestMemory: p.RequestMemory,
+				LimitCpu:      p.LimitCpu,
+				LimitMemory:   p.LimitMemory,
+			})
+		}
+		return answer
+	}
+	return jenkins.DefaultResourceProfiles
+}
+
+// ensureProfilePodTemplate makes sure the Jenkins configuration has a pod
+// template for the given agent label with the resources of the profile and
+// returns its agent label
+func (o *ImportOptions) ensureProfilePodTemplate(label string, profile *jenkins.ResourceProfile) (string, error) {
+	kubeClient, currentNs, err := o.KubeClient()
+	if err != nil {
+		return "", err
+	}
+	ns, _, err := kube.GetDevNamespace(kubeClient, currentNs)
+	if err != nil {
+		return "", err
+	}
+	configMaps := kubeClient.CoreV1().ConfigMaps(ns)
+	cm, err := configMaps.Get(kube.ConfigMapJenkins, meta_v1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("Failed to load the Jenkins configuration from the ConfigMap %s in namespace %s due to: %s", kube.ConfigMapJenkins, ns, err)
+	}
+	configXml := cm.Data[kube.ConfigMapKeyConfigXml]
+	if configXml == "" {
+		return "", fmt.Errorf("The ConfigMap %s in namespace %s does not contain a %s entry", kube.ConfigMapJenkins, ns, kube.ConfigMapKeyConfigXml)
+	}
+	updated, profileLabel, err := jenkins.EnsureResourceProfilePodTemplate(configXml, label, profile)
+	if err != nil {
+		return "", err
+	}
+	if updated != configXml {
+		cm.Data[kube.ConfigMapKeyConfigXml] = updated
+		_, err = configMaps.Update(cm)
+		if err != nil {
+			return "", fmt.Errorf("Failed to update the ConfigMap %s in namespace %s due to: %s", kube.ConfigMapJenkins, ns, err)
+		}
+		o.Printf("Added the pod template for the agent label %s to the Jenkins configuration. Reload the configuration or restart Jenkins to pick it up\n", util.ColorInfo(profileLabel))
+	}
+	return profileLabel, nil
+}
+
+// addPipelineRetryOptions adds an options block with a retry to the generated
+// declarative pipeline so that builds are retried when the agent is evicted
+// from a preempted spot node
+func addPipelineRetryOptions(jenkinsfile string, count int) string {
+	idx := strings.Index(jenkinsfile, "pipeline {")
+	if idx < 0 {
+		return jenkinsfile
+	}
+	lineEnd := strings.Index(jenkinsfile[idx:], "\n")
+	if lineEnd < 0 {
+		return jenkinsfile
+	}
+	insertAt := idx + lineEnd + 1
+	options := fmt.Sprintf("    options {\n      retry(%d)\n    }\n", count)
+	return jenkinsfile[:insertAt] + options + jenkinsfile[insertAt:]
+}
+
+// addPipelineStagePolicies inserts an options block into each stage of the
+// generated declarative pipeline applying the stage timeout and retry policies
+// of the team. Stages holding parallel stages are skipped since the policies
+// are applied to the nested stages instead
+func addPipelineStagePolicies(jenkinsfile string, timeoutMinutes int, retryCount int) string {
+	if timeoutMinutes <= 0 && retryCount <= 0 {
+		return jenkinsfile
+	}
+	lines := strings.Split(jenkinsfile, "\n")
+	answer := []string{}
+	for i, line := range lines {
+		answer = append(answer, line)
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "stage(") || !strings.HasSuffix(trimmed, "{") {
+			continue
+		}
+		if !stageContainsSteps(lines, i) {
+			continue
+		}
+		indent := line[0:strings.Index(line, "stage(")]
+		answer = append(answer, indent+"  options {")
+		if timeoutMinutes > 0 {
+			answer = append(answer, fmt.Sprintf("%s    timeout(time: %d, unit: 'MINUTES')", indent, timeoutMinutes))
+		}
+		if retryCount > 0 {
+			answer = append(answer, fmt.Sprintf("%s    retry(%d)", indent, retryCount))
+		}
+		answer = append(answer, indent+"  }")
+	}
+	return strings.Join(answer, "\n")
+}
+
+// stageContainsSteps returns true if the stage starting at the given line
+// declares its own steps rather than nesting parallel stages
+func stageContainsSteps(lines []string, stageLine int) bool {
+	for i := stageLine + 1; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, "parallel") {
+			return false
+		}
+		if strings.HasPrefix(trimmed, "steps") {
+			return true
+		}
+		if strings.HasPrefix(trimmed, "stage(") {
+			return false
+		}
+	}
+	return false
 }
 
 func (o *ImportOptions) DefaultDockerfile() error {
@@ -515,7 +824,15 @@ func (o *ImportOptions) DefaultDockerfile() error {
 	if exists {
 		return nil
 	}
-	data := []byte(defaultDockerfile)
+	dockerfile := defaultDockerfile
+	contentDir, err := staticSiteDir(dir)
+	if err != nil {
+		return err
+	}
+	if contentDir != "" {
+		dockerfile = fmt.Sprintf(defaultStaticSiteDockerfile, contentDir)
+	}
+	data := []byte(dockerfile)
 	err = ioutil.WriteFile(name, data, DefaultWritePermissions)
 	if err != nil {
 		return fmt.Errorf("Failed to write %s due to %s", name, err)
@@ -531,6 +848,57 @@ func (o *ImportOptions) DefaultDockerfile() error {
 	return nil
 }
 
+// reportDryRun prints the changes the import would make without any remote
+// modifications: the generated files, the git repository which would be
+// created or pushed to and the Jenkins job XML which would be posted
+func (o *ImportOptions) reportDryRun() error {
+	o.Printf("%s no remote changes will be made\n\n", util.ColorStatus("dry-run:"))
+	generated := []string{o.jenkinsfileName(), "Dockerfile", ".gitignore", filepath.Join("charts", o.AppName)}
+	for _, name := range generated {
+		exists, err := util.FileExists(filepath.Join(o.Dir, name))
+		if err == nil && exists {
+			o.Printf("generated %s\n", util.ColorInfo(name))
+		}
+	}
+	if o.RepoURL == "" {
+		owner := o.Organisation
+		if owner == "" {
+			owner = o.GitRepositoryOptions.Owner
+		}
+		name := o.Repository
+		if name == "" {
+			name = o.AppName
+		}
+		if owner != "" {
+			name = owner + "/" + name
+		}
+		o.Printf("would create the git repository %s and push the master branch to it\n", util.ColorInfo(name))
+		o.Printf("would create a Jenkins job for the new repository\n")
+		return nil
+	}
+	o.Printf("would push the local commits to %s\n", util.ColorInfo(o.RepoURL))
+	if o.GitProvider == nil {
+		o.Printf("would create a Jenkins job for %s\n", util.ColorInfo(o.RepoURL))
+		return nil
+	}
+	gitInfo, err := gits.ParseGitURL(o.RepoURL)
+	if err != nil {
+		return err
+	}
+	jenkinsfile := o.jenkinsfileName()
+	behaviours := &jenkins.BranchSourceBehaviours{
+		BuildTags:             o.BuildTags,
+		ForkPRTrust:           o.ForkPRTrust,
+		OnlyProtectedBranches: o.OnlyProtectedBranches,
+	}
+	xml, err := jenkins.CreateMultiBranchProjectXml(gitInfo, o.GitProvider, o.Credentials, jenkinsfile, behaviours)
+	if err != nil {
+		return err
+	}
+	o.Printf("would post this Jenkins job XML:\n\n%s\n", xml)
+	return nil
+}
+
 func (o *ImportOptions) CreateNewRemoteRepository() error {
 	f := o.Factory
 	authConfigSvc, err := f.CreateGitAuthConfigService()
@@ -539,6 +907,12 @@ func (o *ImportOptions) CreateNewRemoteRepository() error {
 	}
 	dir := o.Dir
 	_, defaultRepoName := filepath.Split(dir)
+	if o.Repository != "" {
+		defaultRepoName = o.Repository
+	}
+	if o.Organisation != "" {
+		o.GitRepositoryOptions.Owner = o.Organisation
+	}
 
 	details, err := gits.PickNewGitRepository(o.Out, o.BatchMode, authConfigSvc, defaultRepoName, o.GitRepositoryOptions)
 	if err != nil {
@@ -564,6 +938,20 @@ func (o *ImportOptions) CreateNewRemoteRepository() error {
 		return err
 	}
 	o.Printf("Pushed git repository to %s\n\n", util.ColorInfo(repo.HTMLURL))
+
+	if o.BranchProtection {
+		provider, ok := o.GitProvider.(*gits.GitHubProvider)
+		if !ok {
+			o.warnf("Branch protection is not yet supported for this git provider\n")
+		} else {
+			err = provider.ProtectBranch(details.Organisation, repo.Name, "master")
+			if err != nil {
+				o.warnf("Failed to protect the master branch: %s\n", err)
+			} else {
+				o.Printf("Protected the %s branch of %s\n", util.ColorInfo("master"), util.ColorInfo(details.FullName))
+			}
+		}
+	}
 	return nil
 }
 
@@ -594,6 +982,87 @@ func (o *ImportOptions) CloneRepository() error {
 	return nil
 }
 
+// verifyLocalGitState checks for uncommitted changes and diverged history before
+// pushing so we do not silently commit or lose a developer's half finished work
+func (o *ImportOptions) verifyLocalGitState() error {
+	dir := o.Dir
+	dirty, err := gits.HasChanges(dir)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		o.Printf("%s the directory %s has uncommitted changes\n", util.ColorWarning("WARNING:"), util.ColorInfo(dir))
+		if o.BatchMode {
+			return fmt.Errorf("The directory %s has uncommitted changes. Commit or stash them then try again", dir)
+		}
+		action := ""
+		prompt := &survey.Select{
+			Message: "What would you like to do with the uncommitted changes?",
+			Options: []string{"commit", "stash", "abort"},
+			Default: "commit",
+		}
+		err = survey.AskOne(prompt, &action, nil)
+		if err != nil {
+			return err
+		}
+		switch action {
+		case "commit":
+			message := ""
+			input := &survey.Input{
+				Message: "Commit message: ",
+				Default: "Changes before import",
+			}
+			err = survey.AskOne(input, &message, nil)
+			if err != nil {
+				return err
+			}
+			err = gits.GitAdd(dir, ".")
+			if err != nil {
+				return err
+			}
+			err = gits.GitCommitIfChanges(dir, message)
+			if err != nil {
+				return err
+			}
+		case "stash":
+			err = gits.GitStash(dir)
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("Aborting the import due to uncommitted changes in %s", dir)
+		}
+	}
+	ahead, behind, hasUpstream, err := gits.GitAheadBehindUpstream(dir)
+	if err != nil {
+		return err
+	}
+	if hasUpstream {
+		if ahead > 0 {
+			o.Printf("The current branch is %d commit(s) ahead of its upstream. They will be pushed during the import\n", ahead)
+		}
+		if behind > 0 {
+			o.Printf("%s the current branch is %d commit(s) behind its upstream so the histories have diverged\n", util.ColorWarning("WARNING:"), behind)
+			if o.BatchMode {
+				return fmt.Errorf("The current branch in %s is behind its upstream. Pull or rebase then try again", dir)
+			}
+			flag := false
+			confirm := &survey.Confirm{
+				Message: "Continue with the import anyway?",
+				Default: false,
+			}
+			err = survey.AskOne(confirm, &flag, nil)
+			if err != nil {
+				return err
+			}
+			if !flag {
+				return fmt.Errorf("Aborting the import due to diverged history in %s", dir)
+			}
+		}
+	}
+	return nil
+}
+
 // DiscoverGit checks if there is a git clone or prompts the user to import it
 func (o *ImportOptions) DiscoverGit() error {
 	if !o.DisableDotGitSearch {
@@ -642,7 +1111,7 @@ func (o *ImportOptions) DiscoverGit() error {
 	if err != nil {
 		return err
 	}
-	err = gits.GitAdd(dir, "*")
+	err = o.stageProjectFiles(dir)
 	if err != nil {
 		return err
 	}
@@ -675,6 +1144,185 @@ func (o *ImportOptions) DiscoverGit() error {
 	return nil
 }
 
+// stageProjectFiles stages the project files respecting the .gitignore rules and
+// any team wide exclusion patterns then warns about any large files left staged
+func (o *ImportOptions) stageProjectFiles(dir string) error {
+	err := gits.GitAdd(dir, ".")
+	if err != nil {
+		return err
+	}
+	for _, pattern := range o.teamImportExcludes() {
+		err = gits.GitRemoveFromIndex(dir, pattern)
+		if err != nil {
+			return err
+		}
+	}
+	files, err := gits.GitListStagedFiles(dir)
+	if err != nil {
+		return err
+	}
+	largeFiles := []string{}
+	for _, file := range files {
+		info, err := os.Stat(filepath.Join(dir, file))
+		if err != nil {
+			continue
+		}
+		if o.MaxFileSize > 0 && info.Size() > o.MaxFileSize {
+			o.Printf("%s the file %s is %d bytes which is larger than the limit of %d bytes\n",
+				util.ColorWarning("WARNING:"), util.ColorInfo(file), info.Size(), o.MaxFileSize)
+			largeFiles = append(largeFiles, file)
+		}
+	}
+	if len(largeFiles) > 0 {
+		return o.handleLargeFiles(dir, largeFiles)
+	}
+	return nil
+}
+
+// handleLargeFiles lets the user track large staged files with git-lfs or drop them
+// from the index so that the push is not rejected by the git provider size limits
+func (o *ImportOptions) handleLargeFiles(dir string, files []string) error {
+	if o.BatchMode {
+		o.Printf("Consider tracking the large files with git-lfs or adding them to the .gitignore or the team importExcludes setting\n")
+		return nil
+	}
+	action := ""
+	prompt := &survey.Select{
+		Message: "What would you like to do with the large files?",
+		Options: []string{"track with git-lfs", "exclude", "keep"},
+		Default: "track with git-lfs",
+	}
+	err := survey.AskOne(prompt, &action, nil)
+	if err != nil {
+		return err
+	}
+	switch action {
+	case "track with git-lfs":
+		_, err = exec.LookPath("git-lfs")
+		if err != nil {
+			return fmt.Errorf("Could not find git-lfs on your PATH. Please install it from https://git-lfs.github.com/ then try again")
+		}
+		for _, file := range files {
+			err = gits.GitLFSTrack(dir, file)
+			if err != nil {
+				return err
+			}
+			// remove and re-stage the file so that the git-lfs clean filter applies
+			err = gits.GitRemoveFromIndex(dir, file)
+			if err != nil {
+				return err
+			}
+			err = gits.GitAdd(dir, file)
+			if err != nil {
+				return err
+			}
+		}
+		err = gits.GitAdd(dir, ".gitattributes")
+		if err != nil {
+			return err
+		}
+	case "exclude":
+		for _, file := range files {
+			err = gits.GitRemoveFromIndex(dir, file)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// teamSettings returns the team settings from the dev environment or nil if they
+// cannot be loaded
+func (o *ImportOptions) teamSettings() *v1.TeamSettings {
+	jxClient, ns, err := o.JXClient()
+	if err != nil {
+		return nil
+	}
+	env, err := jxClient.JenkinsV1().Environments(ns).Get(kube.LabelValueDevEnvironment, meta_v1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+	return &env.Spec.TeamSettings
+}
+
+// teamImportExcludes returns the glob patterns the team excludes from the initial
+// import commit. Failing to load the team settings is not fatal
+func (o *ImportOptions) teamImportExcludes() []string {
+	settings := o.teamSettings()
+	if settings == nil {
+		return nil
+	}
+	return settings.ImportExcludes
+}
+
+// applyTeamImportDefaults defaults any import options which the user has not set
+// explicitly from the team settings so that imports comply with the organisation
+// policy
+func (o *ImportOptions) applyTeamImportDefaults() {
+	settings := o.teamSettings()
+	if settings == nil {
+		return
+	}
+	defaults := settings.ImportDefaults
+	if o.Credentials == "" {
+		o.Credentials = defaults.Credentials
+	}
+	if o.Jenkinsfile == "" {
+		o.Jenkinsfile = defaults.JenkinsfileTemplate
+	}
+	if defaults.NoDraft {
+		o.DisableDraft = true
+	}
+	if defaults.PrivateRepos {
+		o.GitRepositoryOptions.Private = true
+	}
+	if defaults.BranchProtection {
+		o.BranchProtection = true
+	}
+	if len(o.Owners) == 0 {
+		o.Owners = defaults.Owners
+	}
+	if defaults.BuildTags {
+		o.BuildTags = true
+	}
+	if o.ForkPRTrust == "" {
+		o.ForkPRTrust = defaults.ForkPRTrust
+	}
+	if defaults.OnlyProtectedBranches {
+		o.OnlyProtectedBranches = true
+	}
+}
+
+// CreateDefaultCodeOwnersFile seeds a CODEOWNERS file from the owners so that
+// reviews are requested from the right people on new Pull Requests
+func (o *ImportOptions) CreateDefaultCodeOwnersFile() error {
+	dir := o.Dir
+	for _, name := range []string{"CODEOWNERS", filepath.Join(".github", "CODEOWNERS"), filepath.Join("docs", "CODEOWNERS"), "OWNERS"} {
+		exists, err := util.FileExists(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		if exists {
+			return nil
+		}
+	}
+	line := "*"
+	for _, owner := range o.Owners {
+		line += " @" + strings.TrimPrefix(owner, "@")
+	}
+	name := filepath.Join(dir, "CODEOWNERS")
+	err := ioutil.WriteFile(name, []byte(line+"\n"), DefaultWritePermissions)
+	if err != nil {
+		return fmt.Errorf("Failed to write %s due to %s", name, err)
+	}
+	err = gits.GitAdd(dir, "CODEOWNERS")
+	if err != nil {
+		return err
+	}
+	return gits.GitCommitIfChanges(dir, "Added CODEOWNERS from the team import defaults")
+}
+
 // DiscoverGit checks if there is a git clone or prompts the user to import it
 func (o *ImportOptions) DefaultGitIgnore() error {
 	name := filepath.Join(o.Dir, ".gitignore")
@@ -751,11 +1399,180 @@ func (o *ImportOptions) DoImport() error {
 	if err != nil {
 		return err
 	}
-	jenkinsfile := o.Jenkinsfile
-	if jenkinsfile == "" {
-		jenkinsfile = jenkins.DefaultJenkinsfile
+	jenkinsfile := o.jenkinsfileName()
+	if o.ForkPRTrust != "" && util.StringArrayIndex(jenkins.ForkPRTrustPolicies, o.ForkPRTrust) < 0 {
+		return util.InvalidArg(o.ForkPRTrust, jenkins.ForkPRTrustPolicies)
+	}
+	behaviours := &jenkins.BranchSourceBehaviours{
+		BuildTags:             o.BuildTags,
+		ForkPRTrust:           o.ForkPRTrust,
+		OnlyProtectedBranches: o.OnlyProtectedBranches,
+	}
+	if o.Monorepo {
+		gitInfo, err := gits.ParseGitURL(gitURL)
+		if err != nil {
+			return fmt.Errorf("Failed to parse git URL %s due to: %s", gitURL, err)
+		}
+		projects, err := o.monorepoProjects()
+		if err != nil {
+			return err
+		}
+		for _, project := range projects {
+			jobName := gitInfo.Name + "-" + project
+			scriptPath := project + "/" + jenkinsfile
+			o.Printf("Creating the job %s for the %s pipeline %s\n", util.ColorInfo(jobName), util.ColorInfo(project), util.ColorInfo(scriptPath))
+			err = jenkins.ImportProject(o.Out, o.Jenkins, gitURL, jobName, scriptPath, o.Credentials, false, o.JobExistsMode, o.BatchMode, gitProvider, authConfigSvc, behaviours)
+			if err != nil {
+				return fmt.Errorf("Failed to import the %s sub-project due to: %s", project, err)
+			}
+		}
+		return nil
+	}
+	return jenkins.ImportProject(o.Out, o.Jenkins, gitURL, "", jenkinsfile, o.Credentials, false, o.JobExistsMode, o.BatchMode, gitProvider, authConfigSvc, behaviours)
+}
+
+// tagRepository tags the repository on the git provider with topics marking it
+// as managed by the platform along with the team and the detected build pack
+// so that dashboards and org wide tooling can discover it. Not all providers
+// support topics so this only warns on problems
+func (o *ImportOptions) tagRepository() {
+	gitInfo, err := gits.ParseGitURL(o.RepoURL)
+	if err != nil {
+		o.warnf("Failed to parse git URL %s due to: %s\n", o.RepoURL, err)
+		return
+	}
+	gitProvider := o.GitProvider
+	if gitProvider == nil {
+		gitProvider, err = o.gitProviderForURL(o.RepoURL, "user name to tag the repository")
+		if err != nil {
+			o.warnf("%s\n", err)
+			return
+		}
+	}
+	topicsProvider, ok := gitProvider.(gits.GitTopicsProvider)
+	if !ok {
+		return
+	}
+	topics := []string{gits.ManagedRepoTopic}
+	kubeClient, ns, err := o.KubeClient()
+	if err == nil {
+		team, _, err := kube.GetDevNamespace(kubeClient, ns)
+		if err == nil && team != "" {
+			topics = append(topics, repoTopic("team-"+team))
+		}
+	}
+	pack, err := buildpacks.Detect(o.Dir)
+	if err == nil && pack != nil {
+		topics = append(topics, repoTopic(pack.Language))
+	}
+	existing, err := topicsProvider.ListRepositoryTopics(gitInfo.Organisation, gitInfo.Name)
+	if err != nil {
+		o.warnf("%s\n", err)
+		return
+	}
+	changed := false
+	for _, topic := range topics {
+		if topic != "" && util.StringArrayIndex(existing, topic) < 0 {
+			existing = append(existing, topic)
+			changed = true
+		}
+	}
+	if changed {
+		err = topicsProvider.UpdateRepositoryTopics(gitInfo.Organisation, gitInfo.Name, existing)
+		if err != nil {
+			o.warnf("%s\n", err)
+			return
+		}
 	}
-	return jenkins.ImportProject(o.Out, o.Jenkins, gitURL, jenkinsfile, o.Credentials, false, gitProvider, authConfigSvc)
+	o.Printf("Repository %s/%s has topics: %s\n", util.ColorInfo(gitInfo.Organisation), util.ColorInfo(gitInfo.Name), util.ColorInfo(strings.Join(existing, ", ")))
+}
+
+// repoTopic converts the text to a valid repository topic: lower case with
+// hyphens in place of any other punctuation
+func repoTopic(text string) string {
+	buffer := &bytes.Buffer{}
+	for _, c := range strings.ToLower(text) {
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-' {
+			buffer.WriteRune(c)
+		} else {
+			buffer.WriteRune('-')
+		}
+	}
+	return strings.Trim(buffer.String(), "-")
+}
+
+// verifyPodTemplates checks that the agent label used by the Jenkinsfile has a
+// pod template and that the cluster can schedule it, only warning on problems
+// so that the import itself still succeeds
+func (o *ImportOptions) verifyPodTemplates() {
+	verify := &StepVerifyPodOptions{
+		StepOptions: StepOptions{
+			CommonOptions: o.CommonOptions,
+		},
+		Dir: o.Dir,
+	}
+	err := verify.Run()
+	if err != nil {
+		o.warnf("%s\n", err)
+		o.warnf("You can rerun this check later via: jx step verify pod\n")
+	}
+}
+
+// watchFirstBuild follows the first build of the imported project and on failure
+// fetches the console log and prints advice for any known failure patterns
+func (o *ImportOptions) watchFirstBuild() error {
+	gitInfo, err := gits.ParseGitURL(o.RepoURL)
+	if err != nil {
+		return fmt.Errorf("Failed to parse git URL %s due to: %s", o.RepoURL, err)
+	}
+	jenk := o.Jenkins
+	jobPath := append(gitInfo.OrganisationFolders(), gitInfo.Name, "master")
+	jobName := strings.Join(jobPath, "/")
+
+	var job gojenkins.Job
+	err = o.retry(60, time.Second*2, func() error {
+		var err error
+		job, err = jenk.GetJobByPath(jobPath...)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to find the job %s due to: %s", jobName, err)
+	}
+	var build gojenkins.Build
+	err = o.retry(60, time.Second*2, func() error {
+		var err error
+		build, err = jenk.GetLastBuild(job)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("No build of %s started: %s", jobName, err)
+	}
+	err = o.tailBuild(jobName, &build)
+	if err != nil {
+		return err
+	}
+	build, err = jenk.GetBuild(job, build.Number)
+	if err != nil {
+		return fmt.Errorf("Failed to check the result of build %s #%d due to: %s", jobName, build.Number, err)
+	}
+	if build.Result == "SUCCESS" {
+		o.Printf("The first build of %s %s\n", util.ColorInfo(jobName), util.ColorInfo("succeeded"))
+		return nil
+	}
+	o.Printf("The first build of %s finished with result %s\n", util.ColorInfo(jobName), util.ColorError(build.Result))
+	buildLog, err := jenk.GetBuildConsoleOutput(build)
+	if err != nil {
+		return fmt.Errorf("Failed to fetch the console log of build %s #%d due to: %s", jobName, build.Number, err)
+	}
+	advices := jenkins.ClassifyBuildFailure(string(buildLog))
+	if len(advices) == 0 {
+		o.Printf("Could not match the failure against a known cause. The full log is at %sconsole\n", build.Url)
+		return fmt.Errorf("First build of %s failed", jobName)
+	}
+	for _, advice := range advices {
+		o.Printf("%s: %s\n", util.ColorWarning(advice.Name), advice.Advice)
+	}
+	return fmt.Errorf("First build of %s failed", jobName)
 }
 
 func (o *ImportOptions) addAppNameToGeneratedFile(filename, field, value string) error {
@@ -789,6 +1606,35 @@ func (o *ImportOptions) addAppNameToGeneratedFile(filename, field, value string)
 	return nil
 }
 
+// VerifyPolicies evaluates the team policies against the project before the repository
+// is pushed and the Jenkins job created, failing the import on any hard policy failure
+func (o *ImportOptions) VerifyPolicies() error {
+	config, err := policies.LoadConfig(o.Dir)
+	if err != nil {
+		return err
+	}
+	violations, err := config.VerifyProject(o.Dir, o.AppName)
+	if err != nil {
+		return err
+	}
+	regoViolations, err := policies.VerifyRegoPolicies(o.Dir, o.PolicyDir)
+	if err != nil {
+		return err
+	}
+	violations = append(violations, regoViolations...)
+	for _, v := range violations {
+		if v.Severity == policies.SeverityFailure {
+			o.Printf("%s policy %s: %s\n", util.ColorError("FAILURE:"), v.Policy, v.Message)
+		} else {
+			o.Printf("%s policy %s: %s\n", util.ColorWarning("WARNING:"), v.Policy, v.Message)
+		}
+	}
+	if policies.HasFailures(violations) {
+		return fmt.Errorf("The project violates one or more policies. Use --no-policy to skip the policy checks")
+	}
+	return nil
+}
+
 func (o *ImportOptions) checkChartmuseumCredentialExists() error {
 
 	name := jenkins.DefaultJenkinsCredentialsPrefix + jenkins.Chartmuseum
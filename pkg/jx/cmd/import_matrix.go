@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/jenkins-x/jx/pkg/config"
+)
+
+// matrixJenkinsfileTemplate renders a declarative pipeline which builds the
+// project across the matrix axes as parallel stages. Each axis gets its own
+// agent so that the stages run with distinct pod templates and Jenkins
+// aggregates the stage results into the overall build status
+const matrixJenkinsfileTemplate = `
+pipeline {
+    agent none
+
+    environment {
+      ORG         = 'jenkinsx'
+      APP_NAME    = '{{.AppName}}'
+    }
+
+    stages {
+      stage('Matrix Build') {
+        parallel {
+{{- range .Axes}}
+          stage('{{.Name}}') {
+            agent {
+              label "{{.Label}}"
+            }
+            steps {
+              container('{{.Container}}') {
+                sh "{{.Command}}"
+              }
+            }
+          }
+{{- end}}
+        }
+      }
+    }
+}
+`
+
+// matrixAxisModel is the template model of one resolved matrix axis
+type matrixAxisModel struct {
+	Name      string
+	Label     string
+	Container string
+	Command   string
+}
+
+// matrixModel is the template model of the matrix Jenkinsfile
+type matrixModel struct {
+	AppName string
+	Axes    []matrixAxisModel
+}
+
+// generateMatrixJenkinsfile renders the Jenkinsfile for the build matrix
+// declared in the project configuration
+func generateMatrixJenkinsfile(appName string, matrix *config.MatrixConfig) (string, error) {
+	model := matrixModel{
+		AppName: appName,
+	}
+	for _, axis := range matrix.Axes {
+		container := axis.Container
+		if container == "" {
+			container = strings.TrimPrefix(axis.Label, "jenkins-")
+		}
+		command := axis.Command
+		if command == "" {
+			command = matrix.Command
+		}
+		model.Axes = append(model.Axes, matrixAxisModel{
+			Name:      axis.Name,
+			Label:     axis.Label,
+			Container: container,
+			Command:   command,
+		})
+	}
+	tmpl, err := template.New("matrix").Parse(matrixJenkinsfileTemplate)
+	if err != nil {
+		return "", err
+	}
+	buffer := &bytes.Buffer{}
+	err = tmpl.Execute(buffer, model)
+	if err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
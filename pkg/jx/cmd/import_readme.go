@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// readmeBuildSectionHeader marks the generated build documentation in the
+// README so that re-importing a project does not duplicate it
+const readmeBuildSectionHeader = "## How this repository is built"
+
+// readmeBuildSection is the onboarding documentation appended to the README
+// of imported projects so it stays consistent across all the repositories
+const readmeBuildSection = `
+%s
+
+This repository is built by [Jenkins X](https://jenkins-x.io/) using the
+pipeline defined in the [Jenkinsfile](Jenkinsfile).
+
+* watch the pipeline activity via: ` + "`jx get activity -f %s`" + `
+* browse the build logs via: ` + "`jx get build logs %s`" + `
+* promote a released version to an environment via: ` + "`jx promote %s --version 1.2.3 --env production`" + `
+
+### Preview environments
+
+Each Pull Request gets its own preview environment so that the change can be
+reviewed running in the cluster before it is merged. A link to the preview is
+commented on the Pull Request once it is ready.
+`
+
+// addReadmeDocumentation inserts the CI badge and the build documentation
+// into the README of the project creating one when it does not exist
+func (o *ImportOptions) addReadmeDocumentation() error {
+	dir := o.Dir
+	name := filepath.Join(dir, "README.md")
+	text := ""
+	exists, err := util.FileExists(name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		data, err := ioutil.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		text = string(data)
+	} else {
+		text = fmt.Sprintf("# %s\n", o.AppName)
+	}
+	if strings.Contains(text, readmeBuildSectionHeader) {
+		return nil
+	}
+	org := o.Organisation
+	if org == "" && o.RepoURL != "" {
+		gitInfo, err := gits.ParseGitURL(o.RepoURL)
+		if err == nil {
+			org = gitInfo.Organisation
+		}
+	}
+	badge := o.buildStatusBadge(org)
+	if badge != "" {
+		text = insertReadmeBadge(text, badge)
+	}
+	job := o.AppName
+	if org != "" {
+		job = org + "/" + o.AppName
+	}
+	text += fmt.Sprintf(readmeBuildSection, readmeBuildSectionHeader, o.AppName, job, o.AppName)
+	err = ioutil.WriteFile(name, []byte(text), DefaultWritePermissions)
+	if err != nil {
+		return fmt.Errorf("Failed to write %s due to %s", name, err)
+	}
+	err = gits.GitAdd(dir, "README.md")
+	if err != nil {
+		return err
+	}
+	return gits.GitCommitIfChanges(dir, "Added the build documentation to the README")
+}
+
+// buildStatusBadge returns the markdown of the Jenkins build status badge of
+// the master branch or an empty string when the Jenkins URL is not available
+func (o *ImportOptions) buildStatusBadge(org string) string {
+	jenkinsURL, err := o.Factory.GetJenkinsURL()
+	if err != nil || jenkinsURL == "" {
+		return ""
+	}
+	jenkinsURL = strings.TrimSuffix(jenkinsURL, "/")
+	job := o.AppName
+	jobPath := "job/" + o.AppName
+	if org != "" {
+		job = org + "/" + o.AppName
+		jobPath = "job/" + org + "/job/" + o.AppName
+	}
+	return fmt.Sprintf("[![Build Status](%s/buildStatus/icon?job=%s/master)](%s/%s/)",
+		jenkinsURL, job, jenkinsURL, jobPath)
+}
+
+// insertReadmeBadge adds the badge below the title of the README or at the
+// top when there is no title
+func insertReadmeBadge(text string, badge string) string {
+	if strings.Contains(text, "buildStatus/icon") {
+		return text
+	}
+	lines := strings.Split(text, "\n")
+	if len(lines) > 0 && strings.HasPrefix(lines[0], "#") {
+		answer := []string{lines[0], "", badge}
+		answer = append(answer, lines[1:]...)
+		return strings.Join(answer, "\n")
+	}
+	return badge + "\n\n" + text
+}
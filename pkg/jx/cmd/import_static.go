@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	defaultStaticSiteDockerfile = `FROM nginx:alpine
+COPY %s /usr/share/nginx/html
+EXPOSE 80
+`
+
+	staticPreviewChartYaml = `apiVersion: v1
+description: Preview chart for the %s static site
+name: preview
+version: 0.1.0-SNAPSHOT
+`
+
+	staticPreviewValuesYaml = `image:
+  repository: draft
+  tag: dev
+  pullPolicy: IfNotPresent
+service:
+  type: ClusterIP
+  internalPort: 80
+  externalPort: 80
+
+# the preview URL of the backing API if there is one; it is exposed to the
+# site as the BACKEND_URL environment variable
+backendURL: ""
+`
+
+	staticPreviewDeploymentYaml = `apiVersion: extensions/v1beta1
+kind: Deployment
+metadata:
+  name: {{ .Release.Name }}
+  labels:
+    app: {{ .Release.Name }}
+spec:
+  replicas: 1
+  template:
+    metadata:
+      labels:
+        app: {{ .Release.Name }}
+    spec:
+      containers:
+      - name: {{ .Release.Name }}
+        image: "{{ .Values.image.repository }}:{{ .Values.image.tag }}"
+        imagePullPolicy: {{ .Values.image.pullPolicy }}
+        env:
+        - name: BACKEND_URL
+          value: {{ .Values.backendURL | quote }}
+        ports:
+        - containerPort: {{ .Values.service.internalPort }}
+`
+
+	staticPreviewServiceYaml = `apiVersion: v1
+kind: Service
+metadata:
+  name: {{ .Release.Name }}
+  labels:
+    app: {{ .Release.Name }}
+  annotations:
+    fabric8.io/expose: "true"
+spec:
+  type: {{ .Values.service.type }}
+  ports:
+  - port: {{ .Values.service.externalPort }}
+    targetPort: {{ .Values.service.internalPort }}
+  selector:
+    app: {{ .Release.Name }}
+`
+)
+
+// staticSiteContentDirs are the conventional folders which hold the content of a
+// static site or the build output of an SPA frontend
+var staticSiteContentDirs = []string{"public", "dist", "site", "build"}
+
+// staticSiteDir returns the folder containing the static site content relative to
+// the project directory or an empty string if the project is not a static site
+func staticSiteDir(dir string) (string, error) {
+	hasPom, err := util.FileExists(filepath.Join(dir, "pom.xml"))
+	if err != nil || hasPom {
+		return "", err
+	}
+	for _, name := range staticSiteContentDirs {
+		exists, err := util.FileExists(filepath.Join(dir, name, "index.html"))
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return name, nil
+		}
+	}
+	exists, err := util.FileExists(filepath.Join(dir, "index.html"))
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return ".", nil
+	}
+	return "", nil
+}
+
+// createStaticSitePreviewChart generates an nginx based preview chart for a static
+// site so that pull requests get a per PR URL without a backend build pack
+func (o *ImportOptions) createStaticSitePreviewChart(dir string, contentDir string) error {
+	chartDir := filepath.Join(dir, "charts", "preview")
+	exists, err := util.FileExists(filepath.Join(chartDir, "Chart.yaml"))
+	if err != nil || exists {
+		return err
+	}
+	templatesDir := filepath.Join(chartDir, "templates")
+	err = os.MkdirAll(templatesDir, DefaultWritePermissions)
+	if err != nil {
+		return err
+	}
+	files := map[string]string{
+		filepath.Join(chartDir, "Chart.yaml"):          fmt.Sprintf(staticPreviewChartYaml, o.AppName),
+		filepath.Join(chartDir, "values.yaml"):         staticPreviewValuesYaml,
+		filepath.Join(templatesDir, "deployment.yaml"): staticPreviewDeploymentYaml,
+		filepath.Join(templatesDir, "service.yaml"):    staticPreviewServiceYaml,
+	}
+	for name, text := range files {
+		err = ioutil.WriteFile(name, []byte(text), DefaultWritePermissions)
+		if err != nil {
+			return fmt.Errorf("Failed to write %s due to %s", name, err)
+		}
+	}
+	o.Printf("Created an nginx based preview chart for the static site content in %s\n", util.ColorInfo(contentDir))
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
 	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1"
 	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
@@ -32,6 +33,7 @@ type InitOptions struct {
 type InitFlags struct {
 	Domain      string
 	Provider    string
+	IPFamily    string
 	DraftClient bool
 	HelmClient  bool
 }
@@ -39,8 +41,18 @@ type InitFlags struct {
 const (
 	INGRESS_SERVICE_NAME    = "jxing-nginx-ingress-controller"
 	DEFAULT_CHARTMUSEUM_URL = "http://chartmuseum.build.cd.jenkins-x.io"
+
+	// IPFamilyIPv4 only exposes ingress endpoints on IPv4 addresses
+	IPFamilyIPv4 = "ipv4"
+	// IPFamilyIPv6 only exposes ingress endpoints on IPv6 addresses
+	IPFamilyIPv6 = "ipv6"
+	// IPFamilyDual exposes ingress endpoints on whichever address families the cluster provides
+	IPFamilyDual = "dual"
 )
 
+// IPFamilies the IP families that can be used to expose ingress endpoints
+var IPFamilies = []string{IPFamilyIPv4, IPFamilyIPv6, IPFamilyDual}
+
 var (
 	initLong = templates.LongDesc(`
 		This command installs the Jenkins X platform on a connected kubernetes cluster
@@ -84,6 +96,7 @@ func NewCmdInit(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Comma
 
 func (options *InitOptions) addInitFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&options.Flags.Domain, "domain", "", "", "Domain to expose ingress endpoints.  Example: jenkinsx.io")
+	cmd.Flags().StringVarP(&options.Flags.IPFamily, "ip-family", "", IPFamilyIPv4, "The IP family used to expose ingress endpoints: "+strings.Join(IPFamilies, ", "))
 	cmd.Flags().BoolVarP(&options.Flags.DraftClient, "draft-client-only", "", false, "Only install draft client")
 	cmd.Flags().BoolVarP(&options.Flags.HelmClient, "helm-client-only", "", false, "Only install helm client")
 }
@@ -322,7 +335,7 @@ func (o *InitOptions) initIngress() error {
 
 		log.Infof("External loadbalancer created\n")
 
-		o.Flags.Domain, err = o.GetDomain(client, o.Flags.Domain, o.Flags.Domain)
+		o.Flags.Domain, err = o.GetDomain(client, o.Flags.Domain, o.Flags.Provider, o.Flags.IPFamily)
 		if err != nil {
 			return err
 		}
@@ -333,7 +346,13 @@ func (o *InitOptions) initIngress() error {
 	return nil
 }
 
-func (o *CommonOptions) GetDomain(client *kubernetes.Clientset, domain string, provider string) (string, error) {
+func (o *CommonOptions) GetDomain(client *kubernetes.Clientset, domain string, provider string, ipFamily string) (string, error) {
+	if ipFamily == "" {
+		ipFamily = IPFamilyIPv4
+	}
+	if util.StringArrayIndex(IPFamilies, ipFamily) < 0 {
+		return "", util.InvalidOption("ip-family", ipFamily, IPFamilies)
+	}
 	var address string
 	if provider == MINIKUBE {
 		ip, err := o.getCommandOutput("", "minikube", "ip")
@@ -347,16 +366,30 @@ func (o *CommonOptions) GetDomain(client *kubernetes.Clientset, domain string, p
 			return "", err
 		}
 		if svc != nil {
+			fallback := ""
 			for _, v := range svc.Status.LoadBalancer.Ingress {
 				if v.IP != "" {
-					address = v.IP
-				} else if v.Hostname != "" {
-					address = v.Hostname
+					fallback = v.IP
+					if ipFamilyMatches(v.IP, ipFamily) {
+						address = v.IP
+					}
+				} else if v.Hostname != "" && fallback == "" {
+					fallback = v.Hostname
+				}
+			}
+			if address == "" {
+				if ipFamily == IPFamilyIPv6 && fallback != "" {
+					return "", fmt.Errorf("No IPv6 address found on the ingress service. The cluster does not appear to support IPv6 so try --ip-family %s or %s", IPFamilyIPv4, IPFamilyDual)
 				}
+				address = fallback
 			}
 		}
 	}
 	defaultDomain := fmt.Sprintf("%s.nip.io", address)
+	if util.IsIPv6(address) {
+		// nip.io does not resolve IPv6 addresses so use the dash syntax of sslip.io instead
+		defaultDomain = fmt.Sprintf("%s.sslip.io", strings.Replace(address, ":", "-", -1))
+	}
 	if domain == "" {
 
 		if o.BatchMode {
@@ -385,3 +418,15 @@ func (o *CommonOptions) GetDomain(client *kubernetes.Clientset, domain string, p
 
 	return domain, nil
 }
+
+// ipFamilyMatches returns true if the given IP address belongs to the requested IP family
+func ipFamilyMatches(address string, ipFamily string) bool {
+	switch ipFamily {
+	case IPFamilyDual:
+		return true
+	case IPFamilyIPv6:
+		return util.IsIPv6(address)
+	default:
+		return !util.IsIPv6(address)
+	}
+}
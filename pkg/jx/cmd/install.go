@@ -39,6 +39,7 @@ type InstallOptions struct {
 
 type InstallFlags struct {
 	Domain                   string
+	IPFamily                 string
 	HTTPS                    bool
 	Provider                 string
 	CloudEnvRepository       string
@@ -168,6 +169,9 @@ func (options *InstallOptions) addInstallFlags(cmd *cobra.Command, includesInit
 	cmd.Flags().StringVarP(&flags.Namespace, "namespace", "", "jx", "The namespace the Jenkins X platform should be installed into")
 	cmd.Flags().StringVarP(&flags.Timeout, "timeout", "", defaultInstallTimeout, "The number of seconds to wait for the helm install to complete")
 	cmd.Flags().BoolVarP(&flags.RegisterLocalHelmRepo, "register-local-helmrepo", "", false, "Registers the Jenkins X chartmuseum registry with your helm client [default false]")
+	if !includesInit {
+		cmd.Flags().StringVarP(&flags.IPFamily, "ip-family", "", IPFamilyIPv4, "The IP family used to expose services: "+strings.Join(IPFamilies, ", "))
+	}
 
 	addGitRepoOptionsArguments(cmd, &options.GitRepositoryOptions)
 	ignoreDomain := false
@@ -329,6 +333,13 @@ func (options *InstallOptions) Run() error {
 		if err != nil {
 			return err
 		}
+	} else {
+		// still record the Jenkins URL and admin credentials in the local config
+		// so that commands like 'jx console' work without a manual login
+		err = options.saveJenkinsAuthConfig()
+		if err != nil {
+			return err
+		}
 	}
 
 	err = options.saveChartmuseumAuthConfig()
@@ -356,6 +367,11 @@ func (options *InstallOptions) Run() error {
 `
 	options.Printf(astrix, fmt.Sprintf("Your admin password is: %s", util.ColorInfo(options.AdminSecretsService.Flags.DefaultAdminPassword)))
 
+	jenkinsURL, err := options.findService(kube.ServiceJenkins)
+	if err == nil {
+		options.Printf("\nJenkins is available at: %s\n", util.ColorInfo(jenkinsURL))
+	}
+
 	options.Printf("\nTo import existing projects into Jenkins: %s\n", util.ColorInfo("jx import"))
 	options.Printf("To create a new Spring Boot microservice: %s\n", util.ColorInfo("jx create spring -d web -d actuator"))
 	return nil
@@ -432,7 +448,7 @@ PipelineSecrets:
 
 func (o *InstallOptions) getExposecontrollerConfigValues() (string, error) {
 	var err error
-	o.Flags.Domain, err = o.GetDomain(o.kubeClient, o.Flags.Domain, o.Flags.Provider)
+	o.Flags.Domain, err = o.GetDomain(o.kubeClient, o.Flags.Domain, o.Flags.Provider, o.Flags.IPFamily)
 	if err != nil {
 		return "", err
 	}
@@ -492,14 +508,16 @@ func (o *InstallOptions) getGitToken() (string, string, error) {
 	if userAuth.IsInvalid() {
 		gits.PrintCreateRepositoryGenerateAccessToken(server, o.Out)
 
-		// TODO could we guess this based on the users ~/.git for github?
-		defaultUserName := ""
+		defaultUserName := gits.GetDefaultGitUserName()
 		err = config.EditUserAuth(server.Label(), userAuth, defaultUserName, false, o.BatchMode)
 		if err != nil {
 			return "", "", err
 		}
 
-		// TODO lets verify the auth works
+		err = gits.ValidateUserAuth(server, userAuth, o.Out)
+		if err != nil {
+			return "", "", err
+		}
 
 		err = authConfigSvc.SaveUserAuth(url, userAuth)
 		if err != nil {
@@ -525,6 +543,28 @@ func (o *InstallOptions) waitForInstallToBeReady(ns string) error {
 
 }
 
+// saveJenkinsAuthConfig stores the Jenkins URL and the generated admin
+// credentials in the local config
+func (options *InstallOptions) saveJenkinsAuthConfig() error {
+	authConfigSvc, err := options.Factory.CreateJenkinsAuthConfigService()
+	if err != nil {
+		return err
+	}
+	config := authConfigSvc.Config()
+
+	url, err := options.findService(kube.ServiceJenkins)
+	if err != nil {
+		return err
+	}
+	server := config.GetOrCreateServer(url)
+
+	userAuth := config.GetOrCreateUserAuth(url, options.CreateJenkinsUserOptions.Username)
+	userAuth.Password = options.AdminSecretsService.Flags.DefaultAdminPassword
+
+	config.CurrentServer = server.URL
+	return authConfigSvc.SaveConfig()
+}
+
 func (options *InstallOptions) saveChartmuseumAuthConfig() error {
 
 	authConfigSvc, err := options.Factory.CreateChartmuseumAuthConfigService()
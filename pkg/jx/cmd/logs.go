@@ -23,12 +23,16 @@ type LogsOptions struct {
 	Container string
 	Namespace string
 	Filter    string
+	Pipeline  bool
+	Build     int
 }
 
 var (
 	logs_long = templates.LongDesc(`
 		Tails the logs of the newest pod for a Deployment.
 
+		You can also tail the console log of a pipeline build using the --pipeline flag.
+
 `)
 
 	logs_example = templates.Examples(`
@@ -37,6 +41,12 @@ var (
 
 		# Tails the log of the container foo in the latest pod in deployment myapp
 		jx logs myapp -c foo
+
+		# Tails the console log of the last build of a pipeline, prompting if no pipeline is given
+		jx logs -p myorg/myrepo/master
+
+		# Tails the console log of build 12 of a pipeline
+		jx logs -p myorg/myrepo/master -b 12
 `)
 )
 
@@ -64,10 +74,24 @@ func NewCmdLogs(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Comma
 	cmd.Flags().StringVarP(&options.Container, "container", "c", "", "The name of the container to log")
 	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "the namespace to look for the Deployment. Defaults to the current namespace")
 	cmd.Flags().StringVarP(&options.Filter, "filter", "f", "", "Filters the available deployments if no deployment argument is provided")
+	cmd.Flags().BoolVarP(&options.Pipeline, "pipeline", "p", false, "Tails the console log of a pipeline build instead of a Deployment")
+	cmd.Flags().IntVarP(&options.Build, "build", "b", 0, "The build number to tail the log of when using --pipeline. Defaults to the last build")
 	return cmd
 }
 
 func (o *LogsOptions) Run() error {
+	if o.Pipeline {
+		logOptions := &GetBuildLogsOptions{
+			GetOptions: GetOptions{
+				CommonOptions: o.CommonOptions,
+			},
+			Tail:   true,
+			Filter: o.Filter,
+			Build:  o.Build,
+		}
+		logOptions.Args = o.Args
+		return logOptions.Run()
+	}
 	args := o.Args
 
 	client, curNs, err := o.Factory.CreateClient()
@@ -7,6 +7,7 @@ import (
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
 )
 
 type OpenOptions struct {
@@ -63,7 +64,28 @@ func (o *OpenOptions) Run() error {
 				CommonOptions: o.CommonOptions,
 			},
 		}
-		return getOptions.Run()
+		if o.BatchMode {
+			return getOptions.Run()
+		}
+		urls, err := getOptions.serviceURLs()
+		if err != nil {
+			return err
+		}
+		if len(urls) == 0 {
+			return outputEmptyListWarning(o.Out)
+		}
+		names := []string{}
+		for _, url := range urls {
+			names = append(names, url.Name)
+		}
+		name, err := util.PickName(names, "Which service do you want to open?:")
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			return getOptions.Run()
+		}
+		return o.ConsoleOptions.Open(name, name)
 	}
 	name := o.Args[0]
 	return o.ConsoleOptions.Open(name, name)
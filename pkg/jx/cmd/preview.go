@@ -5,11 +5,14 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	"strings"
 
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
 	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/dns"
 	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
@@ -17,7 +20,9 @@ import (
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
 	gitcfg "gopkg.in/src-d/go-git.v4/config"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 )
 
 var (
@@ -293,7 +298,16 @@ func (o *PreviewOptions) Run() error {
 		o.ReleaseName = ens
 	}
 
-	err = o.runCommand("helm", "upgrade", o.ReleaseName, ".", "--install", "--wait", "--namespace", ens)
+	envVars, err := o.provisionPreviewDependencies(kubeClient, ens)
+	if err != nil {
+		return err
+	}
+
+	args := []string{"upgrade", o.ReleaseName, ".", "--install", "--wait", "--namespace", ens}
+	for _, key := range util.SortedMapKeys(envVars) {
+		args = append(args, "--set", "env."+key+"="+envVars[key])
+	}
+	err = o.runCommand("helm", args...)
 	if err != nil {
 		return err
 	}
@@ -309,6 +323,10 @@ func (o *PreviewOptions) Run() error {
 			hostname := ing.Spec.Rules[0].Host
 			if hostname != "" {
 				comment = fmt.Sprintf(":star: PR built and available [here](http://%s)", hostname)
+				err = o.ensurePreviewDNSRecord(jxClient, ns, hostname)
+				if err != nil {
+					o.warnf("Failed to create the DNS record of the preview: %s\n", err)
+				}
 			}
 		}
 	}
@@ -333,6 +351,88 @@ func (o *PreviewOptions) Run() error {
 
 }
 
+// provisionPreviewDependencies installs the ephemeral dependencies declared by the
+// project into the preview namespace so that they are torn down along with the
+// preview. It returns the connection environment variables which are wired into
+// the preview deployment of the application
+func (o *PreviewOptions) provisionPreviewDependencies(kubeClient *kubernetes.Clientset, ns string) (map[string]string, error) {
+	envVars := map[string]string{}
+	projectConfig, err := config.LoadProjectConfig(o.Dir)
+	if err != nil {
+		return envVars, err
+	}
+	dependencies, err := config.LoadPreviewDependenciesConfig(o.Dir)
+	if err != nil {
+		return envVars, err
+	}
+	all := []*config.PreviewDependency{}
+	if projectConfig.Preview != nil {
+		all = append(all, projectConfig.Preview.Dependencies...)
+	}
+	all = append(all, dependencies.Dependencies...)
+	for _, dependency := range all {
+		releaseName := ns + "-" + dependency.Name
+		chart := dependency.Chart
+		if chart == "" {
+			// a dependency without a chart is another application built by the team
+			chart = kube.LocalHelmRepoName + "/" + dependency.Name
+		}
+		o.Printf("Provisioning the preview dependency %s from chart %s\n", util.ColorInfo(dependency.Name), util.ColorInfo(chart))
+		if dependency.Fixtures != "" {
+			err = o.createFixturesConfigMap(kubeClient, ns, releaseName, dependency.Fixtures)
+			if err != nil {
+				return envVars, err
+			}
+		}
+		args := []string{"upgrade", releaseName, chart, "--install", "--wait", "--namespace", ns}
+		if dependency.Version != "" {
+			args = append(args, "--version", dependency.Version)
+		}
+		for _, key := range util.SortedMapKeys(dependency.Values) {
+			args = append(args, "--set", key+"="+dependency.Values[key])
+		}
+		err = o.runCommand("helm", args...)
+		if err != nil {
+			return envVars, fmt.Errorf("Failed to provision the preview dependency %s due to: %s", dependency.Name, err)
+		}
+		for k, v := range dependency.Env {
+			envVars[k] = v
+		}
+	}
+	return envVars, nil
+}
+
+// createFixturesConfigMap stores the fixtures file in a ConfigMap in the preview
+// namespace so that the dependency chart can seed from it
+func (o *PreviewOptions) createFixturesConfigMap(kubeClient *kubernetes.Clientset, ns string, releaseName string, fileName string) error {
+	path := filepath.Join(o.Dir, fileName)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Failed to load the fixtures file %s due to %s", path, err)
+	}
+	name := releaseName + "-fixtures"
+	configMaps := kubeClient.CoreV1().ConfigMaps(ns)
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Data: map[string]string{
+			filepath.Base(fileName): string(data),
+		},
+	}
+	current, err := configMaps.Get(name, metav1.GetOptions{})
+	if err == nil {
+		current.Data = cm.Data
+		_, err = configMaps.Update(current)
+	} else {
+		_, err = configMaps.Create(cm)
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to create the fixtures ConfigMap %s due to: %s", name, err)
+	}
+	return nil
+}
+
 func (o *PreviewOptions) discoverGitURL(gitConf string) (string, error) {
 	if gitConf == "" {
 		return "", fmt.Errorf("No GitConfDir defined!")
@@ -363,3 +463,32 @@ func (o *PreviewOptions) discoverGitURL(gitConf string) (string, error) {
 	}
 	return url, nil
 }
+
+// ensurePreviewDNSRecord registers the preview host with the team DNS provider
+// when one is configured so that the preview resolves in the real team domain
+func (o *PreviewOptions) ensurePreviewDNSRecord(jxClient *versioned.Clientset, ns string, hostname string) error {
+	devEnv, err := jxClient.JenkinsV1().Environments(ns).Get(kube.LabelValueDevEnvironment, metav1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+	settings := &devEnv.Spec.TeamSettings
+	if settings.DNS.Provider == "" || settings.DNS.Domain == "" {
+		return nil
+	}
+	if !strings.HasSuffix(hostname, "."+settings.DNS.Domain) {
+		return nil
+	}
+	if settings.DNS.Target == "" {
+		return fmt.Errorf("No DNS target configured in the team settings. Try: jx edit dns")
+	}
+	provider, err := dns.CreateDNSProvider(settings.DNS.Provider)
+	if err != nil {
+		return err
+	}
+	err = provider.EnsureRecord(settings.DNS.Domain, hostname, settings.DNS.Target)
+	if err != nil {
+		return err
+	}
+	o.Printf("Created DNS record for %s via %s\n", util.ColorInfo(hostname), util.ColorInfo(settings.DNS.Provider))
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	os_user "os/user"
 	"path/filepath"
 	"strings"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/blang/semver"
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	typev1 "github.com/jenkins-x/jx/pkg/client/clientset/versioned/typed/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/features"
 	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/helm"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
@@ -46,12 +48,18 @@ type PromoteOptions struct {
 	Application         string
 	Version             string
 	ReleaseName         string
+	Train               string
+	OverrideFreeze      string
 	LocalHelmRepoName   string
 	HelmRepositoryURL   string
 	NoHelmUpdate        bool
 	AllAutomatic        bool
 	Timeout             string
 	PullRequestPollTime string
+	FeatureFlag         string
+	FlagProvider        string
+	FlagServer          string
+	FlagToken           string
 
 	// calculated fields
 	TimeoutDuration         *time.Duration
@@ -127,16 +135,25 @@ func NewCmdPromote(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Co
 func (options *PromoteOptions) addPromoteOptions(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&options.Application, optionApplication, "a", "", "The Application to promote")
 	cmd.Flags().StringVarP(&options.Version, "version", "v", "", "The Version to promote")
+	cmd.Flags().StringVarP(&options.Train, "train", "", "", "The name of a release train to promote; every app on the train is promoted at its pinned version")
+	cmd.Flags().StringVarP(&options.OverrideFreeze, "override-freeze", "", "", "The reason to promote despite an active freeze window; the override is recorded against the window as an audit trail")
 	cmd.Flags().StringVarP(&options.LocalHelmRepoName, "helm-repo-name", "r", kube.LocalHelmRepoName, "The name of the helm repository that contains the app")
 	cmd.Flags().StringVarP(&options.HelmRepositoryURL, "helm-repo-url", "u", helm.DefaultHelmRepositoryURL, "The Helm Repository URL to use for the App")
 	cmd.Flags().StringVarP(&options.ReleaseName, "release", "", "", "The name of the helm release")
 	cmd.Flags().StringVarP(&options.Timeout, optionTimeout, "t", "", "The timeout to wait for the promotion to succeed in the underlying Environment. The command fails if the timeout is exceeded or the promotion does not complete")
 	cmd.Flags().StringVarP(&options.PullRequestPollTime, optionPullRequestPollTime, "", "20s", "Poll time when waiting for a Pull Request to merge")
 	cmd.Flags().BoolVarP(&options.NoHelmUpdate, "no-helm-update", "", false, "Allows the 'helm repo update' command if you are sure your local helm cache is up to date with the version you wish to promote")
+	cmd.Flags().StringVarP(&options.FeatureFlag, "feature-flag", "", "", "The name of a feature flag to enable in the target Environment once the promotion succeeds")
+	cmd.Flags().StringVarP(&options.FlagProvider, "flag-provider", "", "", "The feature flag service to use: "+strings.Join(features.KindFlagProviders, ", "))
+	cmd.Flags().StringVarP(&options.FlagServer, "flag-server", "", "", "The URL of the feature flag service")
+	cmd.Flags().StringVarP(&options.FlagToken, "flag-token", "", "", "The API token for the feature flag service. Defaults to $FEATURE_FLAG_TOKEN")
 }
 
 // Run implements this command
 func (o *PromoteOptions) Run() error {
+	if o.Train != "" {
+		return o.PromoteTrain()
+	}
 	app := o.Application
 	if app == "" {
 		args := o.Args
@@ -194,7 +211,134 @@ func (o *PromoteOptions) Run() error {
 	if err != nil {
 		return err
 	}
-	return err
+	return o.enableFeatureFlag(env)
+}
+
+// enableFeatureFlag creates the feature flag if required and enables it in the
+// environment once the promotion has succeeded so that code deployment and
+// feature enablement are coordinated
+func (o *PromoteOptions) enableFeatureFlag(env *v1.Environment) error {
+	if o.FeatureFlag == "" {
+		return nil
+	}
+	token := o.FlagToken
+	if token == "" {
+		token = os.Getenv("FEATURE_FLAG_TOKEN")
+	}
+	provider, err := features.CreateFlagProvider(o.FlagProvider, o.FlagServer, token)
+	if err != nil {
+		return err
+	}
+	err = provider.EnsureFlag(o.FeatureFlag)
+	if err != nil {
+		return fmt.Errorf("Failed to create the feature flag %s due to: %s", o.FeatureFlag, err)
+	}
+	err = provider.SetFlag(o.FeatureFlag, env.Name, true)
+	if err != nil {
+		return fmt.Errorf("Failed to enable the feature flag %s in environment %s due to: %s", o.FeatureFlag, env.Name, err)
+	}
+	o.Printf("Enabled the feature flag %s in environment %s\n", util.ColorInfo(o.FeatureFlag), util.ColorInfo(env.Name))
+	return nil
+}
+
+// checkPromotionFreeze fails the promotion if a freeze window is currently
+// blocking the environment, unless --override-freeze gives a reason in which
+// case the override is recorded against the window
+func (o *PromoteOptions) checkPromotionFreeze(env *v1.Environment) error {
+	if env == nil {
+		return nil
+	}
+	jxClient, ns, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	devEnv, err := jxClient.JenkinsV1().Environments(ns).Get(kube.LabelValueDevEnvironment, metav1.GetOptions{})
+	if err != nil {
+		// we may be promoting before the dev environment exists so there can be no freeze
+		return nil
+	}
+	settings := &devEnv.Spec.TeamSettings
+	now := time.Now()
+	save := false
+	for i := range settings.FreezeWindows {
+		window := &settings.FreezeWindows[i]
+		if !window.Active(env.Name, now) {
+			continue
+		}
+		if o.OverrideFreeze == "" {
+			message := window.Description
+			if message == "" {
+				message = "promotions are frozen"
+			}
+			return fmt.Errorf("Promotion to %s is blocked by the freeze window %s: %s. Use --override-freeze 'reason' to promote anyway", env.Name, window.Name, message)
+		}
+		window.Overrides = append(window.Overrides, v1.FreezeOverride{
+			User:        localUserName(),
+			Reason:      o.OverrideFreeze,
+			Time:        metav1.NewTime(now),
+			Application: o.Application,
+			Version:     o.Version,
+		})
+		o.warnf("Overriding the freeze window %s on %s: %s\n", window.Name, env.Name, o.OverrideFreeze)
+		save = true
+	}
+	if save {
+		_, err = jxClient.JenkinsV1().Environments(ns).Update(devEnv)
+		if err != nil {
+			return fmt.Errorf("Failed to record the freeze override due to: %s", err)
+		}
+	}
+	return nil
+}
+
+// localUserName returns the name of the local operating system user for the audit trail
+func localUserName() string {
+	u, err := os_user.Current()
+	if err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// PromoteTrain promotes every application of the named release train at its
+// pinned version, stopping at the first failure so that a broken train does not
+// partially roll out
+func (o *PromoteOptions) PromoteTrain() error {
+	jxClient, ns, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	env, err := jxClient.JenkinsV1().Environments(ns).Get(kube.LabelValueDevEnvironment, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to load the team settings from the %s environment due to: %s", kube.LabelValueDevEnvironment, err)
+	}
+	train := findReleaseTrain(&env.Spec.TeamSettings, o.Train)
+	if train == nil {
+		names := []string{}
+		for _, t := range env.Spec.TeamSettings.ReleaseTrains {
+			names = append(names, t.Name)
+		}
+		return util.InvalidOption("train", o.Train, names)
+	}
+	if len(train.Apps) == 0 {
+		return fmt.Errorf("The release train %s has no apps. Add some via: jx create train %s -a app=version", o.Train, o.Train)
+	}
+	info := util.ColorInfo
+	o.Printf("Promoting the %s apps of release train %s\n", info(len(train.Apps)), info(o.Train))
+	for _, app := range train.Apps {
+		appOptions := *o
+		appOptions.Train = ""
+		appOptions.Application = app.Name
+		appOptions.Version = app.Version
+		appOptions.ReleaseName = ""
+		appOptions.Args = nil
+		err = appOptions.Run()
+		if err != nil {
+			return fmt.Errorf("Failed to promote %s %s from release train %s due to: %s", app.Name, app.Version, o.Train, err)
+		}
+	}
+	o.Printf("Release train %s promoted successfully\n", info(o.Train))
+	return nil
 }
 
 func (o *PromoteOptions) PromoteAllAutomatic() error {
@@ -286,6 +430,11 @@ func (o *PromoteOptions) Promote(targetNS string, env *v1.Environment, warnIfAut
 			return releaseInfo, nil
 		}
 	}
+	err := o.checkPromotionFreeze(env)
+	if err != nil {
+		return releaseInfo, err
+	}
+
 	promoteKey := o.createPromoteKey(env)
 
 	if env != nil {
@@ -314,7 +463,7 @@ func (o *PromoteOptions) Promote(targetNS string, env *v1.Environment, warnIfAut
 		}
 	}
 
-	err := o.verifyHelmConfigured()
+	err = o.verifyHelmConfigured()
 	if err != nil {
 		return releaseInfo, err
 	}
@@ -342,6 +491,9 @@ func (o *PromoteOptions) Promote(targetNS string, env *v1.Environment, warnIfAut
 	} else {
 		err = o.runCommand("helm", "upgrade", "--install", "--wait", "--namespace", targetNS, releaseName, fullAppName)
 	}
+	if err == nil {
+		err = o.waitForAppRollout(targetNS, app, releaseName)
+	}
 	if err == nil {
 		err = promoteKey.OnPromoteUpdate(o.Activities, kube.CompletePromotionUpdate)
 		if err == nil {
@@ -353,6 +505,35 @@ func (o *PromoteOptions) Promote(targetNS string, env *v1.Environment, warnIfAut
 	return releaseInfo, err
 }
 
+// waitForAppRollout polls the deployments of the app in the target namespace
+// until the rollout completes so that a direct promotion only succeeds once the
+// new version is actually available. Only used when a --timeout is given
+func (o *PromoteOptions) waitForAppRollout(ns string, app string, releaseName string) error {
+	if o.TimeoutDuration == nil {
+		return nil
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	deployments, err := kubeClient.ExtensionsV1beta1().Deployments(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, d := range deployments.Items {
+		name := d.Name
+		if name != app && name != releaseName && !strings.HasSuffix(name, "-"+app) {
+			continue
+		}
+		o.Printf("Waiting for deployment %s in namespace %s to roll out...\n", util.ColorInfo(name), util.ColorInfo(ns))
+		err = kube.WaitForDeploymentToBeReady(kubeClient, name, ns, *o.TimeoutDuration)
+		if err != nil {
+			return fmt.Errorf("Failed waiting for the rollout of deployment %s in namespace %s due to: %s", name, ns, err)
+		}
+	}
+	return nil
+}
+
 func (o *PromoteOptions) PromoteViaPullRequest(env *v1.Environment, releaseInfo *ReleaseInfo) error {
 	source := &env.Spec.Source
 	gitURL := source.URL
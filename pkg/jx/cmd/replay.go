@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+)
+
+// Replay contains the command line options
+type Replay struct {
+	CommonOptions
+}
+
+var (
+	replay_long = templates.LongDesc(`
+		Replays a previous pipeline build.
+`)
+
+	replay_example = templates.Examples(`
+		# Replay the last build of a pipeline
+		jx replay build foo
+	`)
+)
+
+// NewCmdReplay creates a command object for the "replay" action
+func NewCmdReplay(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &Replay{
+		CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "replay TYPE [flags]",
+		Short:   "Replays a previous pipeline build",
+		Long:    replay_long,
+		Example: replay_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.AddCommand(NewCmdReplayBuild(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *Replay) Run() error {
+	return o.Cmd.Help()
+}
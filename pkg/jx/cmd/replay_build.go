@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/golang-jenkins"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// ReplayBuildOptions contains the command line options
+type ReplayBuildOptions struct {
+	GetOptions
+
+	Tail   bool
+	Filter string
+	Build  int
+}
+
+var (
+	replay_build_long = templates.LongDesc(`
+		Replays a build of a pipeline using the same pipeline script.
+
+		This re-runs the build via the Jenkins replay API so you do not have
+		to re-run a long pipeline because a step flaked.
+`)
+
+	replay_build_example = templates.Examples(`
+		# Replay the last build of a pipeline
+		jx replay build foo
+
+		# Replay build 12 of a pipeline and tail the log
+		jx replay build foo -b 12 -t
+	`)
+)
+
+// NewCmdReplayBuild creates the command
+func NewCmdReplayBuild(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ReplayBuildOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "build [flags]",
+		Short:   "Replays a build of a pipeline",
+		Long:    replay_build_long,
+		Example: replay_build_example,
+		Aliases: []string{"builds"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().BoolVarP(&options.Tail, "tail", "t", false, "Tails the build log to the current terminal")
+	cmd.Flags().StringVarP(&options.Filter, "filter", "f", "", "Filters all the available jobs by those that contain the given text")
+	cmd.Flags().IntVarP(&options.Build, "build", "b", 0, "The build number to replay. Defaults to the last build")
+	return cmd
+}
+
+// Run implements this command
+func (o *ReplayBuildOptions) Run() error {
+	jobMap, err := o.getJobMap(o.Filter)
+	if err != nil {
+		return err
+	}
+	jenkinsClient, err := o.JenkinsClient()
+	if err != nil {
+		return err
+	}
+
+	args := o.Args
+	names := []string{}
+	for k := range jobMap {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return fmt.Errorf("No pipelines have been built!")
+	}
+	if len(args) == 0 {
+		defaultName := ""
+		for _, n := range names {
+			if strings.HasSuffix(n, "/master") {
+				defaultName = n
+				break
+			}
+		}
+		name, err := util.PickNameWithDefault(names, "Which pipeline do you want to replay?: ", defaultName)
+		if err != nil {
+			return err
+		}
+		args = []string{name}
+	}
+	name := args[0]
+	job := jobMap[name]
+	if job == nil {
+		return util.InvalidArg(name, names)
+	}
+	var build gojenkins.Build
+	if o.Build > 0 {
+		build, err = jenkinsClient.GetBuild(*job, o.Build)
+		if err != nil {
+			return fmt.Errorf("Failed to find build %d of %s due to: %s", o.Build, name, err)
+		}
+	} else {
+		build, err = jenkinsClient.GetLastBuild(*job)
+		if err != nil {
+			return fmt.Errorf("Failed to find the last build of %s due to: %s", name, err)
+		}
+	}
+	// remember the latest build so we can spot the replayed one starting
+	previous, _ := jenkinsClient.GetLastBuild(*job)
+
+	u, err := url.Parse(build.Url)
+	if err != nil {
+		return err
+	}
+	err = jenkinsClient.ReplayBuild(u.Path)
+	if err != nil {
+		return fmt.Errorf("Failed to replay build %s #%d due to: %s", name, build.Number, err)
+	}
+	o.Printf("Replaying build %s #%d\n", util.ColorInfo(name), build.Number)
+
+	for {
+		last, err := jenkinsClient.GetLastBuild(*job)
+		if err != nil {
+			return err
+		}
+		if last.Number != previous.Number {
+			o.Printf("Started build of %s at %s\n", util.ColorInfo(name), util.ColorInfo(last.Url))
+			o.Printf("%s %s\n", util.ColorStatus("view the log at:"), util.ColorInfo(util.UrlJoin(last.Url, "/console")))
+			if o.Tail {
+				return o.tailBuild(name, &last)
+			}
+			return nil
+		}
+		time.Sleep(time.Second)
+	}
+}
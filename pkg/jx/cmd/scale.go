@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScaleOptions contains the CLI options
+type ScaleOptions struct {
+	CommonOptions
+
+	Application string
+	Environment string
+	Replicas    int
+}
+
+var (
+	scale_long = templates.LongDesc(`
+		Scales the number of replicas of an application in an environment.
+
+		This scales the underlying Deployment so that routine operations do not
+		require knowledge of the application chart.
+`)
+
+	scale_example = templates.Examples(`
+		# Scale an application in the staging environment to 3 replicas
+		jx scale --app foo --env staging --replicas 3
+	`)
+)
+
+// NewCmdScale creates the command
+func NewCmdScale(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ScaleOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "scale [application]",
+		Short:   "Scales the replicas of an application in an environment",
+		Long:    scale_long,
+		Example: scale_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Application, "app", "a", "", "The application to scale")
+	cmd.Flags().StringVarP(&options.Environment, "env", "e", "", "The environment containing the application")
+	cmd.Flags().IntVarP(&options.Replicas, "replicas", "r", -1, "The number of replicas to scale to")
+	return cmd
+}
+
+// Run implements this command
+func (o *ScaleOptions) Run() error {
+	app := o.Application
+	if app == "" && len(o.Args) > 0 {
+		app = o.Args[0]
+	}
+	if app == "" {
+		return util.MissingOption("app")
+	}
+	if o.Environment == "" {
+		return util.MissingOption("env")
+	}
+	if o.Replicas < 0 {
+		return util.MissingOption("replicas")
+	}
+	ns, err := o.environmentNamespace(o.Environment)
+	if err != nil {
+		return err
+	}
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	deployments := client.ExtensionsV1beta1().Deployments(ns)
+	deployment, err := deployments.Get(app, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to find the application %s in namespace %s due to: %s", app, ns, err)
+	}
+	replicas := int32(o.Replicas)
+	deployment.Spec.Replicas = &replicas
+	_, err = deployments.Update(deployment)
+	if err != nil {
+		return fmt.Errorf("Failed to scale the application %s due to: %s", app, err)
+	}
+	o.Printf("Scaled application %s in environment %s to %s replica(s)\n",
+		util.ColorInfo(app), util.ColorInfo(o.Environment), util.ColorInfo(fmt.Sprintf("%d", o.Replicas)))
+	return nil
+}
+
+// environmentNamespace resolves the namespace of the named environment in the
+// current team
+func (o *CommonOptions) environmentNamespace(envName string) (string, error) {
+	kubeClient, currentNs, err := o.KubeClient()
+	if err != nil {
+		return "", err
+	}
+	jxClient, _, err := o.JXClient()
+	if err != nil {
+		return "", err
+	}
+	ns, _, err := kube.GetDevNamespace(kubeClient, currentNs)
+	if err != nil {
+		return "", err
+	}
+	envMap, envNames, err := kube.GetEnvironments(jxClient, ns)
+	if err != nil {
+		return "", err
+	}
+	env := envMap[envName]
+	if env == nil {
+		return "", util.InvalidArg(envName, envNames)
+	}
+	answer := env.Spec.Namespace
+	if answer == "" {
+		return "", fmt.Errorf("No namespace for environment %s", envName)
+	}
+	return answer, nil
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
 	"net/url"
 	"sort"
@@ -13,14 +14,17 @@ import (
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
 	"github.com/jenkins-x/jx/pkg/util"
+	"gopkg.in/AlecAivazis/survey.v1"
 )
 
 // StartPipelineOptions contains the command line options
 type StartPipelineOptions struct {
 	GetOptions
 
-	Tail   bool
-	Filter string
+	Tail       bool
+	Filter     string
+	FromStage  string
+	Parameters []string
 
 	Jobs map[string]*gojenkins.Job
 }
@@ -40,6 +44,9 @@ var (
 
 		# Select the pipeline to start and tail the log
 		jx start pipeline -t
+
+		# Restart the last build of a pipeline from a given stage
+		jx start pipeline foo --from-stage 'Deploy Staging'
 	`)
 )
 
@@ -70,6 +77,8 @@ func NewCmdStartPipeline(f cmdutil.Factory, out io.Writer, errOut io.Writer) *co
 	}
 	cmd.Flags().BoolVarP(&options.Tail, "tail", "t", false, "Tails the build log to the current terminal")
 	cmd.Flags().StringVarP(&options.Filter, "filter", "f", "", "Filters all the available jobs by those that contain the given text")
+	cmd.Flags().StringVarP(&options.FromStage, "from-stage", "", "", "Restart the last build from the given stage rather than starting a new build")
+	cmd.Flags().StringArrayVarP(&options.Parameters, "param", "p", []string{}, "The build parameters in the form name=value. Any parameter not specified is prompted for or defaulted")
 
 	return cmd
 }
@@ -121,13 +130,37 @@ func (o *StartPipelineOptions) startJob(name string, allNames []string) error {
 		return err
 	}
 
+	// refresh the job so we have its parameter definitions
+	detail, err := jenkins.GetJob(name)
+	if err == nil {
+		job = &detail
+	}
+
 	// ignore errors as it could be there's no last build yet
 	previous, _ := jenkins.GetLastBuild(*job)
 
-	params := url.Values{}
-	err = jenkins.Build(*job, params)
-	if err != nil {
-		return err
+	if o.FromStage != "" {
+		if previous.Url == "" {
+			return fmt.Errorf("No previous build of %s to restart from stage %s", name, o.FromStage)
+		}
+		u, err := url.Parse(previous.Url)
+		if err != nil {
+			return err
+		}
+		err = jenkins.RestartBuildFromStage(u.Path, o.FromStage)
+		if err != nil {
+			return fmt.Errorf("Failed to restart build %s #%d from stage %s due to: %s", name, previous.Number, o.FromStage, err)
+		}
+		o.Printf("Restarting build of %s from stage %s\n", util.ColorInfo(name), util.ColorInfo(o.FromStage))
+	} else {
+		params, err := o.buildParameters(job)
+		if err != nil {
+			return err
+		}
+		err = jenkins.Build(*job, params)
+		if err != nil {
+			return err
+		}
 	}
 
 	i := 0
@@ -152,6 +185,43 @@ func (o *StartPipelineOptions) startJob(name string, allNames []string) error {
 	}
 }
 
+// buildParameters resolves the build parameters of the job from any -p name=value
+// arguments, prompting for any remaining parameter definitions of the job
+func (o *StartPipelineOptions) buildParameters(job *gojenkins.Job) (url.Values, error) {
+	params := url.Values{}
+	for _, p := range o.Parameters {
+		idx := strings.Index(p, "=")
+		if idx < 0 {
+			return nil, fmt.Errorf("Invalid parameter %s. Expected the format name=value", p)
+		}
+		params.Set(p[:idx], p[idx+1:])
+	}
+	for _, action := range job.Actions {
+		for _, def := range action.ParameterDefinitions {
+			if _, exists := params[def.Name]; exists {
+				continue
+			}
+			value := ""
+			if def.DefaultParameterValue.Value != nil {
+				value = fmt.Sprintf("%v", def.DefaultParameterValue.Value)
+			}
+			if !o.BatchMode {
+				prompt := &survey.Input{
+					Message: fmt.Sprintf("Parameter %s:", def.Name),
+					Default: value,
+					Help:    def.Description,
+				}
+				err := survey.AskOne(prompt, &value, nil)
+				if err != nil {
+					return nil, err
+				}
+			}
+			params.Set(def.Name, value)
+		}
+	}
+	return params, nil
+}
+
 func jobName(prefix string, j *gojenkins.Job) string {
 	name := j.FullName
 	if name == "" {
@@ -42,9 +42,17 @@ func NewCmdStep(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Comma
 	}
 
 	cmd.AddCommand(NewCmdStepChangelog(f, out, errOut))
+	cmd.AddCommand(NewCmdStepChanges(f, out, errOut))
+	cmd.AddCommand(NewCmdStepE2E(f, out, errOut))
+	cmd.AddCommand(NewCmdStepEnv(f, out, errOut))
+	cmd.AddCommand(NewCmdStepGPG(f, out, errOut))
 	cmd.AddCommand(NewCmdStepPR(f, out, errOut))
 	cmd.AddCommand(NewCmdStepNexus(f, out, errOut))
+	cmd.AddCommand(NewCmdStepSandbox(f, out, errOut))
+	cmd.AddCommand(NewCmdStepSign(f, out, errOut))
 	cmd.AddCommand(NewCmdStepTag(f, out, errOut))
+	cmd.AddCommand(NewCmdStepValidate(f, out, errOut))
+	cmd.AddCommand(NewCmdStepVerify(f, out, errOut))
 	return cmd
 }
 
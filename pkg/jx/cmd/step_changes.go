@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// StepChangesOptions contains the command line flags
+type StepChangesOptions struct {
+	StepOptions
+
+	Dir  string
+	Base string
+}
+
+var (
+	stepChangesLong = templates.LongDesc(`
+		This pipeline step decides whether a pipeline should run based on the
+		files changed since the base branch and the path filters declared in the
+		trigger section of jenkins-x.yml.
+
+		It prints 'true' when the pipeline should run and 'false' when all of
+		the changed files are filtered out, so it can be used inside a when
+		expression of a declarative pipeline:
+
+		    when {
+		      expression {
+		        sh(returnStdout: true, script: 'jx step changes').trim() == 'true'
+		      }
+		    }
+
+		Patterns ending in / match whole directories while other patterns are
+		matched against the file path and the file name, so *.md matches any
+		markdown file in the repository.
+`)
+
+	stepChangesExample = templates.Examples(`
+		# Decide whether the pipeline should run for the changes since master
+		jx step changes
+
+		# Compare against a different base branch
+		jx step changes --base origin/develop
+`)
+)
+
+// NewCmdStepChanges Steps a command object for the "changes" command
+func NewCmdStepChanges(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepChangesOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "changes",
+		Short:   "Decides whether a pipeline should run based on the changed paths",
+		Long:    stepChangesLong,
+		Example: stepChangesExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "The directory of the project. Defaults to the current directory")
+	cmd.Flags().StringVarP(&options.Base, "base", "", "", "The base branch to compare against. Defaults to $CHANGE_TARGET or origin/master")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepChangesOptions) Run() error {
+	dir := o.Dir
+	if dir == "" {
+		dir = "."
+	}
+	projectConfig, err := config.LoadProjectConfig(dir)
+	if err != nil {
+		return err
+	}
+	trigger := projectConfig.Trigger
+	if trigger == nil {
+		o.Printf("true\n")
+		return nil
+	}
+	base := o.Base
+	if base == "" {
+		base = os.Getenv("CHANGE_TARGET")
+		if base != "" {
+			base = "origin/" + base
+		} else {
+			base = "origin/master"
+		}
+	}
+	files, err := gits.GitListChangedFilesFromBranch(dir, base)
+	if err != nil {
+		return err
+	}
+	if shouldTriggerPipeline(trigger, files) {
+		o.Printf("true\n")
+	} else {
+		o.warnf("All %d changed file(s) are filtered out by the trigger configuration of %s\n",
+			len(files), util.ColorInfo(config.ProjectConfigFileName))
+		o.Printf("false\n")
+	}
+	return nil
+}
+
+// shouldTriggerPipeline returns true if any of the changed files passes the
+// path filters of the trigger configuration
+func shouldTriggerPipeline(trigger *config.TriggerConfig, files []string) bool {
+	for _, file := range files {
+		if matchesAnyPathFilter(trigger.IgnorePaths, file) {
+			continue
+		}
+		if len(trigger.Paths) == 0 || matchesAnyPathFilter(trigger.Paths, file) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAnyPathFilter returns true if the file matches one of the patterns
+func matchesAnyPathFilter(patterns []string, file string) bool {
+	for _, pattern := range patterns {
+		if matchesPathFilter(pattern, file) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPathFilter returns true if the file matches the pattern. Patterns
+// ending in / match the whole directory tree while other patterns are matched
+// against the full path and the file name
+func matchesPathFilter(pattern string, file string) bool {
+	if strings.HasSuffix(pattern, "/") {
+		return strings.HasPrefix(file, pattern)
+	}
+	if answer, _ := filepath.Match(pattern, file); answer {
+		return true
+	}
+	answer, _ := filepath.Match(pattern, filepath.Base(file))
+	return answer
+}
@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// StepE2EOptions contains the command line flags
+type StepE2EOptions struct {
+	StepOptions
+
+	Dir       string
+	App       string
+	Namespace string
+	LogDir    string
+	Keep      bool
+}
+
+var (
+	stepE2ELong = templates.LongDesc(`
+		This pipeline step runs the end to end test suite of a project against a
+		disposable test environment.
+
+		It creates a temporary namespace, installs the dependencies and the
+		application chart into it, runs the e2e command declared in the e2e
+		section of jenkins-x.yml with $E2E_NAMESPACE pointing at the environment
+		and tears the namespace down again afterwards. If the tests fail the pod
+		logs of the environment are collected first so that they can be archived
+		by the pipeline.
+
+		The step does nothing if the project has no e2e configuration so it is
+		safe to include in Pull Request pipelines unconditionally.
+`)
+
+	stepE2EExample = templates.Examples(`
+		# Run the e2e tests of the current project
+		jx step e2e
+
+		# Run the e2e tests keeping the test namespace around for debugging
+		jx step e2e --keep
+`)
+)
+
+// NewCmdStepE2E Steps a command object for the "e2e" command
+func NewCmdStepE2E(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepE2EOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "e2e",
+		Short:   "Runs the e2e tests of a project in a disposable environment",
+		Long:    stepE2ELong,
+		Example: stepE2EExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "The directory of the project. Defaults to the current directory")
+	cmd.Flags().StringVarP(&options.App, "app", "a", "", "The application name. Defaults to the project directory name")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "", "", "The namespace to use for the test environment. Defaults to a generated name")
+	cmd.Flags().StringVarP(&options.LogDir, "log-dir", "", "e2e-logs", "The directory to collect the pod logs into when the tests fail")
+	cmd.Flags().BoolVarP(&options.Keep, "keep", "", false, "Keep the test namespace around after the tests for debugging")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepE2EOptions) Run() error {
+	dir := o.Dir
+	if dir == "" {
+		dir = "."
+	}
+	projectConfig, err := config.LoadProjectConfig(dir)
+	if err != nil {
+		return err
+	}
+	e2e := projectConfig.E2E
+	if e2e == nil {
+		o.Printf("The project has no e2e configuration in %s so there is nothing to do\n", config.ProjectConfigFileName)
+		return nil
+	}
+	app := o.App
+	if app == "" {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return err
+		}
+		app = filepath.Base(absDir)
+	}
+	app = kube.ToValidName(app)
+	ns := o.Namespace
+	if ns == "" {
+		ns = kube.ToValidName(fmt.Sprintf("jx-e2e-%s-%d", app, time.Now().Unix()))
+	}
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	o.Printf("Creating the test environment namespace %s\n", util.ColorInfo(ns))
+	err = kube.EnsureNamespaceCreated(client, ns, map[string]string{"app": app, "jenkins.io/kind": "e2e"}, nil)
+	if err != nil {
+		return err
+	}
+
+	testErr := o.runTests(client, ns, dir, app, e2e)
+	if testErr != nil {
+		o.warnf("The e2e tests failed: %s\n", testErr)
+		o.collectLogs(client, ns, dir)
+	}
+	if o.Keep {
+		o.Printf("Keeping the test namespace %s for debugging. Delete it with: %s\n",
+			util.ColorInfo(ns), util.ColorInfo("kubectl delete namespace "+ns))
+	} else {
+		o.Printf("Deleting the test namespace %s\n", util.ColorInfo(ns))
+		err = client.CoreV1().Namespaces().Delete(ns, &metav1.DeleteOptions{})
+		if err != nil {
+			o.warnf("Failed to delete the test namespace %s: %s\n", ns, err)
+		}
+	}
+	if testErr == nil {
+		o.Printf("The e2e tests of %s %s\n", util.ColorInfo(app), util.ColorInfo("passed"))
+	}
+	return testErr
+}
+
+// runTests deploys the dependencies and the application into the test namespace
+// and runs the e2e command against it
+func (o *StepE2EOptions) runTests(client *kubernetes.Clientset, ns string, dir string, app string, e2e *config.E2EConfig) error {
+	envVars := map[string]string{}
+	for _, dependency := range e2e.Dependencies {
+		releaseName := ns + "-" + dependency.Name
+		o.Printf("Installing the e2e dependency %s from chart %s\n", util.ColorInfo(dependency.Name), util.ColorInfo(dependency.Chart))
+		args := []string{"upgrade", releaseName, dependency.Chart, "--install", "--wait", "--namespace", ns}
+		if dependency.Version != "" {
+			args = append(args, "--version", dependency.Version)
+		}
+		for _, key := range util.SortedMapKeys(dependency.Values) {
+			args = append(args, "--set", key+"="+dependency.Values[key])
+		}
+		err := o.runCommand("helm", args...)
+		if err != nil {
+			return fmt.Errorf("Failed to install the e2e dependency %s due to: %s", dependency.Name, err)
+		}
+		for k, v := range dependency.Env {
+			envVars[k] = v
+		}
+	}
+
+	chartDir := filepath.Join(dir, "charts", app)
+	chartFile := filepath.Join(chartDir, "Chart.yaml")
+	exists, err := util.FileExists(chartFile)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("Could not find the application chart at %s", chartFile)
+	}
+	args := []string{"upgrade", ns, chartDir, "--install", "--wait", "--namespace", ns}
+	for _, key := range util.SortedMapKeys(envVars) {
+		args = append(args, "--set", "env."+key+"="+envVars[key])
+	}
+	err = o.runCommand("helm", args...)
+	if err != nil {
+		return fmt.Errorf("Failed to install the application chart due to: %s", err)
+	}
+
+	timeout := e2e.TimeoutMinutes
+	if timeout <= 0 {
+		timeout = 10
+	}
+	o.Printf("Waiting for the test environment %s to become ready\n", util.ColorInfo(ns))
+	err = kube.WaitForAllDeploymentsToBeReady(client, ns, time.Duration(timeout)*time.Minute)
+	if err != nil {
+		return fmt.Errorf("The test environment failed to become ready due to: %s", err)
+	}
+
+	o.Printf("Running the e2e tests: %s\n", util.ColorInfo(e2e.Command))
+	e := exec.Command("sh", "-c", e2e.Command)
+	e.Dir = dir
+	e.Stdout = o.Out
+	e.Stderr = o.Err
+	env := append(os.Environ(), "E2E_NAMESPACE="+ns)
+	for _, key := range util.SortedMapKeys(envVars) {
+		env = append(env, key+"="+envVars[key])
+	}
+	e.Env = env
+	return e.Run()
+}
+
+// collectLogs writes the pod logs of the test namespace into the log directory
+// so that the pipeline can archive them for debugging the failure
+func (o *StepE2EOptions) collectLogs(client *kubernetes.Clientset, ns string, dir string) {
+	logDir := o.LogDir
+	if !filepath.IsAbs(logDir) {
+		logDir = filepath.Join(dir, logDir)
+	}
+	err := os.MkdirAll(logDir, DefaultWritePermissions)
+	if err != nil {
+		o.warnf("Failed to create the log directory %s: %s\n", logDir, err)
+		return
+	}
+	pods, err := client.CoreV1().Pods(ns).List(metav1.ListOptions{})
+	if err != nil {
+		o.warnf("Failed to list the pods in namespace %s: %s\n", ns, err)
+		return
+	}
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			data, err := client.CoreV1().Pods(ns).GetLogs(pod.Name, &corev1.PodLogOptions{Container: container.Name}).DoRaw()
+			if err != nil {
+				o.warnf("Failed to get the logs of pod %s container %s: %s\n", pod.Name, container.Name, err)
+				continue
+			}
+			fileName := filepath.Join(logDir, pod.Name+"-"+container.Name+".log")
+			err = ioutil.WriteFile(fileName, data, DefaultWritePermissions)
+			if err != nil {
+				o.warnf("Failed to write the log file %s: %s\n", fileName, err)
+			}
+		}
+	}
+	o.Printf("Collected the pod logs of namespace %s into %s\n", util.ColorInfo(ns), util.ColorInfo(logDir))
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+)
+
+// StepEnvOptions contains the command line flags
+type StepEnvOptions struct {
+	StepOptions
+}
+
+// NewCmdStepEnv Steps a command object for the "env" command
+func NewCmdStepEnv(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepEnvOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "env [command]",
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.AddCommand(NewCmdStepEnvApply(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *StepEnvOptions) Run() error {
+	return o.Cmd.Help()
+}
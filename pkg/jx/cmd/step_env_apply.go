@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// StepEnvApplyOptions contains the command line flags
+type StepEnvApplyOptions struct {
+	StepOptions
+
+	Dir         string
+	Namespace   string
+	ReleaseName string
+	Wait        bool
+	Timeout     int
+}
+
+// envRequirements models the requirements.yaml of an environment chart listing
+// the app charts to deploy with their versions
+type envRequirements struct {
+	Dependencies []envDependency `yaml:"dependencies"`
+}
+
+type envDependency struct {
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	Repository string `yaml:"repository"`
+}
+
+var (
+	stepEnvApplyLong = templates.LongDesc(`
+		This pipeline step applies an environment git repository to the environment's
+		namespace.
+
+		It resolves the app charts and versions from the requirements of the
+		environment chart, performs the helm dependency resolution, applies the
+		chart and then waits for the deployments to become ready.
+`)
+
+	stepEnvApplyExample = templates.Examples(`
+		# Apply the environment repository in the current directory
+		jx step env apply --namespace jx-staging
+`)
+)
+
+// NewCmdStepEnvApply Steps a command object for the "env apply" command
+func NewCmdStepEnvApply(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepEnvApplyOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "apply",
+		Short:   "Applies the environment chart to the environment namespace",
+		Long:    stepEnvApplyLong,
+		Example: stepEnvApplyExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "The directory of the environment git repository. Defaults to the current directory")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "", "", "The namespace to deploy the environment into")
+	cmd.Flags().StringVarP(&options.ReleaseName, "release", "", "", "The helm release name. Defaults to the namespace")
+	cmd.Flags().BoolVarP(&options.Wait, "wait", "", true, "Waits for the deployments to become ready after applying the chart")
+	cmd.Flags().IntVarP(&options.Timeout, "timeout", "", 600, "The number of seconds to wait for each deployment to become ready")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepEnvApplyOptions) Run() error {
+	ns := o.Namespace
+	if ns == "" {
+		return util.MissingOption("namespace")
+	}
+	releaseName := o.ReleaseName
+	if releaseName == "" {
+		releaseName = ns
+	}
+	dir := o.Dir
+	if dir == "" {
+		dir = "."
+	}
+	chartDir := filepath.Join(dir, "env")
+	chartFile := filepath.Join(chartDir, "Chart.yaml")
+	exists, err := util.FileExists(chartFile)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("Could not find an environment chart at %s. Is %s an environment git repository?", chartFile, dir)
+	}
+
+	requirements, err := loadEnvRequirements(chartDir)
+	if err != nil {
+		return err
+	}
+	if len(requirements.Dependencies) == 0 {
+		o.Printf("The environment has no apps to deploy\n")
+	} else {
+		o.Printf("Applying %d app(s) to namespace %s:\n", len(requirements.Dependencies), util.ColorInfo(ns))
+		for _, dependency := range requirements.Dependencies {
+			o.Printf("    %s version %s\n", util.ColorInfo(dependency.Name), util.ColorInfo(dependency.Version))
+		}
+	}
+
+	err = o.runCommand("helm", "repo", "update")
+	if err != nil {
+		return err
+	}
+	err = o.runCommandFromDir(dir, "helm", "dependency", "build", chartDir)
+	if err != nil {
+		return fmt.Errorf("Failed to resolve the chart dependencies due to: %s", err)
+	}
+	err = o.runCommandFromDir(dir, "helm", "upgrade", "--install", "--namespace", ns, releaseName, chartDir)
+	if err != nil {
+		return fmt.Errorf("Failed to apply the environment chart due to: %s", err)
+	}
+
+	if o.Wait {
+		client, _, err := o.Factory.CreateClient()
+		if err != nil {
+			return err
+		}
+		o.Printf("Waiting for the deployments in namespace %s to become ready\n", util.ColorInfo(ns))
+		err = kube.WaitForAllDeploymentsToBeReady(client, ns, time.Duration(o.Timeout)*time.Second)
+		if err != nil {
+			return fmt.Errorf("The environment failed its health checks due to: %s", err)
+		}
+	}
+	o.Printf("Environment applied to namespace %s\n", util.ColorInfo(ns))
+	return nil
+}
+
+// loadEnvRequirements loads the requirements.yaml of the environment chart
+func loadEnvRequirements(chartDir string) (*envRequirements, error) {
+	requirements := &envRequirements{}
+	fileName := filepath.Join(chartDir, "requirements.yaml")
+	exists, err := util.FileExists(fileName)
+	if err != nil {
+		return requirements, err
+	}
+	if !exists {
+		return requirements, nil
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return requirements, fmt.Errorf("Failed to load %s due to %s", fileName, err)
+	}
+	err = yaml.Unmarshal(data, requirements)
+	if err != nil {
+		return requirements, fmt.Errorf("Failed to unmarshal %s due to %s", fileName, err)
+	}
+	return requirements, nil
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// gpgSecretName the name of the secret storing the team GPG signing key
+	gpgSecretName = "jx-gpg"
+	// gpgSecretKey the secret entry holding the armored private GPG key
+	gpgSecretKey = "gpg.key"
+)
+
+// StepGPGOptions contains the command line flags
+type StepGPGOptions struct {
+	StepOptions
+
+	Cleanup bool
+}
+
+var (
+	stepGPGLong = templates.LongDesc(`
+		Provisions the team GPG key into a pipeline pod and configures git signing.
+
+		The armored private key is read from the %s secret, imported into the local
+		GPG keyring and git is configured to sign commits and tags with it, so release
+		pipelines can produce signed tags and signed artifacts. Running the step with
+		--cleanup removes the key from the keyring and the git configuration again.
+`)
+
+	stepGPGExample = templates.Examples(`
+		# Import the team GPG key and configure git signing
+		jx step gpg
+
+		# Remove the key and the git signing configuration at the end of the pipeline
+		jx step gpg --cleanup
+	`)
+)
+
+// NewCmdStepGPG creates the command
+func NewCmdStepGPG(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepGPGOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "gpg",
+		Short:   "Imports the team GPG key and configures git signing",
+		Long:    fmt.Sprintf(stepGPGLong, gpgSecretName),
+		Example: stepGPGExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().BoolVarP(&options.Cleanup, "cleanup", "", false, "Removes the imported key and the git signing configuration")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepGPGOptions) Run() error {
+	if o.Cleanup {
+		return o.cleanup()
+	}
+	return o.setup()
+}
+
+// setup imports the team GPG key and configures git to sign commits and tags with it
+func (o *StepGPGOptions) setup() error {
+	kubeClient, currentNs, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err := kube.GetDevNamespace(kubeClient, currentNs)
+	if err != nil {
+		return err
+	}
+	secret, err := kubeClient.CoreV1().Secrets(ns).Get(gpgSecretName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to find the secret %s in namespace %s due to: %s", gpgSecretName, ns, err)
+	}
+	key := secret.Data[gpgSecretKey]
+	if len(key) == 0 {
+		return fmt.Errorf("The secret %s in namespace %s does not contain the entry %s", gpgSecretName, ns, gpgSecretKey)
+	}
+
+	keyFile, err := ioutil.TempFile("", "jx-gpg-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(keyFile.Name())
+	_, err = keyFile.Write(key)
+	if err != nil {
+		return err
+	}
+	err = keyFile.Close()
+	if err != nil {
+		return err
+	}
+	err = o.runCommand("gpg", "--batch", "--import", keyFile.Name())
+	if err != nil {
+		return fmt.Errorf("Failed to import the GPG key due to: %s", err)
+	}
+
+	keyID, err := o.signingKeyID()
+	if err != nil {
+		return err
+	}
+	for _, args := range [][]string{
+		{"config", "--global", "user.signingkey", keyID},
+		{"config", "--global", "commit.gpgsign", "true"},
+		{"config", "--global", "tag.forceSignAnnotated", "true"},
+	} {
+		err = o.runCommand("git", args...)
+		if err != nil {
+			return fmt.Errorf("Failed to configure git signing due to: %s", err)
+		}
+	}
+	o.Printf("Imported the GPG key %s and configured git to sign commits and tags\n", util.ColorInfo(keyID))
+	return nil
+}
+
+// cleanup removes the imported key from the keyring and unsets the git signing configuration
+func (o *StepGPGOptions) cleanup() error {
+	keyID, err := o.getCommandOutput("", "git", "config", "--global", "user.signingkey")
+	if err != nil || keyID == "" {
+		o.warnf("No git signing key is configured so there is nothing to clean up\n")
+		return nil
+	}
+	keyID = strings.TrimSpace(keyID)
+
+	for _, fingerprint := range o.keyFingerprints(keyID) {
+		err = o.runCommand("gpg", "--batch", "--yes", "--delete-secret-and-public-key", fingerprint)
+		if err != nil {
+			o.warnf("Failed to delete the GPG key %s: %s\n", fingerprint, err)
+		}
+	}
+	for _, args := range [][]string{
+		{"config", "--global", "--unset", "user.signingkey"},
+		{"config", "--global", "--unset", "commit.gpgsign"},
+		{"config", "--global", "--unset", "tag.forceSignAnnotated"},
+	} {
+		err = o.runCommand("git", args...)
+		if err != nil {
+			o.warnf("Failed to unset the git signing configuration: %s\n", err)
+		}
+	}
+	o.Printf("Removed the GPG key %s and the git signing configuration\n", util.ColorInfo(keyID))
+	return nil
+}
+
+// signingKeyID returns the ID of the first secret key in the keyring
+func (o *StepGPGOptions) signingKeyID() (string, error) {
+	output, err := o.getCommandOutput("", "gpg", "--list-secret-keys", "--with-colons")
+	if err != nil {
+		return "", fmt.Errorf("Failed to list the GPG keys due to: %s", err)
+	}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, ":")
+		if len(fields) > 4 && fields[0] == "sec" {
+			return fields[4], nil
+		}
+	}
+	return "", fmt.Errorf("No secret GPG key found in the keyring after the import")
+}
+
+// keyFingerprints returns the fingerprints of the keys matching the given key ID
+func (o *StepGPGOptions) keyFingerprints(keyID string) []string {
+	fingerprints := []string{}
+	output, err := o.getCommandOutput("", "gpg", "--list-secret-keys", "--with-colons", keyID)
+	if err != nil {
+		return fingerprints
+	}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, ":")
+		if len(fields) > 9 && fields[0] == "fpr" {
+			fingerprints = append(fingerprints, fields[9])
+		}
+	}
+	return fingerprints
+}
@@ -40,6 +40,7 @@ func NewCmdStepPR(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Com
 	}
 
 	cmd.AddCommand(NewCmdStepPRComment(f, out, errOut))
+	cmd.AddCommand(NewCmdStepPRValidate(f, out, errOut))
 	options.addCommonFlags(cmd)
 
 	return cmd
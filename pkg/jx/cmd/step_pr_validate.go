@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// conventionalCommitPattern matches a conventional commit subject such as
+// 'feat(api): add the thing' so that the changelog and semantic versioning
+// features can classify the change
+var conventionalCommitPattern = regexp.MustCompile(`^(feat|fix|docs|chore|refactor|test|style|perf|build|ci|revert)(\([^)]+\))?!?: .+`)
+
+// StepPRValidateOptions contains the command line flags
+type StepPRValidateOptions struct {
+	StepPROptions
+
+	Dir         string
+	Base        string
+	Title       string
+	Owner       string
+	Repository  string
+	PullRequest string
+	NoComment   bool
+}
+
+var (
+	stepPRValidateLong = templates.LongDesc(`
+		This pipeline step validates that the Pull Request title and the commit
+		messages follow the conventional commit format, such as 'fix: a thing'
+		or 'feat(api): add the thing', which the changelog generation and
+		semantic versioning rely on.
+
+		When the validation fails the problems are posted as a comment on the
+		Pull Request so that the author gets actionable feedback instead of an
+		obscure failure at release time, and the step exits with an error to
+		fail the check.
+`)
+
+	stepPRValidateExample = templates.Examples(`
+		# Validate the Pull Request title and commits in a pipeline
+		jx step pr validate --owner jstrachan --repository myapp
+`)
+)
+
+// NewCmdStepPRValidate Steps a command object for the "pr validate" command
+func NewCmdStepPRValidate(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepPRValidateOptions{
+		StepPROptions: StepPROptions{
+			StepOptions: StepOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "validate",
+		Short:   "Validates that the Pull Request title and commits are conventional",
+		Long:    stepPRValidateLong,
+		Example: stepPRValidateExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "The directory of the project. Defaults to the current directory")
+	cmd.Flags().StringVarP(&options.Base, "base", "", "", "The base branch to validate the commits since. Defaults to $CHANGE_TARGET or origin/master")
+	cmd.Flags().StringVarP(&options.Title, "title", "t", "", "The Pull Request title to validate. Defaults to $CHANGE_TITLE")
+	cmd.Flags().StringVarP(&options.Owner, "owner", "o", "", "git organisation / owner")
+	cmd.Flags().StringVarP(&options.Repository, "repository", "r", "", "git repository")
+	cmd.Flags().StringVarP(&options.PullRequest, "pull-request", "p", "", "git pull request number. Defaults to $CHANGE_ID")
+	cmd.Flags().BoolVarP(&options.NoComment, "no-comment", "", false, "Disable posting the feedback as a comment on the Pull Request")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepPRValidateOptions) Run() error {
+	dir := o.Dir
+	if dir == "" {
+		dir = "."
+	}
+	title := o.Title
+	if title == "" {
+		title = os.Getenv("CHANGE_TITLE")
+	}
+	problems := []string{}
+	if title != "" && !isConventionalMessage(title) {
+		problems = append(problems, fmt.Sprintf("the Pull Request title `%s` is not a conventional commit message", title))
+	}
+	base := o.Base
+	if base == "" {
+		base = os.Getenv("CHANGE_TARGET")
+		if base != "" {
+			base = "origin/" + base
+		} else {
+			base = "origin/master"
+		}
+	}
+	subjects, err := gitCommitSubjectsFromBranch(dir, base)
+	if err != nil {
+		o.warnf("Could not load the commit messages since %s: %s\n", base, err)
+	}
+	for _, subject := range subjects {
+		if !isConventionalMessage(subject) {
+			problems = append(problems, fmt.Sprintf("the commit message `%s` is not a conventional commit message", subject))
+		}
+	}
+	if len(problems) == 0 {
+		o.Printf("The Pull Request title and %s commit message(s) are all conventional\n",
+			util.ColorInfo(fmt.Sprintf("%d", len(subjects))))
+		return nil
+	}
+	o.Printf("%s\n", util.ColorError("The Pull Request failed the semantic validation:"))
+	for _, problem := range problems {
+		o.Printf("    %s\n", problem)
+	}
+	o.commentOnPullRequest(problems)
+	return fmt.Errorf("Found %d semantic validation problems", len(problems))
+}
+
+// commentOnPullRequest posts the validation problems as a comment on the Pull
+// Request so that the author knows how to fix them
+func (o *StepPRValidateOptions) commentOnPullRequest(problems []string) {
+	if o.NoComment {
+		return
+	}
+	prText := o.PullRequest
+	if prText == "" {
+		prText = strings.TrimPrefix(os.Getenv("CHANGE_ID"), "PR-")
+	}
+	if prText == "" || o.Owner == "" || o.Repository == "" {
+		return
+	}
+	prNumber, err := strconv.Atoi(prText)
+	if err != nil {
+		o.warnf("Invalid pull request number %s: %s\n", prText, err)
+		return
+	}
+	comment := ":no_entry_sign: The semantic validation of this PR failed:\n"
+	for _, problem := range problems {
+		comment += "\n* " + problem
+	}
+	comment += "\n\nPlease use conventional commit messages such as `fix: a thing` or `feat(api): add the thing` so that the changelog and release notes can be generated."
+
+	authConfigSvc, err := o.Factory.CreateGitAuthConfigService()
+	if err != nil {
+		o.warnf("Could not comment on the Pull Request: %s\n", err)
+		return
+	}
+	gitInfo, err := gits.GetGitInfo("")
+	if err != nil {
+		o.warnf("Could not comment on the Pull Request: %s\n", err)
+		return
+	}
+	provider, err := gitInfo.PickOrCreateProvider(authConfigSvc, "user name to submit comment as", o.BatchMode)
+	if err != nil {
+		o.warnf("Could not comment on the Pull Request: %s\n", err)
+		return
+	}
+	pr := gits.GitPullRequest{
+		Owner:  o.Owner,
+		Repo:   o.Repository,
+		Number: &prNumber,
+	}
+	err = provider.AddPRComment(&pr, comment)
+	if err != nil {
+		o.warnf("Failed to comment on the Pull Request: %s\n", err)
+	}
+}
+
+// isConventionalMessage returns true if the message follows the conventional
+// commit format. Merge and revert commits are allowed through
+func isConventionalMessage(message string) bool {
+	if strings.HasPrefix(message, "Merge ") || strings.HasPrefix(message, "Revert ") {
+		return true
+	}
+	return conventionalCommitPattern.MatchString(message)
+}
+
+// gitCommitSubjectsFromBranch returns the commit subjects on the current branch
+// since it diverged from the given branch
+func gitCommitSubjectsFromBranch(dir string, branch string) ([]string, error) {
+	text, err := util.GetCommandOutput(dir, "git", "log", "--pretty=%s", branch+"..HEAD")
+	if err != nil {
+		return nil, err
+	}
+	answer := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			answer = append(answer, line)
+		}
+	}
+	return answer, nil
+}
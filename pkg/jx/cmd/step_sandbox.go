@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+)
+
+// StepSandboxOptions contains the command line flags
+type StepSandboxOptions struct {
+	StepOptions
+}
+
+// NewCmdStepSandbox Steps a command object for the "sandbox" command
+func NewCmdStepSandbox(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepSandboxOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "sandbox",
+		Short: "sandbox [command]",
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.AddCommand(NewCmdStepSandboxRun(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *StepSandboxOptions) Run() error {
+	return o.Cmd.Help()
+}
@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// sandboxCreatedByLabel the label marking namespaces created by the sandbox step
+const sandboxCreatedByLabel = "jenkins.io/created-by"
+
+// StepSandboxRunOptions contains the command line flags
+type StepSandboxRunOptions struct {
+	StepOptions
+
+	Prefix string
+	Keep   bool
+}
+
+var (
+	stepSandboxRunLong = templates.LongDesc(`
+		Runs a command inside a throwaway namespace seeded with the team baseline.
+
+		A namespace with a random name is created and the resource quotas, limit
+		ranges, network policies and registry secrets of the team namespace are
+		copied into it. The given command is then run with the NAMESPACE and
+		JX_SANDBOX_NAMESPACE environment variables pointing at the sandbox and the
+		namespace is deleted again when the command finishes, whether it passed or
+		failed. This makes it easy to test charts and operators in pipelines without
+		leaking resources.
+`)
+
+	stepSandboxRunExample = templates.Examples(`
+		# Install a chart in a sandbox namespace and verify its pods start
+		jx step sandbox run -- sh -c 'helm install --namespace $NAMESPACE ./charts/myapp && kubectl rollout status deploy/myapp -n $NAMESPACE'
+
+		# Keep the sandbox around for debugging when the command fails
+		jx step sandbox run --keep -- make e2e
+	`)
+)
+
+// NewCmdStepSandboxRun creates the command
+func NewCmdStepSandboxRun(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepSandboxRunOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "run [flags] -- command [arguments]...",
+		Short:   "Runs a command inside a throwaway namespace seeded with the team baseline",
+		Long:    stepSandboxRunLong,
+		Example: stepSandboxRunExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Prefix, "prefix", "p", "jx-sandbox", "The name prefix of the sandbox namespace")
+	cmd.Flags().BoolVarP(&options.Keep, "keep", "", false, "Keeps the sandbox namespace instead of deleting it when the command finishes")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepSandboxRunOptions) Run() error {
+	args := o.Args
+	if len(args) == 0 {
+		return fmt.Errorf("Missing the command to run. Usage: jx step sandbox run -- <command>")
+	}
+	kubeClient, currentNs, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	devNs, _, err := kube.GetDevNamespace(kubeClient, currentNs)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s", o.Prefix, randomSandboxSuffix(5))
+	_, err = kubeClient.CoreV1().Namespaces().Create(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				sandboxCreatedByLabel: "jx-sandbox",
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to create the sandbox namespace %s due to: %s", name, err)
+	}
+	o.Printf("Created the sandbox namespace %s\n", util.ColorInfo(name))
+
+	if !o.Keep {
+		defer o.deleteSandbox(kubeClient, name)
+	}
+
+	err = o.copyBaseline(kubeClient, devNs, name)
+	if err != nil {
+		return err
+	}
+
+	e := exec.Command(args[0], args[1:]...)
+	e.Env = append(os.Environ(), "NAMESPACE="+name, "JX_SANDBOX_NAMESPACE="+name)
+	e.Stdout = o.Out
+	e.Stderr = o.Err
+	e.Stdin = os.Stdin
+	err = e.Run()
+	if err != nil {
+		return fmt.Errorf("The command failed in the sandbox namespace %s: %s", name, err)
+	}
+	return nil
+}
+
+// deleteSandbox removes the sandbox namespace and everything in it
+func (o *StepSandboxRunOptions) deleteSandbox(kubeClient *kubernetes.Clientset, name string) {
+	err := kubeClient.CoreV1().Namespaces().Delete(name, nil)
+	if err != nil && !errors.IsNotFound(err) {
+		o.warnf("Failed to delete the sandbox namespace %s: %s\n", name, err)
+		return
+	}
+	o.Printf("Deleted the sandbox namespace %s\n", util.ColorInfo(name))
+}
+
+// copyBaseline copies the resource quotas, limit ranges, network policies and
+// registry secrets of the team namespace into the sandbox
+func (o *StepSandboxRunOptions) copyBaseline(kubeClient *kubernetes.Clientset, devNs string, ns string) error {
+	quotas, err := kubeClient.CoreV1().ResourceQuotas(devNs).List(metav1.ListOptions{})
+	if err == nil {
+		for _, quota := range quotas.Items {
+			copy := &corev1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: quota.Name, Labels: quota.Labels},
+				Spec:       quota.Spec,
+			}
+			_, err = kubeClient.CoreV1().ResourceQuotas(ns).Create(copy)
+			if err != nil {
+				return fmt.Errorf("Failed to copy the resource quota %s into the sandbox due to: %s", quota.Name, err)
+			}
+		}
+	}
+	limits, err := kubeClient.CoreV1().LimitRanges(devNs).List(metav1.ListOptions{})
+	if err == nil {
+		for _, limit := range limits.Items {
+			copy := &corev1.LimitRange{
+				ObjectMeta: metav1.ObjectMeta{Name: limit.Name, Labels: limit.Labels},
+				Spec:       limit.Spec,
+			}
+			_, err = kubeClient.CoreV1().LimitRanges(ns).Create(copy)
+			if err != nil {
+				return fmt.Errorf("Failed to copy the limit range %s into the sandbox due to: %s", limit.Name, err)
+			}
+		}
+	}
+	policies, err := kubeClient.NetworkingV1().NetworkPolicies(devNs).List(metav1.ListOptions{})
+	if err == nil {
+		for _, policy := range policies.Items {
+			copy := policy.DeepCopy()
+			copy.ObjectMeta = metav1.ObjectMeta{Name: policy.Name, Labels: policy.Labels}
+			_, err = kubeClient.NetworkingV1().NetworkPolicies(ns).Create(copy)
+			if err != nil {
+				return fmt.Errorf("Failed to copy the network policy %s into the sandbox due to: %s", policy.Name, err)
+			}
+		}
+	}
+	for _, secretName := range []string{kube.SecretJenkinsDockerConfig, kube.SecretDockerRegistryPull} {
+		secret, err := kubeClient.CoreV1().Secrets(devNs).Get(secretName, metav1.GetOptions{})
+		if err != nil {
+			continue
+		}
+		copy := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: secret.Name, Labels: secret.Labels},
+			Type:       secret.Type,
+			Data:       secret.Data,
+		}
+		_, err = kubeClient.CoreV1().Secrets(ns).Create(copy)
+		if err != nil {
+			return fmt.Errorf("Failed to copy the secret %s into the sandbox due to: %s", secretName, err)
+		}
+	}
+	return nil
+}
+
+// randomSandboxSuffix returns a random lower case string used to name a sandbox namespace
+func randomSandboxSuffix(length int) string {
+	chars := "bcdfghjklmnpqrstvwxz2456789"
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+	suffix := make([]byte, length)
+	for i := range suffix {
+		suffix[i] = chars[random.Intn(len(chars))]
+	}
+	return string(suffix)
+}
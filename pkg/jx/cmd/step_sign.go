@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// signingSecretName the name of the secret storing the artifact signing key pair
+	signingSecretName = "jx-artifact-signing"
+	// signingPrivateKey the secret data key of the PEM encoded private key
+	signingPrivateKey = "signing.key"
+	// signingPublicKey the secret data key of the PEM encoded public key
+	signingPublicKey = "signing.pub"
+	// defaultManifestFile the default file name of the signed artifact manifest
+	defaultManifestFile = "artifact-manifest.yaml"
+)
+
+// ArtifactManifest lists the produced artifacts with their checksums along with
+// a signature over the list so later stages can detect tampering
+type ArtifactManifest struct {
+	Files     map[string]string `yaml:"files"`
+	SignedAt  string            `yaml:"signedAt,omitempty"`
+	Signature string            `yaml:"signature,omitempty"`
+}
+
+// StepSignOptions contains the command line flags
+type StepSignOptions struct {
+	StepOptions
+
+	Dir      string
+	Manifest string
+}
+
+var (
+	stepSignLong = templates.LongDesc(`
+		Signs a manifest of the artifacts produced by the build stage.
+
+		The SHA-256 checksum of each file is recorded in a manifest which is then
+		signed with the team signing key stored in a Kubernetes Secret. The key pair
+		is generated on first use. Deploy and promote stages can then verify the
+		manifest via 'jx step verify signature' before using the artifacts.
+`)
+
+	stepSignExample = templates.Examples(`
+		# Sign the artifacts in the charts directory
+		jx step sign --dir charts
+
+		# Sign the artifacts in the current directory writing the manifest to a custom file
+		jx step sign --manifest my-manifest.yaml
+	`)
+)
+
+// NewCmdStepSign creates the command
+func NewCmdStepSign(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepSignOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "sign",
+		Short:   "Signs a manifest of the artifacts produced by the build",
+		Long:    stepSignLong,
+		Example: stepSignExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", ".", "The directory containing the artifacts to sign")
+	cmd.Flags().StringVarP(&options.Manifest, "manifest", "m", "", "The file to write the signed manifest to. Defaults to "+defaultManifestFile+" in the artifact directory")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepSignOptions) Run() error {
+	manifestFile := o.Manifest
+	if manifestFile == "" {
+		manifestFile = filepath.Join(o.Dir, defaultManifestFile)
+	}
+	files, err := artifactChecksums(o.Dir, manifestFile)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("No artifacts found in directory %s", o.Dir)
+	}
+
+	kubeClient, currentNs, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err := kube.GetDevNamespace(kubeClient, currentNs)
+	if err != nil {
+		return err
+	}
+	key, err := getOrCreateSigningKey(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+
+	manifest := &ArtifactManifest{
+		Files:    files,
+		SignedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	payload, err := yaml.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256(payload)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return fmt.Errorf("Failed to sign the artifact manifest due to: %s", err)
+	}
+	manifest.Signature = base64.StdEncoding.EncodeToString(signature)
+
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(manifestFile, data, DefaultWritePermissions)
+	if err != nil {
+		return fmt.Errorf("Failed to write the manifest file %s due to: %s", manifestFile, err)
+	}
+	o.Printf("Signed %s artifact(s) in the manifest %s\n",
+		util.ColorInfo(fmt.Sprintf("%d", len(files))), util.ColorInfo(manifestFile))
+	return nil
+}
+
+// artifactChecksums walks the directory returning the SHA-256 checksum of each
+// file keyed by its relative path, skipping the manifest file itself
+func artifactChecksums(dir string, manifestFile string) (map[string]string, error) {
+	manifestAbs, err := filepath.Abs(manifestFile)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]string{}
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if abs, err := filepath.Abs(path); err == nil && abs == manifestAbs {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("Failed to read the file %s due to: %s", path, err)
+		}
+		files[filepath.ToSlash(rel)] = hex.EncodeToString(sha256Sum(data))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// sha256Sum returns the SHA-256 checksum of the data
+func sha256Sum(data []byte) []byte {
+	digest := sha256.Sum256(data)
+	return digest[:]
+}
+
+// getOrCreateSigningKey loads the team signing key from its Secret generating a
+// new key pair on first use
+func getOrCreateSigningKey(kubeClient *kubernetes.Clientset, ns string) (*rsa.PrivateKey, error) {
+	secrets := kubeClient.CoreV1().Secrets(ns)
+	secret, err := secrets.Get(signingSecretName, metav1.GetOptions{})
+	if err == nil {
+		return parseSigningKey(secret.Data[signingPrivateKey])
+	}
+	if !errors.IsNotFound(err) {
+		return nil, err
+	}
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to generate the signing key due to: %s", err)
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: signingSecretName,
+		},
+		Data: map[string][]byte{
+			signingPrivateKey: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+			signingPublicKey:  pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}),
+		},
+	}
+	_, err = secrets.Create(secret)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create the signing key secret %s due to: %s", signingSecretName, err)
+	}
+	return key, nil
+}
+
+// parseSigningKey parses the PEM encoded private signing key
+func parseSigningKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("The secret %s does not contain a valid PEM encoded signing key", signingSecretName)
+	}
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
+
+// parseSigningPublicKey parses the PEM encoded public signing key
+func parseSigningPublicKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("The secret %s does not contain a valid PEM encoded public key", signingSecretName)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("The public key in the secret %s is not an RSA key", signingSecretName)
+	}
+	return publicKey, nil
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+)
+
+// StepValidateOptions contains the command line flags
+type StepValidateOptions struct {
+	StepOptions
+}
+
+// NewCmdStepValidate Steps a command object for the "validate" command
+func NewCmdStepValidate(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepValidateOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "validate [command]",
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.AddCommand(NewCmdStepValidateConfig(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *StepValidateOptions) Run() error {
+	return o.Cmd.Help()
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// StepValidateConfigOptions contains the command line flags
+type StepValidateConfigOptions struct {
+	StepOptions
+
+	Dir string
+}
+
+var (
+	stepValidateConfigLong = templates.LongDesc(`
+		Validates the ` + config.ProjectConfigFileName + ` of a project.
+
+		The file is checked against the current schema version so that pipelines fail
+		early with a clear message instead of silently ignoring configuration which was
+		written for a different version of jx.
+`)
+
+	stepValidateConfigExample = templates.Examples(`
+		# Validate the configuration of the current project
+		jx step validate config
+
+		# Validate the configuration of a different directory
+		jx step validate config --dir /foo/bar
+	`)
+)
+
+// NewCmdStepValidateConfig creates the command
+func NewCmdStepValidateConfig(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepValidateConfigOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "config",
+		Short:   "Validates the " + config.ProjectConfigFileName + " of a project",
+		Long:    stepValidateConfigLong,
+		Example: stepValidateConfigExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", ".", "The directory of the project to validate")
+	return cmd
+}
+
+// Run implements the command
+func (o *StepValidateConfigOptions) Run() error {
+	fileName := filepath.Join(o.Dir, config.ProjectConfigFileName)
+	exists, err := util.FileExists(fileName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		o.Printf("The project has no %s file so there is nothing to validate\n", util.ColorInfo(config.ProjectConfigFileName))
+		return nil
+	}
+	// loading performs the structural validation and rejects newer schema versions
+	projectConfig, err := config.LoadProjectConfig(o.Dir)
+	if err != nil {
+		return fmt.Errorf("Validation of %s failed: %s", fileName, err)
+	}
+	if projectConfig.Version < config.ProjectConfigVersion {
+		o.warnf("%s has schema version %d but the current version is %d. Consider running: jx upgrade config\n", fileName, projectConfig.Version, config.ProjectConfigVersion)
+	}
+	o.Printf("%s is valid\n", util.ColorInfo(fileName))
+	return nil
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+)
+
+// StepVerifyOptions contains the command line flags
+type StepVerifyOptions struct {
+	StepOptions
+}
+
+// NewCmdStepVerify Steps a command object for the "verify" command
+func NewCmdStepVerify(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepVerifyOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "verify [command]",
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.AddCommand(NewCmdStepVerifyDrift(f, out, errOut))
+	cmd.AddCommand(NewCmdStepVerifyPod(f, out, errOut))
+	cmd.AddCommand(NewCmdStepVerifySignature(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *StepVerifyOptions) Run() error {
+	return o.Cmd.Help()
+}
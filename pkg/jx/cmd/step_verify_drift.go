@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/table"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// StepVerifyDriftOptions contains the command line flags
+type StepVerifyDriftOptions struct {
+	StepOptions
+
+	Environment string
+	Revert      bool
+	PollTime    string
+
+	// calculated fields
+	pollDuration *time.Duration
+}
+
+var (
+	stepVerifyDriftLong = templates.LongDesc(`
+		This pipeline step compares what the environment git repositories declare
+		with what is actually running in the environment namespaces and reports any
+		drift such as manual kubectl or helm edits.
+
+		With --revert the environment chart is re-applied so that the cluster
+		matches git again. With --poll-time the step keeps running and checks the
+		environments periodically like a controller.
+`)
+
+	stepVerifyDriftExample = templates.Examples(`
+		# Report the drift of all environments with a git repository
+		jx step verify drift
+
+		# Check the production environment and revert any manual edits
+		jx step verify drift -e production --revert
+
+		# Run as a controller checking every 10 minutes
+		jx step verify drift --poll-time 10m
+`)
+)
+
+// NewCmdStepVerifyDrift Steps a command object for the "verify drift" command
+func NewCmdStepVerifyDrift(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepVerifyDriftOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "drift",
+		Short:   "Verifies the environments match what their git repositories declare",
+		Long:    stepVerifyDriftLong,
+		Example: stepVerifyDriftExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Environment, "env", "e", "", "The Environment to check. Defaults to all environments with a git repository")
+	cmd.Flags().BoolVarP(&options.Revert, "revert", "", false, "Re-applies the environment chart when drift is found so the cluster matches git again")
+	cmd.Flags().StringVarP(&options.PollTime, "poll-time", "", "", "Keeps running and polls the environments at this interval such as 10m")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepVerifyDriftOptions) Run() error {
+	if o.PollTime != "" {
+		duration, err := time.ParseDuration(o.PollTime)
+		if err != nil {
+			return fmt.Errorf("Invalid duration format %s for option --poll-time: %s", o.PollTime, err)
+		}
+		o.pollDuration = &duration
+	}
+	for {
+		err := o.verifyDrift()
+		if o.pollDuration == nil {
+			return err
+		}
+		if err != nil {
+			o.warnf("Failed to verify the drift: %s\n", err)
+		}
+		time.Sleep(*o.pollDuration)
+	}
+}
+
+// verifyDrift checks each environment once and reports the differences
+func (o *StepVerifyDriftOptions) verifyDrift() error {
+	jxClient, currentNs, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err := kube.GetDevNamespace(kubeClient, currentNs)
+	if err != nil {
+		return err
+	}
+	envList, err := jxClient.JenkinsV1().Environments(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	kube.SortEnvironments(envList.Items)
+
+	checked := 0
+	drifted := 0
+	table := o.CreateTable()
+	table.AddRow("ENVIRONMENT", "APPLICATION", "GIT", "CLUSTER", "STATUS")
+	for i := range envList.Items {
+		env := &envList.Items[i]
+		if o.Environment != "" && env.Name != o.Environment {
+			continue
+		}
+		if env.Spec.Source.URL == "" || env.Spec.Namespace == "" || env.Name == kube.LabelValueDevEnvironment {
+			continue
+		}
+		checked++
+		count, err := o.verifyEnvironmentDrift(env, kubeClient, &table)
+		if err != nil {
+			o.warnf("Failed to check environment %s: %s\n", env.Name, err)
+			continue
+		}
+		drifted += count
+	}
+	if checked == 0 {
+		o.Printf("No environments with a git repository found to check\n")
+		return nil
+	}
+	table.Render()
+	if drifted == 0 {
+		o.Printf("\nNo drift found: the environments match their git repositories\n")
+		return nil
+	}
+	o.Printf("\nFound %s drifted applications\n", util.ColorWarning(drifted))
+	if !o.Revert && o.pollDuration == nil {
+		return fmt.Errorf("The cluster state has drifted from the environment git repositories")
+	}
+	return nil
+}
+
+// verifyEnvironmentDrift compares the apps declared in the environment git
+// repository with the deployments running in its namespace, adding a table row
+// for each difference found
+func (o *StepVerifyDriftOptions) verifyEnvironmentDrift(env *v1.Environment, kubeClient *kubernetes.Clientset, t *table.Table) (int, error) {
+	dir, err := o.cloneEnvironmentRepo(env)
+	if err != nil {
+		return 0, err
+	}
+	requirements, err := loadEnvRequirements(filepath.Join(dir, "env"))
+	if err != nil {
+		return 0, err
+	}
+	declared := map[string]string{}
+	apps := []string{}
+	for _, dep := range requirements.Dependencies {
+		declared[dep.Name] = dep.Version
+		apps = append(apps, dep.Name)
+	}
+	ns := env.Spec.Namespace
+	running := map[string]string{}
+	deployments, err := kube.GetDeployments(kubeClient, ns)
+	if err != nil {
+		return 0, err
+	}
+	for name, d := range deployments {
+		appName := kube.GetAppName(name, ns)
+		running[appName] = kube.GetVersion(&d.ObjectMeta)
+		if _, ok := declared[appName]; !ok {
+			apps = append(apps, appName)
+		}
+	}
+	sort.Strings(apps)
+
+	count := 0
+	for _, app := range apps {
+		gitVersion, inGit := declared[app]
+		clusterVersion, inCluster := running[app]
+		switch {
+		case !inCluster:
+			t.AddRow(env.Name, app, gitVersion, "", "not running")
+			count++
+		case !inGit:
+			t.AddRow(env.Name, app, "", clusterVersion, "not declared in git")
+			count++
+		case gitVersion != clusterVersion:
+			t.AddRow(env.Name, app, gitVersion, clusterVersion, "version drift")
+			count++
+		}
+	}
+	if count > 0 && o.Revert {
+		o.Printf("Reverting environment %s to the state declared in git\n", util.ColorInfo(env.Name))
+		apply := &StepEnvApplyOptions{
+			StepOptions: o.StepOptions,
+			Dir:         dir,
+			Namespace:   ns,
+		}
+		err = apply.Run()
+		if err != nil {
+			return count, fmt.Errorf("Failed to re-apply the environment chart of %s due to: %s", env.Name, err)
+		}
+	}
+	return count, nil
+}
+
+// cloneEnvironmentRepo clones or updates the local copy of the environment git
+// repository and returns its directory
+func (o *StepVerifyDriftOptions) cloneEnvironmentRepo(env *v1.Environment) (string, error) {
+	gitURL := env.Spec.Source.URL
+	gitInfo, err := gits.ParseGitURL(gitURL)
+	if err != nil {
+		return "", err
+	}
+	environmentsDir, err := util.EnvironmentsDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(environmentsDir, gitInfo.Organisation, gitInfo.Name)
+	base := env.Spec.Source.Ref
+	if base == "" {
+		base = "master"
+	}
+	exists, err := util.FileExists(dir)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		err = gits.SetRemoteURL(dir, "origin", gitURL)
+		if err != nil {
+			return "", err
+		}
+		err = gits.GitCmd(dir, "stash")
+		if err != nil {
+			return "", err
+		}
+		err = gits.GitCmd(dir, "checkout", base)
+		if err != nil {
+			return "", err
+		}
+		err = gits.GitCmd(dir, "pull")
+		if err != nil {
+			return "", err
+		}
+	} else {
+		err = os.MkdirAll(dir, DefaultWritePermissions)
+		if err != nil {
+			return "", fmt.Errorf("Failed to create directory %s due to %s", dir, err)
+		}
+		err = gits.GitClone(gitURL, dir)
+		if err != nil {
+			return "", err
+		}
+		if base != "master" {
+			err = gits.GitCmd(dir, "checkout", base)
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+	return dir, nil
+}
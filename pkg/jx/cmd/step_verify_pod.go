@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jenkins"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// StepVerifyPodOptions contains the command line flags
+type StepVerifyPodOptions struct {
+	StepOptions
+
+	Dir       string
+	Label     string
+	Namespace string
+}
+
+var (
+	stepVerifyPodLong = templates.LongDesc(`
+		This step verifies that the agent label used by the Jenkinsfile resolves
+		to a pod template in the Jenkins kubernetes plugin configuration, that
+		the container images of the pod template are available on the cluster
+		nodes and that a node has enough allocatable resources to schedule the
+		agent pod.
+
+		A Jenkinsfile using a label without a matching pod template hangs
+		forever with 'There are no nodes with the label' so catching the
+		problem before a build is queued saves a lot of head scratching.
+`)
+
+	stepVerifyPodExample = templates.Examples(`
+		# Verify the pod template for the Jenkinsfile in the current directory
+		jx step verify pod
+
+		# Verify the pod template for an explicit agent label
+		jx step verify pod --label jenkins-maven
+`)
+)
+
+// NewCmdStepVerifyPod Steps a command object for the "verify pod" command
+func NewCmdStepVerifyPod(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepVerifyPodOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "pod",
+		Short:   "Verifies the pod template and cluster capacity for the pipeline agent",
+		Long:    stepVerifyPodLong,
+		Example: stepVerifyPodExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "The directory containing the Jenkinsfile. Defaults to the current directory")
+	cmd.Flags().StringVarP(&options.Label, "label", "l", "", "The agent label to verify. Defaults to the label used in the Jenkinsfile")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace Jenkins runs in. Defaults to the development namespace")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepVerifyPodOptions) Run() error {
+	label, err := o.agentLabel()
+	if err != nil {
+		return err
+	}
+	kubeClient, currentNs, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns := o.Namespace
+	if ns == "" {
+		ns, _, err = kube.GetDevNamespace(kubeClient, currentNs)
+		if err != nil {
+			return err
+		}
+	}
+	podTemplates, err := o.loadPodTemplates(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+	template := jenkins.FindPodTemplate(podTemplates, label)
+	if template == nil {
+		labels := jenkins.PodTemplateLabels(podTemplates)
+		return fmt.Errorf("No pod template found for the agent label %s. The available pod template labels are: %s. Builds using this label will queue forever with 'There are no nodes with the label'", label, strings.Join(labels, ", "))
+	}
+	o.Printf("The agent label %s resolves to the pod template %s\n", util.ColorInfo(label), util.ColorInfo(template.Name))
+
+	nodes, err := kubeClient.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to list the cluster nodes due to: %s", err)
+	}
+	candidates := o.schedulableNodes(nodes.Items, template.NodeSelector)
+	if len(candidates) == 0 {
+		return fmt.Errorf("No schedulable node matches the pod template %s. Check the node selector %s and that the cluster has ready nodes", template.Name, template.NodeSelector)
+	}
+	o.verifyImages(template, candidates)
+	return o.verifyCapacity(template, candidates)
+}
+
+// agentLabel returns the agent label to verify from the flags or the Jenkinsfile
+func (o *StepVerifyPodOptions) agentLabel() (string, error) {
+	if o.Label != "" {
+		return o.Label, nil
+	}
+	dir := o.Dir
+	if dir == "" {
+		dir = "."
+	}
+	jenkinsfile := filepath.Join(dir, jenkins.DefaultJenkinsfile)
+	exists, err := util.FileExists(jenkinsfile)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", fmt.Errorf("Could not find the file %s. Please specify the agent label via the --label option", jenkinsfile)
+	}
+	data, err := ioutil.ReadFile(jenkinsfile)
+	if err != nil {
+		return "", fmt.Errorf("Failed to load file %s: %s", jenkinsfile, err)
+	}
+	label := jenkins.FindJenkinsfileAgentLabel(string(data))
+	if label == "" {
+		return "", fmt.Errorf("Could not find an agent label in %s. Please specify the agent label via the --label option", jenkinsfile)
+	}
+	return label, nil
+}
+
+// loadPodTemplates loads the pod templates from the Jenkins configuration in
+// the given namespace
+func (o *StepVerifyPodOptions) loadPodTemplates(kubeClient *kubernetes.Clientset, ns string) ([]jenkins.PodTemplate, error) {
+	cm, err := kubeClient.CoreV1().ConfigMaps(ns).Get(kube.ConfigMapJenkins, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load the Jenkins configuration from the ConfigMap %s in namespace %s due to: %s", kube.ConfigMapJenkins, ns, err)
+	}
+	configXml := cm.Data[kube.ConfigMapKeyConfigXml]
+	if configXml == "" {
+		return nil, fmt.Errorf("The ConfigMap %s in namespace %s does not contain a %s entry", kube.ConfigMapJenkins, ns, kube.ConfigMapKeyConfigXml)
+	}
+	podTemplates, err := jenkins.ParsePodTemplates(configXml)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse the pod templates from the Jenkins configuration due to: %s", err)
+	}
+	return podTemplates, nil
+}
+
+// schedulableNodes returns the ready schedulable nodes matching the node
+// selector of the pod template
+func (o *StepVerifyPodOptions) schedulableNodes(nodes []corev1.Node, nodeSelector string) []corev1.Node {
+	selector := parseNodeSelector(nodeSelector)
+	answer := []corev1.Node{}
+	for _, node := range nodes {
+		if node.Spec.Unschedulable || !isNodeReady(&node) {
+			continue
+		}
+		if matchesNodeSelector(&node, selector) {
+			answer = append(answer, node)
+		}
+	}
+	return answer
+}
+
+// verifyImages warns about container images which are not present on any of
+// the candidate nodes as they will need pulling before the first build
+func (o *StepVerifyPodOptions) verifyImages(template *jenkins.PodTemplate, nodes []corev1.Node) {
+	images := map[string]bool{}
+	for _, node := range nodes {
+		for _, image := range node.Status.Images {
+			for _, name := range image.Names {
+				images[name] = true
+			}
+		}
+	}
+	for _, container := range template.Containers {
+		image := container.Image
+		if image == "" {
+			continue
+		}
+		if images[image] || images[image+":latest"] {
+			o.Printf("The image %s of container %s is available on the nodes\n", util.ColorInfo(image), container.Name)
+		} else {
+			o.warnf("The image %s of container %s is not cached on any node yet so it will be pulled when the first build starts\n", image, container.Name)
+		}
+	}
+}
+
+// verifyCapacity checks that at least one candidate node has enough
+// allocatable resources for the resource requests of the pod template
+func (o *StepVerifyPodOptions) verifyCapacity(template *jenkins.PodTemplate, nodes []corev1.Node) error {
+	cpu := resource.Quantity{}
+	memory := resource.Quantity{}
+	for _, container := range template.Containers {
+		q, err := parseQuantity(container.ResourceRequestCpu)
+		if err != nil {
+			o.warnf("Could not parse the cpu request %s of container %s: %s\n", container.ResourceRequestCpu, container.Name, err)
+		} else {
+			cpu.Add(q)
+		}
+		q, err = parseQuantity(container.ResourceRequestMemory)
+		if err != nil {
+			o.warnf("Could not parse the memory request %s of container %s: %s\n", container.ResourceRequestMemory, container.Name, err)
+		} else {
+			memory.Add(q)
+		}
+	}
+	if cpu.IsZero() && memory.IsZero() {
+		o.Printf("The pod template %s does not request any resources\n", template.Name)
+		return nil
+	}
+	for _, node := range nodes {
+		nodeCpu := node.Status.Allocatable[corev1.ResourceCPU]
+		nodeMemory := node.Status.Allocatable[corev1.ResourceMemory]
+		if nodeCpu.Cmp(cpu) >= 0 && nodeMemory.Cmp(memory) >= 0 {
+			o.Printf("The node %s can fit the agent pod requesting %s cpu and %s memory\n",
+				util.ColorInfo(node.Name), util.ColorInfo(cpu.String()), util.ColorInfo(memory.String()))
+			return nil
+		}
+	}
+	return fmt.Errorf("No node has enough allocatable resources for the agent pod of pod template %s which requests %s cpu and %s memory. Please add bigger nodes or reduce the resource requests of the pod template", template.Name, cpu.String(), memory.String())
+}
+
+// parseQuantity parses a resource quantity treating an empty string as zero
+func parseQuantity(text string) (resource.Quantity, error) {
+	if text == "" {
+		return resource.Quantity{}, nil
+	}
+	return resource.ParseQuantity(text)
+}
+
+// parseNodeSelector parses a node selector of the kubernetes plugin which uses
+// the form: key=value,key2=value2
+func parseNodeSelector(nodeSelector string) map[string]string {
+	answer := map[string]string{}
+	for _, part := range strings.Split(nodeSelector, ",") {
+		pair := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(pair) == 2 {
+			answer[pair[0]] = pair[1]
+		}
+	}
+	return answer
+}
+
+// matchesNodeSelector returns true if the node labels match the given selector
+func matchesNodeSelector(node *corev1.Node, selector map[string]string) bool {
+	for k, v := range selector {
+		if node.Labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// isNodeReady returns true if the node has a Ready condition of status True
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StepVerifySignatureOptions contains the command line flags
+type StepVerifySignatureOptions struct {
+	StepOptions
+
+	Dir      string
+	Manifest string
+}
+
+var (
+	stepVerifySignatureLong = templates.LongDesc(`
+		Verifies the signed artifact manifest created by 'jx step sign'.
+
+		The manifest signature is checked against the public half of the team
+		signing key and the SHA-256 checksum of each artifact is compared to the
+		manifest, so deploy and promote stages fail before using artifacts which
+		have been tampered with since they were built.
+`)
+
+	stepVerifySignatureExample = templates.Examples(`
+		# Verify the artifacts in the charts directory
+		jx step verify signature --dir charts
+
+		# Verify a manifest written to a custom file
+		jx step verify signature --manifest my-manifest.yaml
+	`)
+)
+
+// NewCmdStepVerifySignature creates the command
+func NewCmdStepVerifySignature(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepVerifySignatureOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "signature",
+		Short:   "Verifies the signed artifact manifest created by jx step sign",
+		Long:    stepVerifySignatureLong,
+		Example: stepVerifySignatureExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", ".", "The directory containing the artifacts to verify")
+	cmd.Flags().StringVarP(&options.Manifest, "manifest", "m", "", "The manifest file to verify. Defaults to "+defaultManifestFile+" in the artifact directory")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepVerifySignatureOptions) Run() error {
+	manifestFile := o.Manifest
+	if manifestFile == "" {
+		manifestFile = filepath.Join(o.Dir, defaultManifestFile)
+	}
+	data, err := ioutil.ReadFile(manifestFile)
+	if err != nil {
+		return fmt.Errorf("Failed to read the manifest file %s due to: %s", manifestFile, err)
+	}
+	manifest := &ArtifactManifest{}
+	err = yaml.Unmarshal(data, manifest)
+	if err != nil {
+		return fmt.Errorf("Failed to parse the manifest file %s due to: %s", manifestFile, err)
+	}
+	if manifest.Signature == "" {
+		return fmt.Errorf("The manifest %s is not signed", manifestFile)
+	}
+
+	publicKey, err := o.signingPublicKey()
+	if err != nil {
+		return err
+	}
+	signature, err := base64.StdEncoding.DecodeString(manifest.Signature)
+	if err != nil {
+		return fmt.Errorf("Failed to decode the signature of the manifest %s due to: %s", manifestFile, err)
+	}
+	payload, err := yaml.Marshal(&ArtifactManifest{
+		Files:    manifest.Files,
+		SignedAt: manifest.SignedAt,
+	})
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256(payload)
+	err = rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], signature)
+	if err != nil {
+		return fmt.Errorf("The signature of the manifest %s is not valid. The manifest may have been tampered with since it was signed", manifestFile)
+	}
+
+	modified := 0
+	for file, checksum := range manifest.Files {
+		data, err := ioutil.ReadFile(filepath.Join(o.Dir, filepath.FromSlash(file)))
+		if err != nil {
+			o.warnf("The artifact %s listed in the manifest could not be read: %s\n", file, err)
+			modified++
+			continue
+		}
+		if fmt.Sprintf("%x", sha256Sum(data)) != checksum {
+			o.warnf("The artifact %s does not match its signed checksum\n", file)
+			modified++
+		}
+	}
+	if modified > 0 {
+		return fmt.Errorf("%d artifact(s) have been modified or removed since the manifest was signed", modified)
+	}
+	o.Printf("Verified %s artifact(s) against the manifest %s signed at %s\n",
+		util.ColorInfo(fmt.Sprintf("%d", len(manifest.Files))), util.ColorInfo(manifestFile), manifest.SignedAt)
+	return nil
+}
+
+// signingPublicKey loads the public half of the team signing key from its Secret
+func (o *StepVerifySignatureOptions) signingPublicKey() (*rsa.PublicKey, error) {
+	kubeClient, currentNs, err := o.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	ns, _, err := kube.GetDevNamespace(kubeClient, currentNs)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := kubeClient.CoreV1().Secrets(ns).Get(signingSecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to find the signing key secret %s in namespace %s. Has 'jx step sign' been run yet? %s", signingSecretName, ns, err)
+	}
+	return parseSigningPublicKey(secret.Data[signingPublicKey])
+}
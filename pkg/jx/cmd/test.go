@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+)
+
+// TestOptions contains the command line options
+type TestOptions struct {
+	CommonOptions
+}
+
+// NewCmdTest creates the command object for the "test" command which hosts the
+// test suites that validate an installation
+func NewCmdTest(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &TestOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "test",
+		Short:   "Runs a test suite",
+		Aliases: []string{"tests"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.AddCommand(NewCmdTestPlatform(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *TestOptions) Run() error {
+	return o.Cmd.Help()
+}
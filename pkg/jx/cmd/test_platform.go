@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestPlatformOptions contains the command line flags
+type TestPlatformOptions struct {
+	CommonOptions
+
+	Report string
+}
+
+// platformCheck a single check of the platform smoke suite
+type platformCheck struct {
+	Name  string
+	Check func(o *TestPlatformOptions) error
+}
+
+// junitTestSuite models the JUnit XML report of a test suite
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name    string        `xml:"name,attr"`
+	Time    string        `xml:"time,attr"`
+	Failure *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+}
+
+var (
+	testPlatformLong = templates.LongDesc(`
+		Runs a smoke test suite against the installed platform.
+
+		The suite validates the pieces an installation depends on: the Kubernetes
+		API, the team environments and their git repositories, Jenkins and its
+		build queue, the chart museum and the exposed service URLs. The results are
+		written as a JUnit XML report so the suite can run in a pipeline after every
+		platform upgrade and fail it when the platform is unhealthy.
+`)
+
+	testPlatformExample = templates.Examples(`
+		# Validate the platform and write the report to junit-platform.xml
+		jx test platform
+
+		# Validate the platform writing the report to a custom file
+		jx test platform --report my-report.xml
+	`)
+)
+
+// NewCmdTestPlatform creates the command
+func NewCmdTestPlatform(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &TestPlatformOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "platform",
+		Short:   "Runs a smoke test suite against the installed platform",
+		Long:    testPlatformLong,
+		Example: testPlatformExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Report, "report", "r", "junit-platform.xml", "The file to write the JUnit XML report to. Use an empty value to skip the report")
+	return cmd
+}
+
+// Run implements this command
+func (o *TestPlatformOptions) Run() error {
+	checks := []platformCheck{
+		{"kubernetes-api", (*TestPlatformOptions).checkKubernetesAPI},
+		{"team-environments", (*TestPlatformOptions).checkEnvironments},
+		{"environment-git-repositories", (*TestPlatformOptions).checkEnvironmentRepositories},
+		{"jenkins", (*TestPlatformOptions).checkJenkins},
+		{"chartmuseum", (*TestPlatformOptions).checkChartMuseum},
+		{"exposed-urls", (*TestPlatformOptions).checkExposedURLs},
+	}
+
+	suite := junitTestSuite{
+		Name: "jx test platform",
+	}
+	table := o.CreateTable()
+	table.AddRow("CHECK", "STATUS", "DURATION", "MESSAGE")
+	start := time.Now()
+	failures := 0
+	for _, check := range checks {
+		checkStart := time.Now()
+		err := check.Check(o)
+		duration := time.Now().Sub(checkStart)
+		testCase := junitTestCase{
+			Name: check.Name,
+			Time: fmt.Sprintf("%.3f", duration.Seconds()),
+		}
+		if err != nil {
+			failures++
+			testCase.Failure = &junitFailure{Message: err.Error()}
+			table.AddRow(check.Name, util.ColorError("FAIL"), duration.String(), err.Error())
+		} else {
+			table.AddRow(check.Name, util.ColorInfo("PASS"), duration.String(), "")
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	suite.Tests = len(checks)
+	suite.Failures = failures
+	suite.Time = fmt.Sprintf("%.3f", time.Now().Sub(start).Seconds())
+	table.Render()
+
+	if o.Report != "" {
+		data, err := xml.MarshalIndent(suite, "", "  ")
+		if err != nil {
+			return err
+		}
+		err = ioutil.WriteFile(o.Report, append([]byte(xml.Header), data...), DefaultWritePermissions)
+		if err != nil {
+			return fmt.Errorf("Failed to write the report %s due to: %s", o.Report, err)
+		}
+		o.Printf("Wrote the JUnit report to %s\n", util.ColorInfo(o.Report))
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d of %d platform checks failed", failures, len(checks))
+	}
+	o.Printf("All %s platform checks passed\n", util.ColorInfo(fmt.Sprintf("%d", len(checks))))
+	return nil
+}
+
+// checkKubernetesAPI verifies the Kubernetes API is reachable and the team namespace exists
+func (o *TestPlatformOptions) checkKubernetesAPI() error {
+	client, currentNs, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err := kube.GetDevNamespace(client, currentNs)
+	if err != nil {
+		return err
+	}
+	_, err = client.CoreV1().Namespaces().Get(ns, metav1.GetOptions{})
+	return err
+}
+
+// checkEnvironments verifies the dev environment and its team settings can be loaded
+func (o *TestPlatformOptions) checkEnvironments() error {
+	jxClient, ns, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	_, err = jxClient.JenkinsV1().Environments(ns).Get(kube.LabelValueDevEnvironment, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to load the %s environment due to: %s", kube.LabelValueDevEnvironment, err)
+	}
+	return nil
+}
+
+// checkEnvironmentRepositories verifies each environment with a git source has a valid git URL
+func (o *TestPlatformOptions) checkEnvironmentRepositories() error {
+	jxClient, ns, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	envs, err := jxClient.JenkinsV1().Environments(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, env := range envs.Items {
+		gitURL := env.Spec.Source.URL
+		if gitURL == "" {
+			continue
+		}
+		_, err = gits.ParseGitURL(gitURL)
+		if err != nil {
+			return fmt.Errorf("The environment %s has an invalid git URL %s: %s", env.Name, gitURL, err)
+		}
+	}
+	return nil
+}
+
+// checkJenkins verifies Jenkins is reachable and answering API calls
+func (o *TestPlatformOptions) checkJenkins() error {
+	jenkins, err := o.JenkinsClient()
+	if err != nil {
+		return err
+	}
+	_, err = jenkins.GetJobs()
+	if err != nil {
+		return fmt.Errorf("Failed to query the Jenkins jobs due to: %s", err)
+	}
+	return nil
+}
+
+// checkChartMuseum verifies the chart museum service is exposed
+func (o *TestPlatformOptions) checkChartMuseum() error {
+	_, err := o.findService(kube.ServiceChartMuseum)
+	return err
+}
+
+// checkExposedURLs verifies the team namespace exposes at least one service URL
+func (o *TestPlatformOptions) checkExposedURLs() error {
+	client, currentNs, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err := kube.GetDevNamespace(client, currentNs)
+	if err != nil {
+		return err
+	}
+	urls, err := kube.FindServiceURLs(client, ns)
+	if err != nil {
+		return err
+	}
+	if len(urls) == 0 {
+		return fmt.Errorf("No service URLs are exposed in namespace %s", ns)
+	}
+	return nil
+}
@@ -2,29 +2,41 @@ package cmd
 
 import (
 	"io"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"fmt"
 
+	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/log"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
 	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
 	"gopkg.in/AlecAivazis/survey.v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type UninstallOptions struct {
 	CommonOptions
+
+	KeepEnvironments bool
 }
 
 var (
 	uninstall_long = templates.LongDesc(`
-		Uninstalls the Jenkins X platform from a kubernetes cluster`)
+		Uninstalls the Jenkins X platform from a kubernetes cluster.
+
+		Removes the platform helm release, the Environment resources and their
+		namespaces along with the webhooks the platform registered on the git
+		repositories of its pipelines.`)
 	uninstall_example = templates.Examples(`
 		# Uninstall the Jenkins X platform
-		jx uninstall`)
+		jx uninstall
+
+		# Uninstall the platform but keep the environments and their namespaces
+		jx uninstall --keep-environments`)
 )
 
 func NewCmdUninstall(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
@@ -47,7 +59,8 @@ func NewCmdUninstall(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.
 			cmdutil.CheckErr(err)
 		},
 	}
-
+	options.addCommonFlags(cmd)
+	cmd.Flags().BoolVarP(&options.KeepEnvironments, "keep-environments", "", false, "Keeps the Environment resources and their namespaces")
 	return cmd
 }
 
@@ -62,26 +75,126 @@ func (o *UninstallOptions) Run() error {
 	}
 	server := kube.CurrentServer(config)
 	namespace := kube.CurrentNamespace(config)
-	confirm := &survey.Confirm{
-		Message: fmt.Sprintf("Are you sure you wish to remove the Jenkins X platform from the '%s' namespace on cluster '%s'? :", namespace, server),
-		Default: false,
+	if !o.BatchMode {
+		confirm := &survey.Confirm{
+			Message: fmt.Sprintf("Are you sure you wish to remove the Jenkins X platform from the '%s' namespace on cluster '%s'? :", namespace, server),
+			Default: false,
+		}
+		flag := false
+		err = survey.AskOne(confirm, &flag, nil)
+		if err != nil {
+			return err
+		}
+		if !flag {
+			return nil
+		}
 	}
-	flag := false
-	err = survey.AskOne(confirm, &flag, nil)
+
+	// remove the webhooks while the Jenkins server is still around to enumerate its pipelines
+	err = o.removeManagedWebHooks()
 	if err != nil {
-		return err
+		o.warnf("Failed to remove the webhooks from the git repositories: %s\n", err)
 	}
-	if !flag {
-		return nil
+
+	envNamespaces := []string{}
+	if !o.KeepEnvironments {
+		envs, err := jxClient.JenkinsV1().Environments(namespace).List(meta_v1.ListOptions{})
+		if err != nil {
+			o.warnf("Failed to list the Environments in namespace %s: %s\n", namespace, err)
+		} else {
+			for _, env := range envs.Items {
+				ns := env.Spec.Namespace
+				if ns != "" && ns != namespace && util.StringArrayIndex(envNamespaces, ns) < 0 {
+					envNamespaces = append(envNamespaces, ns)
+				}
+			}
+		}
 	}
+
 	err = o.runCommand("helm", "delete", "--purge", "jenkins-x")
 	if err != nil {
 		return err
 	}
-	err = jxClient.JenkinsV1().Environments(namespace).DeleteCollection(&meta_v1.DeleteOptions{}, meta_v1.ListOptions{})
+	if !o.KeepEnvironments {
+		err = jxClient.JenkinsV1().Environments(namespace).DeleteCollection(&meta_v1.DeleteOptions{}, meta_v1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		client, _, err := o.KubeClient()
+		if err != nil {
+			return err
+		}
+		for _, ns := range envNamespaces {
+			err = client.CoreV1().Namespaces().Delete(ns, nil)
+			if err != nil {
+				o.warnf("Failed to delete the environment namespace %s: %s\n", ns, err)
+			} else {
+				o.Printf("Deleted environment namespace %s\n", util.ColorInfo(ns))
+			}
+		}
+	}
+	log.Success("Jenkins X has been successfully uninstalled ")
+	return nil
+}
+
+// removeManagedWebHooks deletes the webhooks pointing at the Jenkins server
+// from the git repositories built by its multibranch pipelines
+func (o *UninstallOptions) removeManagedWebHooks() error {
+	jenkinsClient, err := o.JenkinsClient()
 	if err != nil {
 		return err
 	}
-	log.Success("Jenkins X has been successfully uninstalled ")
+	jenkinsURL := jenkinsClient.BaseURL()
+	jobs, err := jenkinsClient.GetJobs()
+	if err != nil {
+		return err
+	}
+	// reuse the pipeline scan from jx adopt to find the repositories
+	adopt := &AdoptOptions{CommonOptions: o.CommonOptions}
+	projects := []*adoptedProject{}
+	for _, job := range jobs {
+		err = adopt.findMultiBranchProjects(jenkinsClient, []string{}, &job, &projects)
+		if err != nil {
+			return err
+		}
+	}
+	for _, project := range projects {
+		err = o.removeRepositoryWebHooks(project.GitURL, jenkinsURL)
+		if err != nil {
+			o.warnf("%s\n", err)
+		}
+	}
+	return nil
+}
+
+// removeRepositoryWebHooks deletes the webhooks of the repository which point
+// at the given Jenkins URL
+func (o *UninstallOptions) removeRepositoryWebHooks(gitURL string, jenkinsURL string) error {
+	gitInfo, err := gits.ParseGitURL(gitURL)
+	if err != nil {
+		return err
+	}
+	gitProvider, err := o.gitProviderForURL(gitURL, "user name to remove the webhooks")
+	if err != nil {
+		return err
+	}
+	hookManager, ok := gitProvider.(gits.GitWebHookManager)
+	if !ok {
+		return fmt.Errorf("The git provider of %s does not support removing webhooks so please remove the webhooks of %s/%s manually", gitURL, gitInfo.Organisation, gitInfo.Name)
+	}
+	hooks, err := hookManager.ListWebHooks(gitInfo.Organisation, gitInfo.Name)
+	if err != nil {
+		return err
+	}
+	for _, hook := range hooks {
+		if hook.URL == "" || !strings.HasPrefix(hook.URL, jenkinsURL) {
+			continue
+		}
+		err = hookManager.DeleteWebHook(gitInfo.Organisation, gitInfo.Name, hook.ID)
+		if err != nil {
+			return err
+		}
+		o.Printf("Removed webhook %s from %s/%s\n", util.ColorInfo(hook.URL), gitInfo.Organisation, gitInfo.Name)
+	}
 	return nil
 }
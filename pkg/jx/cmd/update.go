@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+)
+
+// UpdateOptions contains the CLI options
+type UpdateOptions struct {
+	CommonOptions
+}
+
+// NewCmdUpdate creates a command object for the "update" command
+func NewCmdUpdate(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &UpdateOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Updates a resource",
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.AddCommand(NewCmdUpdateBranchProtection(f, out, errOut))
+	cmd.AddCommand(NewCmdUpdateLabels(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *UpdateOptions) Run() error {
+	return o.Cmd.Help()
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// defaultBranchProtectionFileName is the default name of the branch protection
+// policy file
+const defaultBranchProtectionFileName = "branch-protection.yaml"
+
+// UpdateBranchProtectionOptions the command line options
+type UpdateBranchProtectionOptions struct {
+	UpdateOptions
+
+	Organisation string
+	GitHost      string
+	GitHub       bool
+	Config       string
+	Filter       string
+}
+
+// branchProtectionPolicy models the branch protection policy file
+type branchProtectionPolicy struct {
+	Branch                  string   `yaml:"branch"`
+	RequiredContexts        []string `yaml:"requiredContexts,omitempty"`
+	RequireUpToDate         bool     `yaml:"requireUpToDate,omitempty"`
+	RequireReviews          bool     `yaml:"requireReviews,omitempty"`
+	DismissStaleReviews     bool     `yaml:"dismissStaleReviews,omitempty"`
+	RequireCodeOwnerReviews bool     `yaml:"requireCodeOwnerReviews,omitempty"`
+	EnforceAdmins           bool     `yaml:"enforceAdmins,omitempty"`
+}
+
+var (
+	update_branchprotection_long = templates.LongDesc(`
+		Applies a branch protection policy to all the repositories of an
+		organisation.
+
+		The policy file declares the protected branch, the required status check
+		contexts such as the Jenkins pipeline contexts, whether branches must be
+		up to date before merging and whether reviews are required, so that the
+		same protection is enforced consistently across every imported
+		repository.
+`)
+
+	update_branchprotection_example = templates.Examples(`
+		# Apply the policy in branch-protection.yaml to all repositories of an organisation
+		jx update branchprotection --github --org myorg
+
+		# Apply a policy file to the repositories containing foo
+		jx update branchprotection --github --org myorg --config mypolicy.yaml --filter foo
+	`)
+)
+
+// NewCmdUpdateBranchProtection creates the command
+func NewCmdUpdateBranchProtection(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &UpdateBranchProtectionOptions{
+		UpdateOptions: UpdateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "branchprotection",
+		Short:   "Applies a branch protection policy across the repositories of an organisation",
+		Aliases: []string{"branch-protection"},
+		Long:    update_branchprotection_long,
+		Example: update_branchprotection_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Organisation, "org", "o", "", "The git provider organisation to apply the policy to")
+	cmd.Flags().StringVarP(&options.GitHost, "git-host", "g", "", "The Git server host if not using GitHub")
+	cmd.Flags().BoolVarP(&options.GitHub, "github", "", false, "If the repositories are on GitHub")
+	cmd.Flags().StringVarP(&options.Config, "config", "c", defaultBranchProtectionFileName, "The branch protection policy file to apply")
+	cmd.Flags().StringVarP(&options.Filter, "filter", "", "", "Only apply the policy to repositories whose name contains this text")
+	return cmd
+}
+
+// Run implements the command
+func (o *UpdateBranchProtectionOptions) Run() error {
+	if o.Organisation == "" {
+		return util.MissingOption("org")
+	}
+	policy, err := loadBranchProtectionPolicy(o.Config)
+	if err != nil {
+		return err
+	}
+	provider, err := o.gitProviderForHost(o.GitHub, o.GitHost, "Pick the git server of the organisation")
+	if err != nil {
+		return err
+	}
+	ghProvider, ok := provider.(*gits.GitHubProvider)
+	if !ok {
+		return fmt.Errorf("Branch protection is currently only supported for GitHub repositories")
+	}
+	repos, err := provider.ListRepositories(o.Organisation)
+	if err != nil {
+		return fmt.Errorf("Failed to list the repositories of %s due to: %s", o.Organisation, err)
+	}
+	protection := &gits.BranchProtectionArguments{
+		Branch:                  policy.Branch,
+		RequiredContexts:        policy.RequiredContexts,
+		RequireUpToDate:         policy.RequireUpToDate,
+		RequireReviews:          policy.RequireReviews,
+		DismissStaleReviews:     policy.DismissStaleReviews,
+		RequireCodeOwnerReviews: policy.RequireCodeOwnerReviews,
+		EnforceAdmins:           policy.EnforceAdmins,
+	}
+	failed := 0
+	for _, repo := range repos {
+		if o.Filter != "" && !strings.Contains(repo.Name, o.Filter) {
+			continue
+		}
+		err = ghProvider.ApplyBranchProtection(o.Organisation, repo.Name, protection)
+		if err != nil {
+			o.warnf("%s\n", err)
+			failed++
+			continue
+		}
+		o.Printf("Protected branch %s of %s\n", util.ColorInfo(policy.Branch),
+			util.ColorInfo(o.Organisation+"/"+repo.Name))
+	}
+	if failed > 0 {
+		return fmt.Errorf("Failed to apply the branch protection policy to %d repositories", failed)
+	}
+	return nil
+}
+
+// loadBranchProtectionPolicy loads and validates the branch protection policy file
+func loadBranchProtectionPolicy(fileName string) (*branchProtectionPolicy, error) {
+	policy := &branchProtectionPolicy{}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return policy, fmt.Errorf("Failed to load %s due to %s", fileName, err)
+	}
+	err = yaml.Unmarshal(data, policy)
+	if err != nil {
+		return policy, fmt.Errorf("Failed to unmarshal %s due to %s", fileName, err)
+	}
+	if policy.Branch == "" {
+		policy.Branch = "master"
+	}
+	return policy, nil
+}
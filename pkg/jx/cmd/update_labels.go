@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// UpdateLabelsOptions the command line options
+type UpdateLabelsOptions struct {
+	UpdateOptions
+
+	Organisation string
+	GitHost      string
+	GitHub       bool
+	Config       string
+	Filter       string
+}
+
+var (
+	update_labels_long = templates.LongDesc(`
+		Syncs a standard label set across all the repositories of an
+		organisation.
+
+		The labels are loaded from a labels configuration file, defaulting to
+		the standard kind and size labels used by the Pull Request labelling
+		controller, and are created or recoloured on every repository.
+`)
+
+	update_labels_example = templates.Examples(`
+		# Sync the standard labels across all repositories of an organisation
+		jx update labels --github --org myorg
+
+		# Sync the labels from a configuration file
+		jx update labels --github --org myorg --config labels.yaml
+	`)
+)
+
+// NewCmdUpdateLabels creates the command
+func NewCmdUpdateLabels(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &UpdateLabelsOptions{
+		UpdateOptions: UpdateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "labels",
+		Short:   "Syncs a standard label set across the repositories of an organisation",
+		Aliases: []string{"label"},
+		Long:    update_labels_long,
+		Example: update_labels_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Organisation, "org", "o", "", "The git provider organisation to sync the labels to")
+	cmd.Flags().StringVarP(&options.GitHost, "git-host", "g", "", "The Git server host if not using GitHub")
+	cmd.Flags().BoolVarP(&options.GitHub, "github", "", false, "If the repositories are on GitHub")
+	cmd.Flags().StringVarP(&options.Config, "config", "c", config.DefaultLabelsFileName, "The labels configuration file to sync")
+	cmd.Flags().StringVarP(&options.Filter, "filter", "", "", "Only sync to repositories whose name contains this text")
+	return cmd
+}
+
+// Run implements the command
+func (o *UpdateLabelsOptions) Run() error {
+	if o.Organisation == "" {
+		return util.MissingOption("org")
+	}
+	labelsConfig, err := config.LoadLabelsConfig(o.Config)
+	if err != nil {
+		return err
+	}
+	provider, err := o.gitProviderForHost(o.GitHub, o.GitHost, "Pick the git server of the organisation")
+	if err != nil {
+		return err
+	}
+	ghProvider, ok := provider.(*gits.GitHubProvider)
+	if !ok {
+		return fmt.Errorf("Label syncing is currently only supported for GitHub repositories")
+	}
+	repos, err := provider.ListRepositories(o.Organisation)
+	if err != nil {
+		return fmt.Errorf("Failed to list the repositories of %s due to: %s", o.Organisation, err)
+	}
+	failed := 0
+	for _, repo := range repos {
+		if o.Filter != "" && !strings.Contains(repo.Name, o.Filter) {
+			continue
+		}
+		for _, label := range labelsConfig.Labels {
+			err = ghProvider.CreateOrUpdateLabel(o.Organisation, repo.Name, gits.GitLabel{
+				Name:  label.Name,
+				Color: label.Color,
+			})
+			if err != nil {
+				o.warnf("%s\n", err)
+				failed++
+			}
+		}
+		o.Printf("Synced %s label(s) to %s\n", util.ColorInfo(fmt.Sprintf("%d", len(labelsConfig.Labels))),
+			util.ColorInfo(o.Organisation+"/"+repo.Name))
+	}
+	if failed > 0 {
+		return fmt.Errorf("Failed to sync %d labels", failed)
+	}
+	return nil
+}
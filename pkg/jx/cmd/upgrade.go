@@ -37,6 +37,8 @@ func NewCmdUpgrade(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Co
 		SuggestFor: []string{"remove", "rm"},
 	}
 
+	cmd.AddCommand(NewCmdUpgradeConfig(f, out, errOut))
+	cmd.AddCommand(NewCmdUpgradeJenkins(f, out, errOut))
 	cmd.AddCommand(NewCmdUpgradePlatform(f, out, errOut))
 	return cmd
 }
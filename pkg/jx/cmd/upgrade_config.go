@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"io"
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UpgradeConfigOptions the options for the upgrade config command
+type UpgradeConfigOptions struct {
+	CommonOptions
+
+	Dir string
+}
+
+var (
+	upgrade_config_long = templates.LongDesc(`
+		Upgrades the configuration files to the current schema version.
+
+		This migrates the jenkins-x.yml of a project, the local auth configuration files
+		and the team settings from older schema versions so that format changes do not
+		break an existing installation silently.
+
+		The command is safe to run repeatedly: configuration which is already at the
+		current version is left untouched.
+`)
+
+	upgrade_config_example = templates.Examples(`
+		# Upgrade the configuration of the current project and the local setup
+		jx upgrade config
+
+		# Upgrade the configuration of a different project directory
+		jx upgrade config --dir /foo/bar
+	`)
+)
+
+// NewCmdUpgradeConfig creates the command
+func NewCmdUpgradeConfig(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &UpgradeConfigOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "config",
+		Short:   "Upgrades the configuration files to the current schema version",
+		Long:    upgrade_config_long,
+		Example: upgrade_config_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", ".", "The directory of the project to upgrade the "+config.ProjectConfigFileName+" of")
+	return cmd
+}
+
+// Run implements the command
+func (o *UpgradeConfigOptions) Run() error {
+	err := o.upgradeProjectConfig()
+	if err != nil {
+		return err
+	}
+	err = o.upgradeAuthConfigs()
+	if err != nil {
+		return err
+	}
+	return o.upgradeTeamSettings()
+}
+
+// upgradeProjectConfig migrates the jenkins-x.yml of the project directory when
+// there is one
+func (o *UpgradeConfigOptions) upgradeProjectConfig() error {
+	fileName := filepath.Join(o.Dir, config.ProjectConfigFileName)
+	exists, err := util.FileExists(fileName)
+	if err != nil || !exists {
+		return err
+	}
+	projectConfig, err := config.LoadProjectConfig(o.Dir)
+	if err != nil {
+		return err
+	}
+	changed, err := projectConfig.Migrate()
+	if err != nil {
+		return err
+	}
+	if !changed {
+		o.Printf("%s is already at schema version %s\n", util.ColorInfo(fileName), util.ColorInfo(config.ProjectConfigVersion))
+		return nil
+	}
+	err = config.SaveProjectConfig(projectConfig, o.Dir)
+	if err != nil {
+		return err
+	}
+	o.Printf("Migrated %s to schema version %s\n", util.ColorInfo(fileName), util.ColorInfo(config.ProjectConfigVersion))
+	return nil
+}
+
+// upgradeAuthConfigs migrates the local auth configuration files
+func (o *UpgradeConfigOptions) upgradeAuthConfigs() error {
+	services := []struct {
+		name   string
+		create func() (auth.AuthConfigService, error)
+	}{
+		{"git", o.Factory.CreateGitAuthConfigService},
+		{"Jenkins", o.Factory.CreateJenkinsAuthConfigService},
+		{"chartmuseum", o.Factory.CreateChartmuseumAuthConfigService},
+		{"issue tracker", o.Factory.CreateIssueTrackerAuthConfigService},
+	}
+	for _, service := range services {
+		svc, err := service.create()
+		if err != nil {
+			return err
+		}
+		changed, err := svc.Config().Migrate()
+		if err != nil {
+			return err
+		}
+		if !changed {
+			continue
+		}
+		err = svc.SaveConfig()
+		if err != nil {
+			return err
+		}
+		o.Printf("Migrated the %s auth configuration %s to schema version %s\n", service.name, util.ColorInfo(svc.FileName), util.ColorInfo(auth.AuthConfigVersion))
+	}
+	return nil
+}
+
+// upgradeTeamSettings migrates the team settings stored in the dev Environment.
+// This only warns when no cluster is available so that the file migrations
+// still work offline
+func (o *UpgradeConfigOptions) upgradeTeamSettings() error {
+	jxClient, ns, err := o.JXClient()
+	if err != nil {
+		o.warnf("Could not connect to the cluster to upgrade the team settings: %s\n", err)
+		return nil
+	}
+	env, err := jxClient.JenkinsV1().Environments(ns).Get(kube.LabelValueDevEnvironment, metav1.GetOptions{})
+	if err != nil {
+		o.warnf("Could not load the team settings to upgrade them: %s\n", err)
+		return nil
+	}
+	settings := &env.Spec.TeamSettings
+	if settings.Version >= v1.TeamSettingsVersion {
+		o.Printf("The team settings are already at schema version %s\n", util.ColorInfo(settings.Version))
+		return nil
+	}
+	settings.Version = v1.TeamSettingsVersion
+	_, err = jxClient.JenkinsV1().Environments(ns).Update(env)
+	if err != nil {
+		return err
+	}
+	o.Printf("Migrated the team settings to schema version %s\n", util.ColorInfo(v1.TeamSettingsVersion))
+	return nil
+}
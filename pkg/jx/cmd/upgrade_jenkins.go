@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+)
+
+// UpgradeJenkinsOptions the options for the upgrade jenkins command
+type UpgradeJenkinsOptions struct {
+	CommonOptions
+}
+
+// NewCmdUpgradeJenkins creates a command object for the "upgrade jenkins" command
+func NewCmdUpgradeJenkins(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &UpgradeJenkinsOptions{
+		CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "jenkins",
+		Short: "Upgrades a Jenkins resource",
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.AddCommand(NewCmdUpgradeJenkinsPlugins(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *UpgradeJenkinsOptions) Run() error {
+	return o.Cmd.Help()
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jenkins"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// UpgradeJenkinsPluginsOptions the options for the upgrade jenkins plugins command
+type UpgradeJenkinsPluginsOptions struct {
+	CommonOptions
+
+	Restart bool
+}
+
+var (
+	upgrade_jenkins_plugins_long = templates.LongDesc(`
+		Upgrades the plugins on the Jenkins master to the recommended plugin manifest
+		for the platform.
+
+		Any plugin which is missing, behind the recommended version or running a
+		version with a published security advisory is installed or upgraded via the
+		Jenkins update centre. A restart of the master is required before the new
+		plugin versions take effect.
+`)
+
+	upgrade_jenkins_plugins_example = templates.Examples(`
+		# Upgrade the Jenkins plugins to the recommended versions
+		jx upgrade jenkins plugins
+
+		# Upgrade the plugins then safely restart the Jenkins master
+		jx upgrade jenkins plugins --restart
+	`)
+)
+
+// NewCmdUpgradeJenkinsPlugins creates the command
+func NewCmdUpgradeJenkinsPlugins(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &UpgradeJenkinsPluginsOptions{
+		CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+		false,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "plugins",
+		Short:   "Upgrades the Jenkins plugins to the recommended versions",
+		Long:    upgrade_jenkins_plugins_long,
+		Example: upgrade_jenkins_plugins_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().BoolVarP(&options.Restart, "restart", "", false, "Safely restart the Jenkins master after the plugins are installed")
+	return cmd
+}
+
+// Run implements this command
+func (o *UpgradeJenkinsPluginsOptions) Run() error {
+	jenk, err := o.JenkinsClient()
+	if err != nil {
+		return err
+	}
+	plugins, err := jenk.GetPlugins(1)
+	if err != nil {
+		return err
+	}
+	installed := map[string]string{}
+	for _, plugin := range plugins.Plugins {
+		installed[plugin.ShortName] = plugin.Version
+	}
+
+	count := 0
+	for _, required := range jenkins.RequiredPlugins {
+		version, ok := installed[required.Name]
+		if ok && jenkins.ComparePluginVersions(version, required.Version) >= 0 &&
+			!jenkins.IsVulnerablePluginVersion(required.Name, version) {
+			continue
+		}
+		if !ok {
+			o.Printf("Installing plugin %s version %s\n", util.ColorInfo(required.Name), util.ColorInfo(required.Version))
+		} else {
+			o.Printf("Upgrading plugin %s from %s to %s\n", util.ColorInfo(required.Name), version, util.ColorInfo(required.Version))
+		}
+		err = jenk.InstallPlugin(required.Name, required.Version)
+		if err != nil {
+			return fmt.Errorf("Failed to install plugin %s version %s due to: %s", required.Name, required.Version, err)
+		}
+		count++
+	}
+	for _, plugin := range plugins.Plugins {
+		if jenkins.RequiredPluginVersion(plugin.ShortName) != "" {
+			continue
+		}
+		if jenkins.IsVulnerablePluginVersion(plugin.ShortName, plugin.Version) {
+			o.Printf("Upgrading plugin %s from the vulnerable version %s to the latest version\n", util.ColorInfo(plugin.ShortName), util.ColorError(plugin.Version))
+			err = jenk.InstallPlugin(plugin.ShortName, "latest")
+			if err != nil {
+				return fmt.Errorf("Failed to upgrade plugin %s due to: %s", plugin.ShortName, err)
+			}
+			count++
+		}
+	}
+	if count == 0 {
+		o.Printf("All plugins are up to date with the recommended versions\n")
+		return nil
+	}
+	if o.Restart {
+		o.Printf("Restarting the Jenkins master so the new plugin versions take effect\n")
+		return jenk.SafeRestart()
+	}
+	o.Printf("Installed or upgraded %d plugin(s). Restart the Jenkins master for them to take effect\n", count)
+	return nil
+}
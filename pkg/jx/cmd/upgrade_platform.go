@@ -1,21 +1,44 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
 )
 
 var (
 	upgrade_platform_long = templates.LongDesc(`
-		Upgrades the Jenkins X platform if there is a newer release
+		Upgrades the Jenkins X platform if there is a newer release.
+
+		Before applying anything you can render a diff of all the Kubernetes
+		resources, including the Jenkins configuration, that the upgrade would
+		change using the --diff flag. The rendered manifests can also be saved as a
+		plan file which pins the chart version so the reviewed upgrade can be
+		applied later with --apply-plan.
 `)
 
 	upgrade_platform_example = templates.Examples(`
-		# Upgrades the Jenkins X platform 
+		# Upgrades the Jenkins X platform
 		jx upgrade platform
+
+		# Show the resource changes the upgrade would apply without upgrading
+		jx upgrade platform --diff
+
+		# Save the reviewed upgrade as a plan and apply it later
+		jx upgrade platform --diff --plan upgrade-plan.yaml
+		jx upgrade platform --apply-plan upgrade-plan.yaml
+
+		# Canary the new version on a single team before rolling it out to all teams
+		jx upgrade platform --team my-team
 	`)
 )
 
@@ -27,6 +50,10 @@ type UpgradePlatformOptions struct {
 	ReleaseName string
 	Chart       string
 	Namespace   string
+	Team        string
+	Diff        bool
+	Plan        string
+	ApplyPlan   string
 }
 
 // NewCmdUpgradePlatform defines the command
@@ -58,11 +85,27 @@ func NewCmdUpgradePlatform(f cmdutil.Factory, out io.Writer, errOut io.Writer) *
 	cmd.Flags().StringVarP(&options.ReleaseName, "name", "n", "jenkins-x", "The release name")
 	cmd.Flags().StringVarP(&options.Chart, "chart", "c", "jenkins-x/jenkins-x-platform", "The Chart to upgrade")
 	cmd.Flags().StringVarP(&options.Version, "version", "v", "", "The Version to promote")
+	cmd.Flags().StringVarP(&options.Team, "team", "", "", "Only upgrade the platform release of the given team namespace so the new version can be canaried on one team first")
+	cmd.Flags().BoolVarP(&options.Diff, "diff", "", false, "Display the resource changes the upgrade would apply instead of upgrading")
+	cmd.Flags().StringVarP(&options.Plan, "plan", "", "", "The file to save the rendered upgrade plan to so it can be applied later with --apply-plan")
+	cmd.Flags().StringVarP(&options.ApplyPlan, "apply-plan", "", "", "Applies a previously saved upgrade plan file")
 	return cmd
 }
 
 // Run implements the command
 func (o *UpgradePlatformOptions) Run() error {
+	if o.Team != "" {
+		err := o.useTeamRelease()
+		if err != nil {
+			return err
+		}
+	}
+	if o.ApplyPlan != "" {
+		return o.applyPlan()
+	}
+	if o.Diff || o.Plan != "" {
+		return o.renderPlan()
+	}
 	ns := o.Namespace
 	version := o.Version
 	err := o.runCommand("helm", "repo", "update")
@@ -79,3 +122,166 @@ func (o *UpgradePlatformOptions) Run() error {
 	args = append(args, o.ReleaseName, o.Chart)
 	return o.runCommand("helm", args...)
 }
+
+// useTeamRelease resolves the platform release deployed in the team namespace so
+// only that team is upgraded, leaving the other teams on their current version
+func (o *UpgradePlatformOptions) useTeamRelease() error {
+	releases, err := o.listPlatformReleases()
+	if err != nil {
+		return err
+	}
+	for _, release := range releases {
+		if release.Namespace == o.Team {
+			o.ReleaseName = release.Release
+			o.Namespace = release.Namespace
+			o.Printf("Upgrading only the platform release %s of team namespace %s. The current version of the team is %s\n",
+				util.ColorInfo(release.Release), util.ColorInfo(release.Namespace), util.ColorInfo(release.Version))
+			return nil
+		}
+	}
+	return fmt.Errorf("No platform release found in the team namespace %s. Use 'jx get platform versions' to see the current releases", o.Team)
+}
+
+// renderPlan renders the manifests the upgrade would apply, displays the diff to
+// the currently deployed release and optionally saves the plan file
+func (o *UpgradePlatformOptions) renderPlan() error {
+	err := o.runCommand("helm", "repo", "update")
+	if err != nil {
+		return err
+	}
+	tmpDir, err := ioutil.TempDir("", "jx-upgrade-plan-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	fetchArgs := []string{"fetch", "--untar", "--untardir", tmpDir}
+	if o.Version != "" {
+		fetchArgs = append(fetchArgs, "--version", o.Version)
+	}
+	fetchArgs = append(fetchArgs, o.Chart)
+	err = o.runCommand("helm", fetchArgs...)
+	if err != nil {
+		return fmt.Errorf("Failed to fetch the chart %s due to: %s", o.Chart, err)
+	}
+	names := strings.Split(o.Chart, "/")
+	chartDir := filepath.Join(tmpDir, names[len(names)-1])
+
+	templateArgs := []string{"template", "--name", o.ReleaseName}
+	if o.Namespace != "" {
+		templateArgs = append(templateArgs, "--namespace", o.Namespace)
+	}
+	templateArgs = append(templateArgs, chartDir)
+	newManifest, err := o.getCommandOutput("", "helm", templateArgs...)
+	if err != nil {
+		return fmt.Errorf("Failed to render the chart %s due to: %s", o.Chart, err)
+	}
+	currentManifest, err := o.getCommandOutput("", "helm", "get", "manifest", o.ReleaseName)
+	if err != nil {
+		o.warnf("Failed to load the manifest of the deployed release %s so showing the full plan: %s\n", o.ReleaseName, err)
+		currentManifest = ""
+	}
+
+	currentFile := filepath.Join(tmpDir, "current.yaml")
+	newFile := filepath.Join(tmpDir, "upgraded.yaml")
+	err = ioutil.WriteFile(currentFile, []byte(currentManifest), DefaultWritePermissions)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(newFile, []byte(newManifest), DefaultWritePermissions)
+	if err != nil {
+		return err
+	}
+	diff, changed := diffFiles(currentFile, newFile)
+	if !changed {
+		o.Printf("The upgrade would not change any resources\n")
+	} else {
+		o.Printf("The upgrade would apply these changes:\n\n%s\n", diff)
+	}
+
+	if o.Plan != "" {
+		version := chartVersion(chartDir)
+		header := fmt.Sprintf("# chart: %s\n# version: %s\n# release: %s\n# namespace: %s\n", o.Chart, version, o.ReleaseName, o.Namespace)
+		err = ioutil.WriteFile(o.Plan, []byte(header+newManifest), DefaultWritePermissions)
+		if err != nil {
+			return fmt.Errorf("Failed to save the plan file %s due to: %s", o.Plan, err)
+		}
+		o.Printf("Saved the upgrade plan for chart version %s to %s. Apply it later with: %s\n",
+			util.ColorInfo(version), util.ColorInfo(o.Plan), util.ColorInfo("jx upgrade platform --apply-plan "+o.Plan))
+	}
+	return nil
+}
+
+// applyPlan applies a previously saved upgrade plan by upgrading to the chart
+// version pinned in the plan file
+func (o *UpgradePlatformOptions) applyPlan() error {
+	data, err := ioutil.ReadFile(o.ApplyPlan)
+	if err != nil {
+		return fmt.Errorf("Failed to read the plan file %s due to: %s", o.ApplyPlan, err)
+	}
+	chart := ""
+	version := ""
+	release := o.ReleaseName
+	namespace := o.Namespace
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(line, "# ") {
+			break
+		}
+		fields := strings.SplitN(strings.TrimPrefix(line, "# "), ":", 2)
+		if len(fields) != 2 {
+			continue
+		}
+		value := strings.TrimSpace(fields[1])
+		switch fields[0] {
+		case "chart":
+			chart = value
+		case "version":
+			version = value
+		case "release":
+			release = value
+		case "namespace":
+			if value != "" {
+				namespace = value
+			}
+		}
+	}
+	if chart == "" || version == "" {
+		return fmt.Errorf("The plan file %s does not contain the chart and version headers. Was it created with --plan?", o.ApplyPlan)
+	}
+	o.Printf("Applying the upgrade plan %s: chart %s version %s\n",
+		util.ColorInfo(o.ApplyPlan), util.ColorInfo(chart), util.ColorInfo(version))
+	err = o.runCommand("helm", "repo", "update")
+	if err != nil {
+		return err
+	}
+	args := []string{"upgrade", "--version", version}
+	if namespace != "" {
+		args = append(args, "--namespace", namespace)
+	}
+	args = append(args, release, chart)
+	return o.runCommand("helm", args...)
+}
+
+// diffFiles returns the unified diff of the two files and whether they differ
+func diffFiles(oldFile string, newFile string) (string, bool) {
+	e := exec.Command("diff", "-u", oldFile, newFile)
+	data, err := e.Output()
+	if err == nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// chartVersion reads the version from the Chart.yaml of the chart directory
+func chartVersion(chartDir string) string {
+	data, err := ioutil.ReadFile(filepath.Join(chartDir, "Chart.yaml"))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "version:") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "version:"))
+		}
+	}
+	return ""
+}
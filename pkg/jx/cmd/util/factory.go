@@ -1,6 +1,7 @@
 package util
 
 import (
+	"crypto/rand"
 	"flag"
 	"fmt"
 	"io"
@@ -15,6 +16,8 @@ import (
 	"github.com/jenkins-x/jx/pkg/auth"
 	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
 	"github.com/jenkins-x/jx/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -32,6 +35,13 @@ const (
 	JenkinsAuthConfigFile     = "jenkinsAuth.yaml"
 	GitAuthConfigFile         = "gitAuth.yaml"
 	ChartmuseumAuthConfigFile = "chartmuseumAuth.yaml"
+	IssuesAuthConfigFile      = "issuesAuth.yaml"
+
+	// cipherSecretName the secret in the team namespace holding the key used
+	// to encrypt the tokens in the local auth config files
+	cipherSecretName = "jx-auth-cipher"
+	// cipherSecretKey the data entry of the cipher secret holding the key
+	cipherSecretKey = "cipher.key"
 )
 
 type Factory interface {
@@ -47,6 +57,8 @@ type Factory interface {
 
 	CreateChartmuseumAuthConfigService() (auth.AuthConfigService, error)
 
+	CreateIssueTrackerAuthConfigService() (auth.AuthConfigService, error)
+
 	CreateClient() (*kubernetes.Clientset, string, error)
 
 	CreateJXClient() (*versioned.Clientset, string, error)
@@ -62,6 +74,9 @@ type Factory interface {
 
 type factory struct {
 	Batch bool
+
+	cipher        auth.SecretCipher
+	cipherChecked bool
 }
 
 // NewFactory creates a factory with the default Kubernetes resources defined
@@ -137,6 +152,18 @@ func (f *factory) CreateChartmuseumAuthConfigService() (auth.AuthConfigService,
 	return authConfigSvc, err
 }
 
+func (f *factory) CreateIssueTrackerAuthConfigService() (auth.AuthConfigService, error) {
+	authConfigSvc, err := f.CreateAuthConfigService(IssuesAuthConfigFile)
+	if err != nil {
+		return authConfigSvc, err
+	}
+	_, err = authConfigSvc.LoadConfig()
+	if err != nil {
+		return authConfigSvc, err
+	}
+	return authConfigSvc, err
+}
+
 func (f *factory) CreateGitAuthConfigService() (auth.AuthConfigService, error) {
 
 	// if in cluster then there's no user configfile, so check for env vars first
@@ -191,9 +218,56 @@ func (f *factory) CreateAuthConfigService(fileName string) (auth.AuthConfigServi
 		return svc, err
 	}
 	svc.FileName = filepath.Join(dir, fileName)
+	svc.Cipher = f.secretCipher()
 	return svc, nil
 }
 
+// secretCipher returns the cipher used to encrypt the tokens in the local auth
+// config files. The key is kept in a Kubernetes secret in the team namespace
+// so it never lives next to the config on disk. When no cluster is reachable a
+// nil cipher is returned and the config is stored in plaintext as before
+func (f *factory) secretCipher() auth.SecretCipher {
+	if f.cipherChecked {
+		return f.cipher
+	}
+	f.cipherChecked = true
+	client, ns, err := f.CreateClient()
+	if err != nil {
+		return nil
+	}
+	devNs, _, err := kube.GetDevNamespace(client, ns)
+	if err != nil {
+		return nil
+	}
+	secrets := client.CoreV1().Secrets(devNs)
+	secret, err := secrets.Get(cipherSecretName, metav1.GetOptions{})
+	if err != nil {
+		key := make([]byte, 32)
+		_, err = rand.Read(key)
+		if err != nil {
+			return nil
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: cipherSecretName,
+			},
+			Data: map[string][]byte{
+				cipherSecretKey: key,
+			},
+		}
+		secret, err = secrets.Create(secret)
+		if err != nil {
+			return nil
+		}
+	}
+	cipher, err := auth.NewAESCipher(secret.Data[cipherSecretKey])
+	if err != nil {
+		return nil
+	}
+	f.cipher = cipher
+	return cipher
+}
+
 func (f *factory) CreateJXClient() (*versioned.Clientset, string, error) {
 	config, err := f.createKubeConfig()
 	if err != nil {
@@ -19,9 +19,30 @@ const (
 	// ServiceKubernetesDashboard the kubernetes dashboard
 	ServiceKubernetesDashboard = "jenkins-x-kubernetes-dashboard"
 
+	// ConfigMapJenkins the ConfigMap holding the Jenkins configuration
+	ConfigMapJenkins = "jenkins"
+
+	// ConfigMapKeyConfigXml the ConfigMap entry holding the Jenkins config.xml
+	ConfigMapKeyConfigXml = "config.xml"
+
+	// SecretJenkins the secret name holding the Jenkins admin credentials
+	SecretJenkins = "jenkins"
+
+	// JenkinsAdminUserField the secret entry holding the Jenkins admin user name
+	JenkinsAdminUserField = "jenkins-admin-user"
+
+	// JenkinsAdminPasswordField the secret entry holding the Jenkins admin password
+	JenkinsAdminPasswordField = "jenkins-admin-password"
+
 	// the git credentials secret
 	SecretJenkinsGitCredentials = "jenkins-git-credentials"
 
+	// SecretJenkinsDockerConfig the docker registry credentials used by pipelines
+	SecretJenkinsDockerConfig = "jenkins-docker-cfg"
+
+	// SecretDockerRegistryPull the image pull secret for the docker registry
+	SecretDockerRegistryPull = "jenkins-x-registry-pull"
+
 	// LocalHelmRepoName is the default name of the local chart repository where CI / CD releases go to
 	LocalHelmRepoName = "releases"
 
@@ -93,6 +93,38 @@ func FindServiceURLs(client *kubernetes.Clientset, namespace string) ([]ServiceU
 	return urls, nil
 }
 
+// FindIngressURLs returns the URLs exposed by the Ingress rules in the namespace
+// which is useful for services exposed without the exposecontroller annotation
+func FindIngressURLs(client *kubernetes.Clientset, namespace string) ([]ServiceURL, error) {
+	urls := []ServiceURL{}
+	ingresses, err := client.ExtensionsV1beta1().Ingresses(namespace).List(meta_v1.ListOptions{})
+	if err != nil {
+		return urls, err
+	}
+	for _, ing := range ingresses.Items {
+		tlsHosts := map[string]bool{}
+		for _, tls := range ing.Spec.TLS {
+			for _, host := range tls.Hosts {
+				tlsHosts[host] = true
+			}
+		}
+		for _, rule := range ing.Spec.Rules {
+			if rule.Host == "" {
+				continue
+			}
+			scheme := "http"
+			if tlsHosts[rule.Host] {
+				scheme = "https"
+			}
+			urls = append(urls, ServiceURL{
+				Name: ing.Name,
+				URL:  scheme + "://" + rule.Host,
+			})
+		}
+	}
+	return urls, nil
+}
+
 // waits for the pods of a deployment to become ready
 func WaitForExternalIP(client *kubernetes.Clientset, name, namespace string, timeout time.Duration) error {
 
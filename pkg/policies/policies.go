@@ -0,0 +1,262 @@
+package policies
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// ConfigFileName is the name of the optional policy configuration file in a project or policy directory
+	ConfigFileName = "jx-policies.yml"
+
+	// SeverityWarning indicates the violation should be reported but not block the import
+	SeverityWarning = "warning"
+
+	// SeverityFailure indicates the violation should fail the import
+	SeverityFailure = "failure"
+)
+
+// Config defines the policies to evaluate against a project before it is imported
+type Config struct {
+	// RequireDockerfile requires that the project contains a Dockerfile
+	RequireDockerfile bool `json:"requireDockerfile,omitempty"`
+
+	// RequireResourceLimits requires that the helm chart declares resource limits
+	RequireResourceLimits bool `json:"requireResourceLimits,omitempty"`
+
+	// DisallowLatestTags fails if a Dockerfile or chart references a `latest` image tag
+	DisallowLatestTags bool `json:"disallowLatestTags,omitempty"`
+
+	// ApprovedBaseImages is an optional list of image prefixes which Dockerfile FROM lines must match
+	ApprovedBaseImages []string `json:"approvedBaseImages,omitempty"`
+}
+
+// Violation represents a single policy violation found in a project
+type Violation struct {
+	Policy   string
+	Message  string
+	Severity string
+}
+
+// DefaultConfig returns the policies applied when no configuration file is present
+func DefaultConfig() *Config {
+	return &Config{
+		RequireDockerfile:     true,
+		RequireResourceLimits: true,
+		DisallowLatestTags:    true,
+	}
+}
+
+// LoadConfig loads the policy configuration from the given directory if a
+// configuration file exists, otherwise it returns the default configuration
+func LoadConfig(dir string) (*Config, error) {
+	config := DefaultConfig()
+	fileName := filepath.Join(dir, ConfigFileName)
+	exists, err := util.FileExists(fileName)
+	if err != nil {
+		return config, err
+	}
+	if !exists {
+		return config, nil
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return config, fmt.Errorf("Failed to load file %s due to %s", fileName, err)
+	}
+	err = yaml.Unmarshal(data, config)
+	if err != nil {
+		return config, fmt.Errorf("Failed to unmarshal YAML file %s due to %s", fileName, err)
+	}
+	return config, nil
+}
+
+// VerifyProject evaluates the configured policies against the project in the
+// given directory returning any violations found
+func (c *Config) VerifyProject(dir string, appName string) ([]Violation, error) {
+	violations := []Violation{}
+
+	dockerfiles, err := findDockerfiles(dir)
+	if err != nil {
+		return violations, err
+	}
+	if c.RequireDockerfile && len(dockerfiles) == 0 {
+		violations = append(violations, Violation{
+			Policy:   "require-dockerfile",
+			Message:  "no Dockerfile found in the project",
+			Severity: SeverityWarning,
+		})
+	}
+	for _, dockerfile := range dockerfiles {
+		v, err := c.verifyDockerfile(dir, dockerfile)
+		if err != nil {
+			return violations, err
+		}
+		violations = append(violations, v...)
+	}
+
+	v, err := c.verifyChart(dir, appName)
+	if err != nil {
+		return violations, err
+	}
+	violations = append(violations, v...)
+	return violations, nil
+}
+
+// VerifyRegoPolicies runs conftest over the project when a policy directory of
+// rego files is supplied, returning a failure violation if any policy fails
+func VerifyRegoPolicies(dir string, policyDir string) ([]Violation, error) {
+	violations := []Violation{}
+	if policyDir == "" {
+		return violations, nil
+	}
+	exists, err := util.FileExists(policyDir)
+	if err != nil {
+		return violations, err
+	}
+	if !exists {
+		return violations, fmt.Errorf("policy directory %s does not exist", policyDir)
+	}
+	_, err = exec.LookPath("conftest")
+	if err != nil {
+		violations = append(violations, Violation{
+			Policy:   "conftest",
+			Message:  "could not find the conftest binary on the PATH so skipping the rego policies",
+			Severity: SeverityWarning,
+		})
+		return violations, nil
+	}
+	output, err := util.GetCommandOutput(dir, "conftest", "test", "--policy", policyDir, ".")
+	if err != nil {
+		for _, line := range strings.Split(output, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			violations = append(violations, Violation{
+				Policy:   "conftest",
+				Message:  line,
+				Severity: SeverityFailure,
+			})
+		}
+	}
+	return violations, nil
+}
+
+// HasFailures returns true if any of the violations have the failure severity
+func HasFailures(violations []Violation) bool {
+	for _, v := range violations {
+		if v.Severity == SeverityFailure {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Config) verifyDockerfile(dir string, name string) ([]Violation, error) {
+	violations := []Violation{}
+	data, err := ioutil.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return violations, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		text := strings.TrimSpace(line)
+		if !strings.HasPrefix(strings.ToUpper(text), "FROM ") {
+			continue
+		}
+		image := strings.TrimSpace(text[5:])
+		if c.DisallowLatestTags && (strings.HasSuffix(image, ":latest") || !strings.Contains(image, ":")) {
+			violations = append(violations, Violation{
+				Policy:   "no-latest-tags",
+				Message:  fmt.Sprintf("%s uses an unpinned or latest base image %s", name, image),
+				Severity: SeverityFailure,
+			})
+		}
+		if len(c.ApprovedBaseImages) > 0 && !isApprovedImage(image, c.ApprovedBaseImages) {
+			violations = append(violations, Violation{
+				Policy:   "approved-base-images",
+				Message:  fmt.Sprintf("%s uses base image %s which is not in the approved list", name, image),
+				Severity: SeverityFailure,
+			})
+		}
+	}
+	return violations, nil
+}
+
+func (c *Config) verifyChart(dir string, appName string) ([]Violation, error) {
+	violations := []Violation{}
+	chartDir := filepath.Join(dir, "charts", appName)
+	exists, err := util.FileExists(chartDir)
+	if err != nil || !exists {
+		return violations, err
+	}
+	if c.RequireResourceLimits {
+		valuesFile := filepath.Join(chartDir, "values.yaml")
+		data, err := ioutil.ReadFile(valuesFile)
+		if err == nil && !strings.Contains(string(data), "limits:") {
+			violations = append(violations, Violation{
+				Policy:   "require-resource-limits",
+				Message:  fmt.Sprintf("chart %s does not declare resource limits in values.yaml", chartDir),
+				Severity: SeverityWarning,
+			})
+		}
+	}
+	if c.DisallowLatestTags {
+		err = filepath.Walk(chartDir, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			if filepath.Base(path) != "values.yaml" {
+				return nil
+			}
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			for _, line := range strings.Split(string(data), "\n") {
+				text := strings.TrimSpace(line)
+				if strings.HasPrefix(text, "tag:") && strings.TrimSpace(text[4:]) == "latest" {
+					violations = append(violations, Violation{
+						Policy:   "no-latest-tags",
+						Message:  fmt.Sprintf("%s uses the latest image tag", path),
+						Severity: SeverityFailure,
+					})
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return violations, err
+		}
+	}
+	return violations, nil
+}
+
+func findDockerfiles(dir string) ([]string, error) {
+	names := []string{}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return names, err
+	}
+	for _, f := range files {
+		if !f.IsDir() && strings.HasPrefix(f.Name(), "Dockerfile") {
+			names = append(names, f.Name())
+		}
+	}
+	return names, nil
+}
+
+func isApprovedImage(image string, approved []string) bool {
+	for _, prefix := range approved {
+		if strings.HasPrefix(image, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -145,6 +145,13 @@ func (model *SpringBootModel) CreateSurvey(data *SpringBootForm, advanced bool,
 
 	var qs = []*survey.Question{}
 	if batchMode {
+		// we cannot prompt for the missing values so default or fail fast
+		if data.GroupId == "" {
+			data.GroupId = model.GroupId.Default
+		}
+		if data.ArtifactId == "" {
+			return util.MissingOption(OptionArtifactId)
+		}
 		return nil
 	}
 	if data.Language == "" {
@@ -308,10 +315,14 @@ func (data *SpringBootForm) CreateProject(workDir string) (string, error) {
 	if err != nil {
 		return answer, err
 	}
+	defer res.Body.Close()
 	body, err := ioutil.ReadAll(res.Body)
 	if err != nil {
 		return answer, err
 	}
+	if res.StatusCode >= 400 {
+		return answer, fmt.Errorf("Failed to create the project at %s: status %s: %s", u, res.Status, strings.TrimSpace(string(body)))
+	}
 
 	dir := filepath.Join(workDir, dirName)
 	zipFile := dir + ".zip"
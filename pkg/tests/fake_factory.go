@@ -0,0 +1,147 @@
+package tests
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/jenkins-x/golang-jenkins"
+	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/table"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"k8s.io/client-go/kubernetes"
+
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	metricsclient "k8s.io/metrics/pkg/client/clientset_generated/clientset"
+)
+
+// FakeFactory is a cmdutil.Factory implementation which wires commands to a
+// fake Jenkins server and an auth configuration stored in a test directory so
+// that command level tests can run hermetically
+type FakeFactory struct {
+	// Jenkins is the fake Jenkins server commands talk to
+	Jenkins *FakeJenkins
+	// ConfigDir is the directory the auth configuration files are stored in,
+	// typically a temporary directory owned by the test
+	ConfigDir string
+	// Batch is the batch mode flag passed down by the commands
+	Batch bool
+}
+
+// NewFakeFactory creates a fake factory storing its auth configuration in the
+// given directory. Callers should invoke Close when they are done with it
+func NewFakeFactory(configDir string) *FakeFactory {
+	return &FakeFactory{
+		Jenkins:   NewFakeJenkins(),
+		ConfigDir: configDir,
+	}
+}
+
+// Close stops the fake servers owned by the factory
+func (f *FakeFactory) Close() {
+	f.Jenkins.Close()
+}
+
+// SetBatch sets the batch mode flag
+func (f *FakeFactory) SetBatch(batch bool) {
+	f.Batch = batch
+}
+
+// CreateJenkinsClient returns a client talking to the fake Jenkins server
+func (f *FakeFactory) CreateJenkinsClient() (*gojenkins.Jenkins, error) {
+	return f.Jenkins.Client(), nil
+}
+
+// GetJenkinsURL returns the URL of the fake Jenkins server
+func (f *FakeFactory) GetJenkinsURL() (string, error) {
+	return f.Jenkins.BaseURL(), nil
+}
+
+// CreateAuthConfigService creates an auth config service storing its
+// configuration in the factory config directory
+func (f *FakeFactory) CreateAuthConfigService(fileName string) (auth.AuthConfigService, error) {
+	svc := auth.AuthConfigService{}
+	svc.FileName = filepath.Join(f.ConfigDir, fileName)
+	return svc, nil
+}
+
+// CreateGitAuthConfigService returns a git auth configuration seeded with a
+// fake git server and user so that commands do not need to prompt for one
+func (f *FakeFactory) CreateGitAuthConfigService() (auth.AuthConfigService, error) {
+	svc, err := f.CreateAuthConfigService(cmdutil.GitAuthConfigFile)
+	if err != nil {
+		return svc, err
+	}
+	config, err := svc.LoadConfig()
+	if err != nil {
+		return svc, err
+	}
+	if len(config.Servers) == 0 {
+		config.Servers = []*auth.AuthServer{
+			{
+				Name: "FakeGit",
+				URL:  "fake.git",
+				Users: []*auth.UserAuth{
+					{
+						Username: "fakeuser",
+						ApiToken: "fake-token",
+					},
+				},
+			},
+		}
+		config.CurrentServer = "fake.git"
+		config.DefaultUsername = "fakeuser"
+	}
+	return svc, nil
+}
+
+// CreateJenkinsAuthConfigService returns the Jenkins auth configuration
+func (f *FakeFactory) CreateJenkinsAuthConfigService() (auth.AuthConfigService, error) {
+	return f.loadAuthConfigService(cmdutil.JenkinsAuthConfigFile)
+}
+
+// CreateChartmuseumAuthConfigService returns the chartmuseum auth configuration
+func (f *FakeFactory) CreateChartmuseumAuthConfigService() (auth.AuthConfigService, error) {
+	return f.loadAuthConfigService(cmdutil.ChartmuseumAuthConfigFile)
+}
+
+// CreateIssueTrackerAuthConfigService returns the issue tracker auth configuration
+func (f *FakeFactory) CreateIssueTrackerAuthConfigService() (auth.AuthConfigService, error) {
+	return f.loadAuthConfigService(cmdutil.IssuesAuthConfigFile)
+}
+
+func (f *FakeFactory) loadAuthConfigService(fileName string) (auth.AuthConfigService, error) {
+	svc, err := f.CreateAuthConfigService(fileName)
+	if err != nil {
+		return svc, err
+	}
+	_, err = svc.LoadConfig()
+	return svc, err
+}
+
+// CreateClient is not supported by the fake factory as no Kubernetes cluster
+// is available in hermetic tests
+func (f *FakeFactory) CreateClient() (*kubernetes.Clientset, string, error) {
+	return nil, "", fmt.Errorf("The fake factory does not support Kubernetes clients")
+}
+
+// CreateJXClient is not supported by the fake factory
+func (f *FakeFactory) CreateJXClient() (*versioned.Clientset, string, error) {
+	return nil, "", fmt.Errorf("The fake factory does not support Kubernetes clients")
+}
+
+// CreateApiExtensionsClient is not supported by the fake factory
+func (f *FakeFactory) CreateApiExtensionsClient() (*apiextensionsclientset.Clientset, error) {
+	return nil, fmt.Errorf("The fake factory does not support Kubernetes clients")
+}
+
+// CreateMetricsClient is not supported by the fake factory
+func (f *FakeFactory) CreateMetricsClient() (*metricsclient.Clientset, error) {
+	return nil, fmt.Errorf("The fake factory does not support Kubernetes clients")
+}
+
+// CreateTable creates a table for console output
+func (f *FakeFactory) CreateTable(out io.Writer) table.Table {
+	return table.CreateTable(out)
+}
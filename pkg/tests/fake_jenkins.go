@@ -0,0 +1,248 @@
+package tests
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/jenkins-x/golang-jenkins"
+)
+
+// FakeJob is the in memory state of a job on the FakeJenkins server
+type FakeJob struct {
+	Name      string
+	Class     string
+	XML       string
+	Jobs      map[string]*FakeJob
+	LastBuild *gojenkins.Build
+}
+
+// FakeJenkins is an in memory Jenkins server which speaks just enough of the
+// Jenkins REST API for the gojenkins client so that commands which talk to
+// Jenkins can be tested hermetically
+type FakeJenkins struct {
+	// Jobs is the in memory job tree indexed by job name
+	Jobs map[string]*FakeJob
+	// Credentials are the credentials stored on the server indexed by id
+	Credentials map[string]gojenkins.Credentials
+	// TriggeredBuilds records the job paths whose builds have been triggered
+	TriggeredBuilds []string
+
+	server *httptest.Server
+}
+
+// NewFakeJenkins creates and starts a fake Jenkins server. Callers should
+// invoke Close when they are done with it
+func NewFakeJenkins() *FakeJenkins {
+	fake := &FakeJenkins{
+		Jobs:        map[string]*FakeJob{},
+		Credentials: map[string]gojenkins.Credentials{},
+	}
+	fake.server = httptest.NewServer(http.HandlerFunc(fake.handle))
+	return fake
+}
+
+// Client returns a gojenkins client talking to the fake server
+func (f *FakeJenkins) Client() *gojenkins.Jenkins {
+	auth := &gojenkins.Auth{
+		Username: "admin",
+		ApiToken: "fake-token",
+	}
+	return gojenkins.NewJenkins(auth, f.server.URL)
+}
+
+// BaseURL returns the URL of the fake server
+func (f *FakeJenkins) BaseURL() string {
+	return f.server.URL
+}
+
+// Close stops the fake server
+func (f *FakeJenkins) Close() {
+	f.server.Close()
+}
+
+// CreateFakeJob registers a job of the given class at the given path creating
+// any missing parent folders so that tests can seed state
+func (f *FakeJenkins) CreateFakeJob(class string, path ...string) *FakeJob {
+	jobs := f.Jobs
+	var job *FakeJob
+	for i, name := range path {
+		job = jobs[name]
+		if job == nil {
+			jobClass := "com.cloudbees.hudson.plugins.folder.Folder"
+			if i == len(path)-1 {
+				jobClass = class
+			}
+			job = &FakeJob{
+				Name:  name,
+				Class: jobClass,
+				Jobs:  map[string]*FakeJob{},
+			}
+			jobs[name] = job
+		}
+		jobs = job.Jobs
+	}
+	return job
+}
+
+// handle serves the subset of the Jenkins REST API used by the commands
+func (f *FakeJenkins) handle(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	parts := []string{}
+	if path != "" {
+		parts = strings.Split(path, "/")
+	}
+	if len(parts) > 0 && parts[0] == "credentials" {
+		f.handleCredentials(w, r, parts)
+		return
+	}
+	if len(parts) == 1 && parts[0] == "createItem" && r.Method == "POST" {
+		f.createJob(w, r, f.Jobs)
+		return
+	}
+
+	// walk the /job/<name>/job/<name>... prefix to find the addressed job
+	jobs := f.Jobs
+	var job *FakeJob
+	jobPath := []string{}
+	i := 0
+	for i+1 < len(parts) && parts[i] == "job" {
+		next := jobs[parts[i+1]]
+		if next == nil {
+			http.NotFound(w, r)
+			return
+		}
+		job = next
+		jobs = next.Jobs
+		jobPath = append(jobPath, parts[i+1])
+		i += 2
+	}
+	rest := strings.Join(parts[i:], "/")
+	switch {
+	case job == nil && (rest == "" || rest == "api/json"):
+		f.writeJSON(w, f.rootJSON())
+	case job == nil:
+		http.NotFound(w, r)
+	case rest == "" || rest == "api/json":
+		f.writeJSON(w, f.jobJSON(jobPath, job))
+	case rest == "config.xml" && r.Method == "GET":
+		w.Header().Set("Content-Type", "application/xml")
+		w.Write([]byte(job.XML))
+	case rest == "config.xml" && r.Method == "POST":
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		job.XML = string(data)
+	case rest == "createItem" && r.Method == "POST":
+		f.createJob(w, r, job.Jobs)
+	case rest == "build" && r.Method == "POST":
+		f.TriggeredBuilds = append(f.TriggeredBuilds, strings.Join(jobPath, "/"))
+	case rest == "lastBuild/api/json":
+		if job.LastBuild == nil {
+			http.NotFound(w, r)
+			return
+		}
+		f.writeJSON(w, job.LastBuild)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// createJob creates a job from the posted config.xml in the given job map
+func (f *FakeJenkins) createJob(w http.ResponseWriter, r *http.Request, jobs map[string]*FakeJob) {
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		http.Error(w, "Missing the name parameter", http.StatusBadRequest)
+		return
+	}
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	xml := string(data)
+	class := "org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject"
+	if strings.Contains(xml, "com.cloudbees.hudson.plugins.folder.Folder") {
+		class = "com.cloudbees.hudson.plugins.folder.Folder"
+	}
+	jobs[name] = &FakeJob{
+		Name:  name,
+		Class: class,
+		XML:   xml,
+		Jobs:  map[string]*FakeJob{},
+	}
+}
+
+// handleCredentials serves the credentials store endpoints
+func (f *FakeJenkins) handleCredentials(w http.ResponseWriter, r *http.Request, parts []string) {
+	if r.Method == "POST" && parts[len(parts)-1] == "createCredentials" {
+		err := r.ParseForm()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		credential := gojenkins.Credential{}
+		err = json.Unmarshal([]byte(r.Form.Get("json")), &credential)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.Credentials[credential.Credentials.Id] = credential.Credentials
+		return
+	}
+	if len(parts) >= 7 && parts[5] == "credentials" {
+		credentials, ok := f.Credentials[parts[6]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		f.writeJSON(w, &credentials)
+		return
+	}
+	http.NotFound(w, r)
+}
+
+// rootJSON returns the JSON document of the Jenkins root listing the top level jobs
+func (f *FakeJenkins) rootJSON() interface{} {
+	jobs := []gojenkins.Job{}
+	for name, job := range f.Jobs {
+		jobs = append(jobs, f.jobJSON([]string{name}, job))
+	}
+	return struct {
+		Jobs []gojenkins.Job `json:"jobs"`
+	}{Jobs: jobs}
+}
+
+// jobJSON returns the JSON document of the job at the given path
+func (f *FakeJenkins) jobJSON(jobPath []string, job *FakeJob) gojenkins.Job {
+	url := f.server.URL
+	for _, name := range jobPath {
+		url += "/job/" + name
+	}
+	url += "/"
+	answer := gojenkins.Job{
+		Name:     job.Name,
+		FullName: strings.Join(jobPath, "/"),
+		Class:    job.Class,
+		Url:      url,
+	}
+	for name, child := range job.Jobs {
+		answer.Jobs = append(answer.Jobs, f.jobJSON(append(jobPath, name), child))
+	}
+	return answer
+}
+
+// writeJSON marshals the given value as the JSON response
+func (f *FakeJenkins) writeJSON(w http.ResponseWriter, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
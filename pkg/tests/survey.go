@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"os"
+	"strings"
+)
+
+// Keys for scripting survey prompts which are not plain text answers
+const (
+	// KeyEnter accepts the current answer or selection
+	KeyEnter = "\n"
+	// KeyArrowUp moves the selection up in a select prompt
+	KeyArrowUp = "\x1b[A"
+	// KeyArrowDown moves the selection down in a select prompt
+	KeyArrowDown = "\x1b[B"
+)
+
+// ScriptedSurvey feeds scripted answers to survey prompts by replacing the
+// process standard input with a pipe so that commands which prompt the user
+// can be tested without a terminal
+type ScriptedSurvey struct {
+	original *os.File
+	reader   *os.File
+	writer   *os.File
+}
+
+// ScriptSurveyAnswers replaces standard input with the given scripted answers.
+// Plain text answers are submitted with the enter key while answers starting
+// with an escape sequence, such as KeyArrowDown, are sent as they are so that
+// select prompts can be navigated. Callers should invoke Restore when the
+// command has finished
+func ScriptSurveyAnswers(answers ...string) (*ScriptedSurvey, error) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	s := &ScriptedSurvey{
+		original: os.Stdin,
+		reader:   reader,
+		writer:   writer,
+	}
+	os.Stdin = reader
+	go func() {
+		for _, answer := range answers {
+			writer.WriteString(answer)
+			if !strings.HasPrefix(answer, "\x1b") && !strings.HasSuffix(answer, KeyEnter) {
+				writer.WriteString(KeyEnter)
+			}
+		}
+	}()
+	return s, nil
+}
+
+// Restore puts the original standard input back and closes the pipe
+func (s *ScriptedSurvey) Restore() {
+	os.Stdin = s.original
+	s.writer.Close()
+	s.reader.Close()
+}
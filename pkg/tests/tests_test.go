@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jenkins"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeJenkins(t *testing.T) {
+	fake := NewFakeJenkins()
+	defer fake.Close()
+	client := fake.Client()
+
+	folderXml, err := jenkins.CreateFolderXml(fake.BaseURL(), "myorg")
+	assert.Nil(t, err)
+	err = client.CreateJobWithXML(folderXml, "myorg")
+	assert.Nil(t, err)
+
+	folder, err := client.GetJob("myorg")
+	assert.Nil(t, err)
+	assert.Equal(t, "com.cloudbees.hudson.plugins.folder.Folder", folder.Class)
+
+	err = client.CreateFolderJobWithXML("<project/>", "myorg", "myapp")
+	assert.Nil(t, err)
+
+	job, err := client.GetJobByPath("myorg", "myapp")
+	assert.Nil(t, err)
+	assert.Equal(t, "myapp", job.Name)
+
+	_, err = client.GetJobByPath("myorg", "does-not-exist")
+	assert.NotNil(t, err)
+	assert.True(t, client.IsErrNotFound(err))
+
+	err = client.UpdateJobWithXML("<project><disabled>false</disabled></project>", "myorg", "myapp")
+	assert.Nil(t, err)
+	configXml, err := client.GetJobConfigXML("myorg", "myapp")
+	assert.Nil(t, err)
+	assert.Contains(t, configXml, "<disabled>false</disabled>")
+
+	err = client.TriggerBranchIndexing("myorg", "myapp")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"myorg/myapp"}, fake.TriggeredBuilds)
+
+	_, err = client.GetCredential("jenkins-x-git")
+	assert.NotNil(t, err)
+	err = client.CreateCredential("jenkins-x-git", "fakeuser", "fake-token")
+	assert.Nil(t, err)
+	credential, err := client.GetCredential("jenkins-x-git")
+	assert.Nil(t, err)
+	assert.Equal(t, "fakeuser", credential.Username)
+}
+
+func TestFakeProvider(t *testing.T) {
+	provider := gits.NewFakeProvider("myorg")
+
+	repo, err := provider.CreateRepository("myorg", "myapp", false)
+	assert.Nil(t, err)
+	assert.Equal(t, "myapp", repo.Name)
+
+	repos, err := provider.ListRepositories("myorg")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(repos))
+
+	pr, err := provider.CreatePullRequest(&gits.GitPullRequestArguments{
+		Owner: "myorg",
+		Repo:  "myapp",
+		Title: "fix: a thing",
+		Head:  "fix-a-thing",
+		Base:  "master",
+	})
+	assert.Nil(t, err)
+	assert.NotNil(t, pr.Number)
+
+	err = provider.MergePullRequest(pr, "merged")
+	assert.Nil(t, err)
+	err = provider.UpdatePullRequestStatus(pr)
+	assert.Nil(t, err)
+	assert.NotNil(t, pr.Merged)
+	assert.True(t, *pr.Merged)
+}
+
+func TestScriptedSurveyImportProject(t *testing.T) {
+	configDir, err := ioutil.TempDir("", "jx-test-tests-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(configDir)
+
+	factory := NewFakeFactory(configDir)
+	defer factory.Close()
+
+	provider := gits.NewFakeProvider("myorg")
+	provider.NewFakeRepository("myorg", "myapp")
+
+	// the job already exists so replacing it avoids waiting for branch indexing
+	factory.Jenkins.CreateFakeJob("org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject", "myorg", "myapp")
+
+	// answer the prompt confirming the git user for the pipeline credentials
+	script, err := ScriptSurveyAnswers("y")
+	assert.Nil(t, err)
+	defer script.Restore()
+
+	authConfigSvc, err := factory.CreateGitAuthConfigService()
+	assert.Nil(t, err)
+
+	client, err := factory.CreateJenkinsClient()
+	assert.Nil(t, err)
+
+	out := &bytes.Buffer{}
+	err = jenkins.ImportProject(out, client, "https://fake.git/myorg/myapp.git", "", jenkins.DefaultJenkinsfile, "", false, jenkins.JobExistsReplace, false, provider, authConfigSvc, nil)
+	assert.Nil(t, err)
+
+	credential, err := client.GetCredential("jenkins-x-git")
+	assert.Nil(t, err)
+	assert.Equal(t, "fakeuser", credential.Username)
+
+	job := factory.Jenkins.Jobs["myorg"].Jobs["myapp"]
+	assert.True(t, strings.Contains(job.XML, "GitHubSCMSource"), "the job config should use the github branch source but was %s", job.XML)
+
+	fakeRepo := provider.Repositories["myorg"][0]
+	assert.Equal(t, 1, len(fakeRepo.WebHooks), "a webhook should have been registered")
+}
@@ -2,6 +2,7 @@ package util
 
 import (
 	"bytes"
+	"net"
 	"strings"
 )
 
@@ -22,3 +23,18 @@ func UrlJoin(paths ...string) string {
 	}
 	return buffer.String()
 }
+
+// IsIPv6 returns true if the given address is an IPv6 IP address
+func IsIPv6(address string) bool {
+	ip := net.ParseIP(address)
+	return ip != nil && ip.To4() == nil
+}
+
+// URLHost returns the host so that it can be used inside a URL, wrapping
+// IPv6 addresses in square brackets
+func URLHost(host string) string {
+	if IsIPv6(host) {
+		return "[" + host + "]"
+	}
+	return host
+}
@@ -10,3 +10,15 @@ func TestUrlJoin(t *testing.T) {
 	assert.Equal(t, "http://foo.bar/whatnot/thingy", UrlJoin("http://foo.bar", "whatnot", "thingy"))
 	assert.Equal(t, "http://foo.bar/whatnot/thingy/", UrlJoin("http://foo.bar/", "/whatnot/", "/thingy/"))
 }
+
+func TestIsIPv6(t *testing.T) {
+	assert.False(t, IsIPv6("10.0.0.1"))
+	assert.False(t, IsIPv6("foo.bar"))
+	assert.True(t, IsIPv6("2001:db8::1"))
+}
+
+func TestURLHost(t *testing.T) {
+	assert.Equal(t, "10.0.0.1", URLHost("10.0.0.1"))
+	assert.Equal(t, "foo.bar", URLHost("foo.bar"))
+	assert.Equal(t, "[2001:db8::1]", URLHost("2001:db8::1"))
+}